@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"rent-car/api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAdmin godoc
+// @Security ApiKeyAuth
+// @Router		/admin [POST]
+// @Summary		create an admin
+// @Description This api creates a new admin account and returns its id
+// @Tags		admin
+// @Accept		json
+// @Produce		json
+// @Param		admin body models.CreateAdmin true "admin"
+// @Success		201  {string}  string
+// @Failure		400  {object}  models.Response
+// @Failure		403  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CreateAdmin(c *gin.Context) {
+	var admin models.CreateAdmin
+
+	if err := c.ShouldBindJSON(&admin); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	if admin.Login == "" {
+		handleResponseLog(c, h.Log, "missing admin login", http.StatusBadRequest, "")
+		return
+	}
+
+	id, err := h.Services.Admin().Create(c.Request.Context(), admin)
+	if err != nil {
+		handleError(c, h.Log, "error while creating admin", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Admin was successfully created", http.StatusCreated, id)
+}
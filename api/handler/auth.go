@@ -1,10 +1,8 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
 	"rent-car/api/models"
-	"rent-car/pkg/check"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
@@ -21,24 +19,20 @@ import (
 // @Success      201  {object}  models.CustomerLoginResponse
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      423  {object}  models.Response
+// @Failure      429  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h *Handler) LoginCustomer(c *gin.Context) {
 	loginReq := models.CustomerLoginRequest{}
 
 	if err := c.ShouldBindJSON(&loginReq); err != nil {
-		handleResponseLog(c, h.Log, "error while binding body", http.StatusBadRequest, err)
-		return
-	}
-	fmt.Println("loginReq: ", loginReq)
-
-	if err := check.ValidatePassword(loginReq.Password); err != nil {
-		handleResponseLog(c, h.Log, "error while validating password", http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while binding body", err)
 		return
 	}
 
 	loginResp, err := h.Services.Auth().CustomerLogin(c.Request.Context(), loginReq)
 	if err != nil {
-		handleResponseLog(c, h.Log, "unauthorized", http.StatusUnauthorized, err)
+		handleError(c, h.Log, "error while logging in", err)
 		return
 	}
 
@@ -57,24 +51,20 @@ func (h *Handler) LoginCustomer(c *gin.Context) {
 // @Success      201  {object}  models.Response
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      429  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h *Handler) CustomerRegister(c *gin.Context) {
 	loginReq := models.CustomerRegisterRequest{}
 
 	if err := c.ShouldBindJSON(&loginReq); err != nil {
-		handleResponseLog(c, h.Log, "error while binding body", http.StatusBadRequest, err)
-		return
-	}
-	fmt.Println("loginReq: ", loginReq)
-
-	if _, err := check.ValidateEmail(loginReq.Mail); err != nil {
-		handleResponseLog(c, h.Log, "error while validating email"+loginReq.Mail, http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while binding body", err)
 		return
 	}
 
 	err := h.Services.Auth().CustomerRegister(c.Request.Context(), loginReq)
 	if err != nil {
-		handleResponseLog(c, h.Log, "", http.StatusInternalServerError, err)
+		handleError(c, h.Log, "error while registering customer", err)
 		return
 	}
 
@@ -92,31 +82,21 @@ func (h *Handler) CustomerRegister(c *gin.Context) {
 // @Success      201  {object}  models.CustomerLoginResponse
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      429  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h *Handler) CustomerRegisterConfirm(c *gin.Context) {
 	req := models.CustomerRegisterConfirm{}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		handleResponseLog(c, h.Log, "error while binding body", http.StatusBadRequest, err)
+		handleBindError(c, h.Log, "error while binding body", err)
 		return
 	}
-	fmt.Println("req: ", req)
-	
-	if _, err := check.ValidateEmail(req.Mail); err != nil {
-		handleResponseLog(c, h.Log, "error while validating email"+req.Mail, http.StatusBadRequest, err.Error())
-		return
-	}
-	
-	if err := check.ValidatePassword(req.Customer.Password); err != nil {
-		handleResponseLog(c, h.Log, "error while validating password", http.StatusBadRequest, err.Error())
-		return
-	}
-	
+
 	//login validation
 
 	confResp, err := h.Services.Auth().CustomerRegisterConfirm(c.Request.Context(), req)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while confirming", http.StatusUnauthorized, err.Error())
+		handleError(c, h.Log, "error while confirming", err)
 		return
 	}
 
@@ -141,27 +121,160 @@ func (h Handler) ChangePasswordCustomer(c *gin.Context) {
 	pass := models.ChangePassword{}
 
 	if err := c.ShouldBindJSON(&pass); err != nil {
-		handleResponseLog(c, h.Log, "error while decoding request body", http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if err := check.ValidatePassword(pass.NewPassword); err != nil {
-		handleResponseLog(c, h.Log, "error while validating new password", http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while decoding request body", err)
 		return
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(pass.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while hashing new password", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while hashing new password", err)
 		return
 	}
 	pass.NewPassword = string(hashedPassword)
 
 	msg, err := h.Services.Auth().ChangePassword(c.Request.Context(), pass)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while updating customer", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while updating customer", err)
 		return
 	}
 
 	handleResponseLog(c, h.Log, "Customer password was successfully updated", http.StatusOK, msg)
 }
+
+// AdminLogin godoc
+// @Router       /admin/login [POST]
+// @Summary      Admin login
+// @Description  Admin login
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        login body models.AdminLoginRequest true "login"
+// @Success      200  {object}  models.AdminLoginResponse
+// @Failure      400  {object}  models.Response
+// @Failure      401  {object}  models.Response
+// @Failure      423  {object}  models.Response
+// @Failure      429  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h *Handler) LoginAdmin(c *gin.Context) {
+	loginReq := models.AdminLoginRequest{}
+
+	if err := c.ShouldBindJSON(&loginReq); err != nil {
+		handleBindError(c, h.Log, "error while binding body", err)
+		return
+	}
+
+	loginResp, err := h.Services.Auth().AdminLogin(c.Request.Context(), loginReq)
+	if err != nil {
+		handleError(c, h.Log, "error while logging in", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Succes", http.StatusOK, loginResp)
+}
+
+// RefreshToken godoc
+// @Router       /auth/refresh [POST]
+// @Summary      Refresh tokens
+// @Description  Exchanges a refresh token for a new token pair. Each refresh token can be used once; reusing one revokes the whole session
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        refresh body models.RefreshTokenRequest true "refresh token"
+// @Success      200  {object}  models.RefreshTokenResponse
+// @Failure      400  {object}  models.Response
+// @Failure      401  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h *Handler) RefreshToken(c *gin.Context) {
+	req := models.RefreshTokenRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while binding body", err)
+		return
+	}
+
+	resp, err := h.Services.Auth().Refresh(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while refreshing tokens", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Succes", http.StatusOK, resp)
+}
+
+// Logout godoc
+// @Security ApiKeyAuth
+// @Router       /auth/logout [POST]
+// @Summary      Logout
+// @Description  Revokes the current access token and the refresh tokens of its session
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.Response
+// @Failure      401  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h *Handler) Logout(c *gin.Context) {
+	err := h.Services.Auth().Logout(c.Request.Context(), getAccessToken(c))
+	if err != nil {
+		handleError(c, h.Log, "error while logging out", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Logged out", http.StatusOK, "")
+}
+
+// ForgotPassword godoc
+// @Router       /customer/password/forgot [POST]
+// @Summary      Forgot password
+// @Description  Sends a password reset otp code to the customer's email
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        forgot body models.ForgotPasswordRequest true "email"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      429  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	req := models.ForgotPasswordRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while binding body", err)
+		return
+	}
+
+	if err := h.Services.Auth().ForgotPassword(c.Request.Context(), req); err != nil {
+		handleError(c, h.Log, "error while sending password reset code", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Otp sent successfull", http.StatusOK, "")
+}
+
+// ResetPassword godoc
+// @Router       /customer/password/reset [POST]
+// @Summary      Reset password
+// @Description  Sets a new customer password using the emailed otp code and signs the customer out everywhere
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        reset body models.ResetPasswordRequest true "reset"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      429  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h *Handler) ResetPassword(c *gin.Context) {
+	req := models.ResetPasswordRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while binding body", err)
+		return
+	}
+
+	err := h.Services.Auth().ResetPassword(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while resetting password", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Password was successfully reset", http.StatusOK, "")
+}
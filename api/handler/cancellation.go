@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"rent-car/api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CancelOrder godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/cancel [POST]
+// @Summary		cancel an order
+// @Description This api cancels a new or confirmed order and frees its car, cancelling is free until 48 hours before the rental starts, 20% of the total is kept after that and 50% from 24 hours before, nothing is refunded once the rental has started, whatever was paid above the fee is returned as refund_due
+// @Tags		order
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		cancellation body models.CancelOrder true "cancellation"
+// @Success		200  {object}  models.OrderCancellation
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CancelOrder(c *gin.Context) {
+	var req models.CancelOrder
+
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	req.OrderId = c.Param("id")
+	req.CancelledBy = data.UserID
+	req.CancelledByRole = data.UserRole
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	cancellation, err := h.Services.Order().Cancel(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while cancelling order", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Order was successfully cancelled", http.StatusOK, cancellation)
+}
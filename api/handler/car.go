@@ -1,9 +1,9 @@
 package handler
 
 import (
-	"database/sql"
 	"net/http"
 	"rent-car/api/models"
+	"rent-car/config"
 	"rent-car/pkg/check"
 	"strconv"
 
@@ -27,19 +27,13 @@ func (h *Handler) CreateCar(c *gin.Context) {
 	var carReq models.CreateCarRequest
 
 	if err := c.ShouldBindJSON(&carReq); err != nil {
-		handleResponseLog(c, h.Log, "error while reading request body", http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if err := check.ValidateCarYear(int(carReq.Year)); err != nil {
-		handleResponseLog(c, h.Log, "error while validating car year, year: "+strconv.Itoa(int(carReq.Year)), http.StatusBadRequest, err.Error())
-
+		handleBindError(c, h.Log, "error while reading request body", err)
 		return
 	}
 
 	id, err := h.Services.Car().Create(c.Request.Context(), carReq)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while creating car", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while creating car", err)
 		return
 	}
 
@@ -63,18 +57,13 @@ func (h *Handler) UpdateCar(c *gin.Context) {
 	var carReq models.UpdateCarRequest
 
 	if err := c.ShouldBindJSON(&carReq); err != nil {
-		handleResponseLog(c, h.Log, "error while reading request body", http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if err := uuid.Validate(carReq.ID); err != nil {
-		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while reading request body", err)
 		return
 	}
 
 	id, err := h.Services.Car().Update(c.Request.Context(), carReq)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while updating car", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while updating car", err)
 		return
 	}
 
@@ -85,7 +74,7 @@ func (h *Handler) UpdateCar(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router		/car/{id} [GET]
 // @Summary		get a car by its id
-// @Description This api gets a car by its id and returns its info
+// @Description This api gets a car by its id and returns its info with its photos, admins also get its damage history
 // @Tags		car
 // @Accept		json
 // @Produce		json
@@ -95,6 +84,12 @@ func (h *Handler) UpdateCar(c *gin.Context) {
 // @Failure		404  {object}  models.Response
 // @Failure		500  {object}  models.Response
 func (h *Handler) GetCarByID(c *gin.Context) {
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
 	id := c.Param("id")
 
 	if id == "" {
@@ -102,13 +97,10 @@ func (h *Handler) GetCarByID(c *gin.Context) {
 		return
 	}
 
-	car, err := h.Services.Car().GetByID(c.Request.Context(), id)
+	// damage history is for staff, customers only see the car itself
+	car, err := h.Services.Car().GetByID(c.Request.Context(), id, data.UserRole != config.CUSTOMER_ROLE)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			handleResponseLog(c, h.Log, "car not found", http.StatusNotFound, err.Error())
-			return
-		}
-		handleResponseLog(c, h.Log, "error while getting car by ID", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while getting car by ID", err)
 		return
 	}
 
@@ -126,6 +118,8 @@ func (h *Handler) GetCarByID(c *gin.Context) {
 // @Param		car query string true "cars"
 // @Param		page query int false "page"
 // @Param		limit query int false "limit"
+// @Param		sort query string false "comma separated sort keys, '-' prefix sorts descending: created_at, name, year, price, horse_power, mileage"
+// @Param		fields query string false "comma separated fields to load, the others come back empty; all by default"
 // @Success		200  {object}  models.GetAllCarsResponse
 // @Failure		400  {object}  models.Response
 // @Failure		404  {object}  models.Response
@@ -151,10 +145,17 @@ func (h *Handler) GetAllCars(c *gin.Context) {
 
 	req.Page = page
 	req.Limit = limit
+	req.Sort = ParseSortQueryParam(c)
+	req.Fields = ParseFieldsQueryParam(c)
+
+	if err := check.Struct(req); err != nil {
+		handleError(c, h.Log, "error while validating cars request", err)
+		return
+	}
 
 	cars, err := h.Services.Car().GetAll(c.Request.Context(), req)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while getting cars", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while getting cars", err)
 		return
 	}
 
@@ -170,6 +171,15 @@ func (h *Handler) GetAllCars(c *gin.Context) {
 // @Accept		json
 // @Produce		json
 // @Param		car query string true "cars"
+// @Param		from query string false "rental start date (YYYY-MM-DD), defaults to today"
+// @Param		to query string false "rental end date (YYYY-MM-DD), defaults to from"
+// @Param		brand query string false "brand"
+// @Param		colour query string false "colour"
+// @Param		year_from query int false "minimum year"
+// @Param		year_to query int false "maximum year"
+// @Param		horse_power_from query int false "minimum horse power"
+// @Param		horse_power_to query int false "maximum horse power"
+// @Param		max_price query number false "maximum daily price"
 // @Param		page query int false "page"
 // @Param		limit query int false "limit"
 // @Success		200  {object}  models.GetAvailableCarsResponse
@@ -182,6 +192,38 @@ func (h *Handler) GetAvailableCars(c *gin.Context) {
 	)
 
 	req.Search = c.Query("search")
+	req.Brand = c.Query("brand")
+	req.Colour = c.Query("colour")
+	req.From = c.Query("from")
+	req.To = c.Query("to")
+
+	ranges := map[string]*int64{
+		"year_from":        &req.YearFrom,
+		"year_to":          &req.YearTo,
+		"horse_power_from": &req.HorsePowerFrom,
+		"horse_power_to":   &req.HorsePowerTo,
+	}
+	for key, value := range ranges {
+		if c.Query(key) == "" {
+			continue
+		}
+
+		parsed, err := strconv.ParseInt(c.Query(key), 10, 64)
+		if err != nil {
+			handleResponseLog(c, h.Log, "error while parsing "+key, http.StatusBadRequest, err.Error())
+			return
+		}
+		*value = parsed
+	}
+
+	if c.Query("max_price") != "" {
+		maxPrice, err := strconv.ParseFloat(c.Query("max_price"), 64)
+		if err != nil {
+			handleResponseLog(c, h.Log, "error while parsing max_price", http.StatusBadRequest, err.Error())
+			return
+		}
+		req.MaxPrice = maxPrice
+	}
 
 	page, err := strconv.ParseUint(c.DefaultQuery("page", "1"), 10, 64)
 	if err != nil {
@@ -198,9 +240,14 @@ func (h *Handler) GetAvailableCars(c *gin.Context) {
 	req.Page = page
 	req.Limit = limit
 
+	if err := check.Struct(req); err != nil {
+		handleError(c, h.Log, "error while validating available cars request", err)
+		return
+	}
+
 	cars, err := h.Services.Car().GetAvailable(c.Request.Context(), req)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while getting available cars", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while getting available cars", err)
 		return
 	}
 
@@ -235,7 +282,7 @@ func (h *Handler) DeleteCar(c *gin.Context) {
 
 	err := h.Services.Car().Delete(c.Request.Context(), id)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while deleting car", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while deleting car", err)
 		return
 	}
 
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UploadCarPhoto godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/photos [POST]
+// @Summary		upload a car photo
+// @Description This api adds a jpeg or png photo of up to 10 MB to the end of a car's gallery and generates its thumbnail, a car's first photo becomes its primary one
+// @Tags		car
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		file formData file true "photo"
+// @Success		201  {object}  models.CarPhoto
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		413  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UploadCarPhoto(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.MAX_UPLOAD_SIZE+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handleResponseLog(c, h.Log, "error while reading file", http.StatusRequestEntityTooLarge, service.ErrFileTooLarge.Error())
+			return
+		}
+		handleResponseLog(c, h.Log, "error while reading file", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while opening file", http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	photo, err := h.Services.CarPhoto().Upload(c.Request.Context(), id, file)
+	if err != nil {
+		handleError(c, h.Log, "error while uploading car photo", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Car photo was successfully uploaded", http.StatusCreated, photo)
+}
+
+// GetCarPhotos godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/photos [GET]
+// @Summary		get car photos
+// @Description This api gets a car's gallery in display order
+// @Tags		car
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Success		200  {object}  []models.CarPhoto
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetCarPhotos(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	photos, err := h.Services.CarPhoto().GetByCarID(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, h.Log, "error while getting car photos", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Car photos were successfully gotten", http.StatusOK, photos)
+}
+
+// ReorderCarPhotos godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/photos [PUT]
+// @Summary		reorder car photos
+// @Description This api sets the display order of a car's gallery, photo_ids must list every photo of the car once
+// @Tags		car
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		order body models.ReorderCarPhotos true "order"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) ReorderCarPhotos(c *gin.Context) {
+	var req models.ReorderCarPhotos
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	req.CarId = c.Param("id")
+
+	if err := uuid.Validate(req.CarId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.Services.CarPhoto().Reorder(c.Request.Context(), req); err != nil {
+		handleError(c, h.Log, "error while reordering car photos", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Car photos were successfully reordered", http.StatusOK, req.CarId)
+}
+
+// SetPrimaryCarPhoto godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/photos/{photo_id}/primary [PUT]
+// @Summary		set the primary car photo
+// @Description This api makes a photo the one shown for the car in listings
+// @Tags		car
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		photo_id path string true "photo ID"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) SetPrimaryCarPhoto(c *gin.Context) {
+	carID, photoID, ok := h.carPhotoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Services.CarPhoto().SetPrimary(c.Request.Context(), carID, photoID); err != nil {
+		handleError(c, h.Log, "error while setting primary car photo", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Primary car photo was successfully set", http.StatusOK, photoID)
+}
+
+// DeleteCarPhoto godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/photos/{photo_id} [DELETE]
+// @Summary		delete a car photo
+// @Description This api removes a photo from a car's gallery, if it was the primary photo the next one takes its place
+// @Tags		car
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		photo_id path string true "photo ID"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) DeleteCarPhoto(c *gin.Context) {
+	carID, photoID, ok := h.carPhotoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Services.CarPhoto().Delete(c.Request.Context(), carID, photoID); err != nil {
+		handleError(c, h.Log, "error while deleting car photo", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Car photo was successfully deleted", http.StatusOK, photoID)
+}
+
+// GetPhoto godoc
+// @Router		/photo/{id} [GET]
+// @Summary		get a car photo
+// @Description This api serves a car photo
+// @Tags		car
+// @Produce		jpeg,png
+// @Param		id path string true "photo ID"
+// @Success		200  {file}    file
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetPhoto(c *gin.Context) {
+	h.servePhoto(c, false)
+}
+
+// GetPhotoThumbnail godoc
+// @Router		/photo/{id}/thumbnail [GET]
+// @Summary		get a car photo thumbnail
+// @Description This api serves the jpeg thumbnail of a car photo
+// @Tags		car
+// @Produce		jpeg
+// @Param		id path string true "photo ID"
+// @Success		200  {file}    file
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetPhotoThumbnail(c *gin.Context) {
+	h.servePhoto(c, true)
+}
+
+func (h Handler) servePhoto(c *gin.Context, thumb bool) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating photo ID", http.StatusNotFound, err.Error())
+		return
+	}
+
+	photo, file, err := h.Services.CarPhoto().Open(c.Request.Context(), id, thumb)
+	if err != nil {
+		handleError(c, h.Log, "error while getting car photo", err)
+		return
+	}
+	defer file.Close()
+
+	size, contentType := photo.Size, photo.ContentType
+	if thumb {
+		size, contentType = -1, "image/jpeg"
+	}
+
+	// a photo's files never change once uploaded
+	c.DataFromReader(http.StatusOK, size, contentType, file, map[string]string{
+		"ETag":                   strconv.Quote(photo.Checksum),
+		"Cache-Control":          "public, max-age=86400, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h Handler) carPhotoParams(c *gin.Context) (string, string, bool) {
+	carID, photoID := c.Param("id"), c.Param("photo_id")
+
+	if err := uuid.Validate(carID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+
+	if err := uuid.Validate(photoID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating photo ID", http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+
+	return carID, photoID, true
+}
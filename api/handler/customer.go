@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"rent-car/api/models"
+	"rent-car/config"
 	"rent-car/pkg/check"
 	"strconv"
 
@@ -28,22 +29,7 @@ func (h Handler) CreateCustomer(c *gin.Context) {
 	customer := models.CreateCustomer{}
 
 	if err := c.ShouldBindJSON(&customer); err != nil {
-		handleResponseLog(c, h.Log, "error while decoding request body", http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if _, err := check.ValidateEmail(customer.Email); err != nil {
-		handleResponseLog(c, h.Log, "error while validating email"+customer.Email, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if _, err := check.ValidatePhone(customer.Phone); err != nil {
-		handleResponseLog(c, h.Log, "error while validating phone", http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if err := check.ValidatePassword(customer.Password); err != nil {
-		handleResponseLog(c, h.Log, "error while validating password", http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while decoding request body", err)
 		return
 	}
 
@@ -51,14 +37,14 @@ func (h Handler) CreateCustomer(c *gin.Context) {
 		customer.Password,
 	), bcrypt.DefaultCost)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while generating customer password", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while generating customer password", err)
 		return
 	}
 	customer.Password = string(hashedPass)
 
 	id, err := h.Services.Customer().Create(c.Request.Context(), customer)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while creating customer", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while creating customer", err)
 		return
 	}
 
@@ -83,7 +69,7 @@ func (h Handler) UpdateCustomer(c *gin.Context) {
 	customer := models.UpdateCustomer{}
 
 	if err := c.ShouldBindJSON(&customer); err != nil {
-		handleResponseLog(c, h.Log, "error while decoding request body", http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while decoding request body", err)
 		return
 	}
 
@@ -94,19 +80,9 @@ func (h Handler) UpdateCustomer(c *gin.Context) {
 		handleResponseLog(c, h.Log, "error while validating id"+id, http.StatusBadRequest, err.Error())
 		return
 	}
-	if _, err := check.ValidateEmail(customer.Email); err != nil {
-		handleResponseLog(c, h.Log, "error while validating email"+customer.Email, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if _, err := check.ValidatePhone(customer.Phone); err != nil {
-		handleResponseLog(c, h.Log, "error while validating phone", http.StatusBadRequest, err.Error())
-		return
-	}
-
 	ID, err := h.Services.Customer().Update(c.Request.Context(), customer, id)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while updating customer", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while updating customer", err)
 		return
 	}
 
@@ -152,8 +128,12 @@ func (h Handler) GetCustomerByID(c *gin.Context) {
 // @Accept 			json
 // @Produce 		json
 // @Param 			search query string true "customers"
+// @Param 			verification_status query string false "unverified, pending, verified or rejected"
 // @Param 			page query uint64 false "page"
 // @Param 			limit query uint64 false "limit"
+// @Param 			cursor query string false "next_cursor of the previous page, replaces page"
+// @Param 			sort query string false "comma separated sort keys, '-' prefix sorts descending: created_at, first_name, last_name; can not be combined with cursor"
+// @Param 			fields query string false "comma separated fields to load, the others come back empty; all by default"
 // @Success 		200 {object} models.GetAllCustomersResponse
 // @Failure 		400 {object} models.Response
 // @Failure 		500 {object} models.Response
@@ -163,6 +143,8 @@ func (h Handler) GetAllCustomers(c *gin.Context) {
 	)
 
 	req.Search = c.Query("search")
+	req.VerificationStatus = c.Query("verification_status")
+	req.Cursor = c.Query("cursor")
 
 	page, err := strconv.ParseUint(c.DefaultQuery("page", "1"), 10, 64)
 	if err != nil {
@@ -178,10 +160,17 @@ func (h Handler) GetAllCustomers(c *gin.Context) {
 
 	req.Page = page
 	req.Limit = limit
+	req.Sort = ParseSortQueryParam(c)
+	req.Fields = ParseFieldsQueryParam(c)
+
+	if err := check.Struct(req); err != nil {
+		handleError(c, h.Log, "error while validating customers request", err)
+		return
+	}
 
 	customers, err := h.Services.Customer().GetAll(c.Request.Context(), req)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while getting customers", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while getting customers", err)
 		return
 	}
 
@@ -203,33 +192,43 @@ func (h Handler) GetAllCustomers(c *gin.Context) {
 // @Failure		404  {object}  models.Response
 // @Failure		500  {object}  models.Response
 func (h Handler) GetCustomerCars(c *gin.Context) {
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
 	customerID := c.Query("customerID")
 	carName := c.Query("carName")
 
+	// customers may only look at their own rentals
+	if data.UserRole == config.CUSTOMER_ROLE {
+		customerID = data.UserID
+	}
+
 	if customerID == "" && carName == "" {
 		handleResponseLog(c, h.Log, "missing customerID or carName", http.StatusBadRequest, "")
 		return
 	}
 
 	var customer models.GetCustomerCarsResponse
-	var err error
 
 	if customerID != "" && carName == "" {
 		customer, err = h.Services.Customer().GetCustomerCars(c.Request.Context(), "", customerID, true)
 		if err != nil {
-			handleResponseLog(c, h.Log, "error while getting customer cars by Customer ID", http.StatusInternalServerError, err.Error())
+			handleError(c, h.Log, "error while getting customer cars by Customer ID", err)
 			return
 		}
 	} else if carName != "" && customerID == "" {
 		customer, err = h.Services.Customer().GetCustomerCars(c.Request.Context(), carName, "", false)
 		if err != nil {
-			handleResponseLog(c, h.Log, "error while getting customer cars by Car Name", http.StatusInternalServerError, err.Error())
+			handleError(c, h.Log, "error while getting customer cars by Car Name", err)
 			return
 		}
 	} else if carName != "" && customerID != "" {
 		customer, err = h.Services.Customer().GetCustomerCars(c.Request.Context(), carName, customerID, false)
 		if err != nil {
-			handleResponseLog(c, h.Log, "error while getting customer cars by Car Name", http.StatusInternalServerError, err.Error())
+			handleError(c, h.Log, "error while getting customer cars by Car Name", err)
 			return
 		}
 	}
@@ -267,9 +266,234 @@ func (h Handler) DeleteCustomer(c *gin.Context) {
 
 	err = h.Services.Customer().Delete(c.Request.Context(), id)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while deleting customer", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while deleting customer", err)
 		return
 	}
 
 	handleResponseLog(c, h.Log, "Customer was successfully deleted/updated by Id", http.StatusOK, id)
 }
+
+// BlockCustomer godoc
+// @Security ApiKeyAuth
+// @Router		/customer/{id}/block [POST]
+// @Summary		block a customer
+// @Description This api blocks a customer from logging in and booking, until the given time or until unblocked
+// @Tags		customer
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "customer ID"
+// @Param		block body models.BlockCustomer true "block"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) BlockCustomer(c *gin.Context) {
+	var req models.BlockCustomer
+
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	req.CustomerId = c.Param("id")
+	req.AdminId = data.UserID
+
+	if err := uuid.Validate(req.CustomerId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating customer ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.Services.Customer().Block(c.Request.Context(), req); err != nil {
+		handleError(c, h.Log, "error while blocking customer", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Customer was successfully blocked", http.StatusOK, req.CustomerId)
+}
+
+// UnblockCustomer godoc
+// @Security ApiKeyAuth
+// @Router		/customer/{id}/unblock [POST]
+// @Summary		unblock a customer
+// @Description This api lifts a customer block
+// @Tags		customer
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "customer ID"
+// @Param		unblock body models.UnblockCustomer true "unblock"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UnblockCustomer(c *gin.Context) {
+	var req models.UnblockCustomer
+
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	req.CustomerId = c.Param("id")
+	req.AdminId = data.UserID
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	if err := h.Services.Customer().Unblock(c.Request.Context(), req); err != nil {
+		handleError(c, h.Log, "error while unblocking customer", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Customer was successfully unblocked", http.StatusOK, req.CustomerId)
+}
+
+// GetCustomerBlocks godoc
+// @Security ApiKeyAuth
+// @Router		/customer/{id}/blocks [GET]
+// @Summary		get customer blocks
+// @Description This api gets whether a customer is blocked and the history of who blocked and unblocked them
+// @Tags		customer
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "customer ID"
+// @Success		200  {object}  models.GetCustomerBlocksResponse
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetCustomerBlocks(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating customer ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	blocks, err := h.Services.Customer().GetBlocks(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, h.Log, "error while getting customer blocks", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Customer blocks were successfully gotten", http.StatusOK, blocks)
+}
+
+// UpdateCustomerLicence godoc
+// @Security ApiKeyAuth
+// @Router		/customer/{id}/licence [PUT]
+// @Summary		update customer licence
+// @Description This api stores the customer's driver's licence and date of birth and sends them for review
+// @Tags		customer
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "customer ID"
+// @Param		licence body models.UpdateCustomerLicence true "licence"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UpdateCustomerLicence(c *gin.Context) {
+	var req models.UpdateCustomerLicence
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	req.CustomerId = c.Param("id")
+
+	if err := uuid.Validate(req.CustomerId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating customer ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.Services.Customer().UpdateLicence(c.Request.Context(), req); err != nil {
+		handleError(c, h.Log, "error while updating customer licence", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Customer licence was sent for review", http.StatusOK, req.CustomerId)
+}
+
+// ReviewCustomerVerification godoc
+// @Security ApiKeyAuth
+// @Router		/customer/{id}/verification [POST]
+// @Summary		review customer licence
+// @Description This api verifies or rejects a customer's pending licence
+// @Tags		customer
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "customer ID"
+// @Param		review body models.ReviewCustomerVerification true "review"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) ReviewCustomerVerification(c *gin.Context) {
+	var req models.ReviewCustomerVerification
+
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	req.CustomerId = c.Param("id")
+	req.AdminId = data.UserID
+
+	if err := uuid.Validate(req.CustomerId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating customer ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.Services.Customer().ReviewVerification(c.Request.Context(), req); err != nil {
+		handleError(c, h.Log, "error while reviewing customer licence", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Customer licence was successfully reviewed", http.StatusOK, req.CustomerId)
+}
+
+// GetCustomerVerification godoc
+// @Security ApiKeyAuth
+// @Router		/customer/{id}/verification [GET]
+// @Summary		get customer verification
+// @Description This api gets the customer's licence details and review status
+// @Tags		customer
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "customer ID"
+// @Success		200  {object}  models.CustomerVerification
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetCustomerVerification(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating customer ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	verification, err := h.Services.Customer().GetVerification(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, h.Log, "error while getting customer verification", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Customer verification was successfully gotten", http.StatusOK, verification)
+}
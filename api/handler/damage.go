@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateDamageReport godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/damages [POST]
+// @Summary		report damage
+// @Description This api logs damage found on a car against the order it was rented with, the order's return inspection has to be recorded first
+// @Tags		damage
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		damage body models.CreateDamageReport true "damage"
+// @Success		201  {object}  models.DamageReport
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CreateDamageReport(c *gin.Context) {
+	var damage models.CreateDamageReport
+
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&damage); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	damage.OrderId = c.Param("id")
+	damage.ReportedBy = data.UserID
+
+	if err := uuid.Validate(damage.OrderId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.Services.Damage().Create(c.Request.Context(), damage)
+	if err != nil {
+		handleError(c, h.Log, "error while reporting damage", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Damage was successfully reported", http.StatusCreated, report)
+}
+
+// GetOrderDamageReports godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/damages [GET]
+// @Summary		get order damage reports
+// @Description This api gets the damage reported on an order with its photos
+// @Tags		damage
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Success		200  {object}  models.GetDamageReportsResponse
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetOrderDamageReports(c *gin.Context) {
+	orderID := c.Param("id")
+
+	if err := uuid.Validate(orderID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	damages, err := h.Services.Damage().GetByOrderID(c.Request.Context(), orderID)
+	if err != nil {
+		handleError(c, h.Log, "error while getting damage reports", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Damage reports were successfully gotten", http.StatusOK, damages)
+}
+
+// GetDamageReportByID godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/damages/{damage_id} [GET]
+// @Summary		get a damage report
+// @Description This api gets a single damage report of an order
+// @Tags		damage
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		damage_id path string true "damage id"
+// @Success		200  {object}  models.DamageReport
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetDamageReportByID(c *gin.Context) {
+	orderID, damageID, ok := h.damageParams(c)
+	if !ok {
+		return
+	}
+
+	damage, err := h.Services.Damage().GetByID(c.Request.Context(), orderID, damageID)
+	if err != nil {
+		handleError(c, h.Log, "error while getting damage report", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Damage report was successfully gotten", http.StatusOK, damage)
+}
+
+// UpdateDamageReport godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/damages/{damage_id} [PUT]
+// @Summary		update a damage report
+// @Description This api updates a damage report, e.g. with the actual repair cost once the car is fixed and whether the customer was charged for it
+// @Tags		damage
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		damage_id path string true "damage id"
+// @Param		damage body models.UpdateDamageReport true "damage"
+// @Success		200  {object}  models.DamageReport
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UpdateDamageReport(c *gin.Context) {
+	var damage models.UpdateDamageReport
+
+	if err := c.ShouldBindJSON(&damage); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	orderID, damageID, ok := h.damageParams(c)
+	if !ok {
+		return
+	}
+	damage.OrderId, damage.Id = orderID, damageID
+
+	report, err := h.Services.Damage().Update(c.Request.Context(), damage)
+	if err != nil {
+		handleError(c, h.Log, "error while updating damage report", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Damage report was successfully updated", http.StatusOK, report)
+}
+
+// UploadDamagePhoto godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/damages/{damage_id}/photos [POST]
+// @Summary		upload a damage photo
+// @Description This api attaches a jpeg or png photo of up to 10 MB to a damage report
+// @Tags		damage
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		damage_id path string true "damage id"
+// @Param		file formData file true "photo"
+// @Success		201  {object}  models.DamagePhoto
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		413  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UploadDamagePhoto(c *gin.Context) {
+	orderID, damageID, ok := h.damageParams(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.MAX_UPLOAD_SIZE+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handleResponseLog(c, h.Log, "error while reading file", http.StatusRequestEntityTooLarge, service.ErrFileTooLarge.Error())
+			return
+		}
+		handleResponseLog(c, h.Log, "error while reading file", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while opening file", http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	photo, err := h.Services.Damage().UploadPhoto(c.Request.Context(), orderID, damageID, file)
+	if err != nil {
+		handleError(c, h.Log, "error while uploading damage photo", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Damage photo was successfully uploaded", http.StatusCreated, photo)
+}
+
+// GetDamagePhoto godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/damages/{damage_id}/photos/{photo_id} [GET]
+// @Summary		get a damage photo
+// @Description This api serves a photo attached to a damage report
+// @Tags		damage
+// @Produce		jpeg,png
+// @Param		id path string true "order id"
+// @Param		damage_id path string true "damage id"
+// @Param		photo_id path string true "photo id"
+// @Success		200  {file}    file
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetDamagePhoto(c *gin.Context) {
+	orderID, damageID, ok := h.damageParams(c)
+	if !ok {
+		return
+	}
+
+	photoID := c.Param("photo_id")
+
+	if err := uuid.Validate(photoID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating photo ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	photo, file, err := h.Services.Damage().OpenPhoto(c.Request.Context(), orderID, damageID, photoID)
+	if err != nil {
+		handleError(c, h.Log, "error while getting damage photo", err)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, photo.Size, photo.ContentType, file, map[string]string{
+		"ETag":                   strconv.Quote(photo.Checksum),
+		"Cache-Control":          "private, max-age=3600",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h Handler) damageParams(c *gin.Context) (string, string, bool) {
+	orderID, damageID := c.Param("id"), c.Param("damage_id")
+
+	if err := uuid.Validate(orderID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+
+	if err := uuid.Validate(damageID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating damage ID", http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+
+	return orderID, damageID, true
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+	"rent-car/api/models"
+	"rent-car/pkg/check"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReleaseOrderDeposit godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/deposit/release [POST]
+// @Summary		release an order deposit
+// @Description This api releases the deposit held for a returned order, withholding the listed deductions
+// @Tags		deposit
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		release body models.ReleaseDeposit true "deductions"
+// @Success		200  {object}  models.OrderDeposit
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) ReleaseOrderDeposit(c *gin.Context) {
+	var req models.ReleaseDeposit
+
+	_, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	req.OrderId = c.Param("id")
+
+	if err := uuid.Validate(req.OrderId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deposit, err := h.Services.Deposit().Release(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while releasing deposit", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Deposit was successfully released", http.StatusOK, deposit)
+}
+
+// GetOrderDeposit godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/deposit [GET]
+// @Summary		get an order deposit
+// @Description This api gets the deposit held for an order together with its deductions
+// @Tags		deposit
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Success		200  {object}  models.OrderDeposit
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetOrderDeposit(c *gin.Context) {
+	_, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	orderID := c.Param("id")
+
+	if err := uuid.Validate(orderID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deposit, err := h.Services.Deposit().GetByOrderID(c.Request.Context(), orderID)
+	if err != nil {
+		handleError(c, h.Log, "error while getting order deposit", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Order deposit was successfully gotten", http.StatusOK, deposit)
+}
+
+// GetAllDeposits godoc
+// @Security ApiKeyAuth
+// @Router		/deposit [GET]
+// @Summary		get all deposits
+// @Description This api lists deposits for reconciliation, with the held and withheld totals of the filtered set
+// @Tags		deposit
+// @Accept		json
+// @Produce		json
+// @Param		status query string false "held, released, partially_released or withheld"
+// @Param		from query string false "captured on or after (YYYY-MM-DD)"
+// @Param		to query string false "captured on or before (YYYY-MM-DD)"
+// @Param		page query int false "page"
+// @Param		limit query int false "limit"
+// @Success		200  {object}  models.GetAllDepositsResponse
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetAllDeposits(c *gin.Context) {
+	var (
+		req = models.GetAllDepositsRequest{}
+	)
+
+	_, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	req.Status = c.Query("status")
+	req.From = c.Query("from")
+	req.To = c.Query("to")
+
+	page, err := strconv.ParseUint(c.DefaultQuery("page", "1"), 10, 64)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while parsing page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.ParseUint(c.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while parsing limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req.Page = page
+	req.Limit = limit
+
+	if err := check.Struct(req); err != nil {
+		handleError(c, h.Log, "error while validating deposits request", err)
+		return
+	}
+
+	deposits, err := h.Services.Deposit().GetAll(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while getting all deposits", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Deposits were gotten successfully", http.StatusOK, deposits)
+}
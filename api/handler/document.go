@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UploadCustomerDocument godoc
+// @Security ApiKeyAuth
+// @Router		/customer/{id}/documents [POST]
+// @Summary		upload a customer document
+// @Description This api uploads a licence scan or passport for a customer, as a jpeg, png, webp or pdf file of up to 10 MB
+// @Tags		document
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param		id path string true "customer ID"
+// @Param		type formData string true "licence or passport"
+// @Param		file formData file true "document"
+// @Success		201  {object}  models.Document
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		413  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UploadCustomerDocument(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating customer ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.uploadDocument(c, models.UploadDocument{CustomerId: id})
+}
+
+// UploadCarDocument godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/documents [POST]
+// @Summary		upload a car document
+// @Description This api uploads a registration, insurance certificate or photo for a car, photos must be jpeg, png or webp and documents may also be pdf, up to 10 MB
+// @Tags		document
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		type formData string true "registration, insurance or photo"
+// @Param		file formData file true "document"
+// @Success		201  {object}  models.Document
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		413  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UploadCarDocument(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.uploadDocument(c, models.UploadDocument{CarId: id})
+}
+
+func (h Handler) uploadDocument(c *gin.Context, req models.UploadDocument) {
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// leave room for the other form fields and the multipart framing
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, config.MAX_UPLOAD_SIZE+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handleResponseLog(c, h.Log, "error while reading file", http.StatusRequestEntityTooLarge, service.ErrFileTooLarge.Error())
+			return
+		}
+		handleResponseLog(c, h.Log, "error while reading file", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while opening file", http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	req.Type = c.PostForm("type")
+	req.FileName = fileHeader.Filename
+	req.UploadedBy = data.UserID
+	req.File = file
+
+	document, err := h.Services.Document().Upload(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while uploading document", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Document was successfully uploaded", http.StatusCreated, document)
+}
+
+// GetCustomerDocuments godoc
+// @Security ApiKeyAuth
+// @Router		/customer/{id}/documents [GET]
+// @Summary		get customer documents
+// @Description This api gets a customer's documents with short lived download links
+// @Tags		document
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "customer ID"
+// @Param		type query string false "licence or passport"
+// @Success		200  {object}  models.GetAllDocumentsResponse
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetCustomerDocuments(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating customer ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.getDocuments(c, models.GetAllDocumentsRequest{CustomerId: id, Type: c.Query("type")})
+}
+
+// GetCarDocuments godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/documents [GET]
+// @Summary		get car documents
+// @Description This api gets a car's documents with short lived download links
+// @Tags		document
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		type query string false "registration, insurance or photo"
+// @Success		200  {object}  models.GetAllDocumentsResponse
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetCarDocuments(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.getDocuments(c, models.GetAllDocumentsRequest{CarId: id, Type: c.Query("type")})
+}
+
+func (h Handler) getDocuments(c *gin.Context, req models.GetAllDocumentsRequest) {
+	documents, err := h.Services.Document().GetAll(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while getting documents", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Documents were successfully gotten", http.StatusOK, documents)
+}
+
+// DeleteDocument godoc
+// @Security ApiKeyAuth
+// @Router		/document/{id} [DELETE]
+// @Summary		delete a document
+// @Description This api deletes a document and its file
+// @Tags		document
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "document ID"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) DeleteDocument(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating document ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.Services.Document().Delete(c.Request.Context(), id); err != nil {
+		handleError(c, h.Log, "error while deleting document", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Document was successfully deleted", http.StatusOK, id)
+}
+
+// DownloadDocument godoc
+// @Router		/document/{id}/download [GET]
+// @Summary		download a document
+// @Description This api streams a document file, the link must come from a document's url field and expires shortly after it was issued
+// @Tags		document
+// @Produce		octet-stream
+// @Param		id path string true "document ID"
+// @Param		expires query string true "expiry as unix time"
+// @Param		signature query string true "signature"
+// @Success		200  {file}    file
+// @Failure		403  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) DownloadDocument(c *gin.Context) {
+	document, file, err := h.Services.Document().Download(c.Request.Context(), c.Param("id"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		handleError(c, h.Log, "error while downloading document", err)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, document.Size, document.ContentType, file, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}),
+		"ETag":                   strconv.Quote(document.Checksum),
+		"Cache-Control":          "private, no-store",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
@@ -3,12 +3,14 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"rent-car/api/models"
 	"rent-car/config"
-	"rent-car/pkg/jwt"
+	"rent-car/pkg/apperr"
 	"rent-car/pkg/logger"
 	"rent-car/service"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -47,6 +49,65 @@ func handleResponse(c *gin.Context, msg string, statusCode int, data interface{}
 	c.JSON(resp.StatusCode, resp)
 }
 
+// errorStatus is the HTTP status of each domain error code.
+var errorStatus = map[apperr.Code]int{
+	apperr.Validation:      http.StatusBadRequest,
+	apperr.Unauthorized:    http.StatusUnauthorized,
+	apperr.Forbidden:       http.StatusForbidden,
+	apperr.NotFound:        http.StatusNotFound,
+	apperr.Conflict:        http.StatusConflict,
+	apperr.TooLarge:        http.StatusRequestEntityTooLarge,
+	apperr.Locked:          http.StatusLocked,
+	apperr.TooManyRequests: http.StatusTooManyRequests,
+	apperr.Internal:        http.StatusInternalServerError,
+}
+
+// handleError responds with the status and code of the domain error in err,
+// any other error is internal.
+func handleError(c *gin.Context, log logger.ILogger, msg string, err error) {
+	appErr, ok := apperr.As(err)
+	if !ok {
+		handleResponseLog(c, log, msg, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponseLog(c, log, msg, errorStatus[appErr.Code], models.ErrorResponse{
+		Code:    string(appErr.Code),
+		Message: err.Error(),
+		Fields:  appErr.Fields,
+	})
+}
+
+// handleBindError responds to a request that failed binding, validation
+// errors list every failed field, anything else is a malformed body.
+func handleBindError(c *gin.Context, log logger.ILogger, msg string, err error) {
+	if _, ok := apperr.As(err); ok {
+		handleError(c, log, msg, err)
+		return
+	}
+	handleResponseLog(c, log, msg, http.StatusBadRequest, err.Error())
+}
+
+// errorResponse turns whatever a failed response carries into the stable
+// error body. Internal errors are only logged, clients get a generic message.
+func errorResponse(statusCode int, data interface{}) models.ErrorResponse {
+	if resp, ok := data.(models.ErrorResponse); ok {
+		return resp
+	}
+
+	if statusCode >= http.StatusInternalServerError {
+		return models.ErrorResponse{Code: string(apperr.Internal), Message: "internal server error"}
+	}
+
+	resp := models.ErrorResponse{Code: string(apperr.Validation), Message: fmt.Sprint(data)}
+	for code, status := range errorStatus {
+		if status == statusCode {
+			resp.Code = string(code)
+		}
+	}
+	return resp
+}
+
 func handleResponseLog(c *gin.Context, log logger.ILogger, msg string, statusCode int, data interface{}) {
 	resp := models.Response{}
 
@@ -61,9 +122,11 @@ func handleResponseLog(c *gin.Context, log logger.ILogger, msg string, statusCod
 	} else if statusCode >= 400 && statusCode <= 499 {
 		resp.Description = config.ERR_BADREQUEST
 		log.Error("!!!!!!!! BAD REQUEST !!!!!!!!", logger.Any("error: ", msg), logger.Int("status: ", statusCode))
+		data = errorResponse(statusCode, data)
 	} else {
 		resp.Description = config.ERR_INTERNAL_SERVER
-		log.Error("!!!!!!!! ERR_INTERNAL_SERVER !!!!!!!!", logger.Any("error: ", msg), logger.Int("status: ", statusCode))
+		log.Error("!!!!!!!! ERR_INTERNAL_SERVER !!!!!!!!", logger.Any("error: ", msg), logger.Any("reason: ", data), logger.Int("status: ", statusCode))
+		data = errorResponse(statusCode, data)
 	}
 
 	resp.StatusCode = statusCode
@@ -108,24 +171,33 @@ func ParseLimitQueryParam(c *gin.Context) (uint64, error) {
 	return limit, nil
 }
 
-func getAuthInfo(c *gin.Context) (models.AuthInfo, error) {
-	accessToken := c.GetHeader("Authorization")
-	if accessToken == "" {
-		return models.AuthInfo{}, errors.New("unauthorized")
-	}
+// ParseSortQueryParam splits sort=price,-year into its keys, a leading '-'
+// sorts that key descending.
+func ParseSortQueryParam(c *gin.Context) []string {
+	return splitQueryParam(c, "sort")
+}
 
-	m, err := jwt.ExtractClaims(accessToken)
-	if err != nil {
-		return models.AuthInfo{}, err
+// ParseFieldsQueryParam splits fields=id,name into the fields a list should
+// return, none means all of them.
+func ParseFieldsQueryParam(c *gin.Context) []string {
+	return splitQueryParam(c, "fields")
+}
+
+func splitQueryParam(c *gin.Context, key string) []string {
+	var values []string
+	for _, value := range strings.Split(c.Query(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
 	}
+	return values
+}
 
-	role := m["user_role"].(string)
-	if !(role == config.ADMIN_ROLE || role == config.CUSTOMER_ROLE) {
+func getAuthInfo(c *gin.Context) (models.AuthInfo, error) {
+	authInfo, ok := c.Get(authInfoKey)
+	if !ok {
 		return models.AuthInfo{}, errors.New("unauthorized")
 	}
 
-	return models.AuthInfo{
-		UserID:   m["user_id"].(string),
-		UserRole: role,
-	}, nil
+	return authInfo.(models.AuthInfo), nil
 }
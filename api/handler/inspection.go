@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"rent-car/api/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateOrderInspection godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/inspections [POST]
+// @Summary		record an order inspection
+// @Description This api records the odometer reading and fuel level (percent of the tank) of a car as it is picked up or returned, the pickup inspection is taken on a confirmed order and the return inspection on a picked up one
+// @Tags		order
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		inspection body models.CreateOrderInspection true "inspection"
+// @Success		201  {string}  string
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CreateOrderInspection(c *gin.Context) {
+	var inspection models.CreateOrderInspection
+
+	data, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&inspection); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	inspection.OrderId = c.Param("id")
+	inspection.InspectedBy = data.UserID
+
+	if err := uuid.Validate(inspection.OrderId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.Services.Order().CreateInspection(c.Request.Context(), inspection)
+	if err != nil {
+		handleError(c, h.Log, "error while recording inspection", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Inspection was successfully recorded", http.StatusCreated, id)
+}
+
+// GetOrderInspections godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/inspections [GET]
+// @Summary		get order inspections
+// @Description This api gets the pickup and return inspections of an order
+// @Tags		order
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Success		200  {object}  []models.OrderInspection
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetOrderInspections(c *gin.Context) {
+	orderID := c.Param("id")
+
+	if err := uuid.Validate(orderID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	inspections, err := h.Services.Order().GetInspections(c.Request.Context(), orderID)
+	if err != nil {
+		handleError(c, h.Log, "error while getting order inspections", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Order inspections were successfully gotten", http.StatusOK, inspections)
+}
@@ -0,0 +1,380 @@
+package handler
+
+import (
+	"net/http"
+	"rent-car/api/models"
+	"rent-car/pkg/check"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateMaintenance godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance [POST]
+// @Summary		schedule car maintenance
+// @Description This api schedules a maintenance window for a car, the car can not be rented for any day of it and it can not overlap a booking
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		maintenance body models.CreateMaintenance true "maintenance"
+// @Success		201  {object}  models.Maintenance
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CreateMaintenance(c *gin.Context) {
+	var req models.CreateMaintenance
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	req.CarId = c.Param("id")
+
+	if err := uuid.Validate(req.CarId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	maintenance, err := h.Services.Maintenance().Create(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while scheduling maintenance", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Maintenance was successfully scheduled", http.StatusCreated, maintenance)
+}
+
+// GetCarMaintenance godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance [GET]
+// @Summary		get car maintenance
+// @Description This api gets a car's maintenance history and scheduled windows with the total cost spent on it
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Success		200  {object}  models.GetAllMaintenanceResponse
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetCarMaintenance(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	maintenance, err := h.Services.Maintenance().GetAll(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, h.Log, "error while getting car maintenance", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Car maintenance was successfully gotten", http.StatusOK, maintenance)
+}
+
+// GetMaintenanceByID godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance/{maintenance_id} [GET]
+// @Summary		get car maintenance by id
+// @Description This api gets a single maintenance entry of a car
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		maintenance_id path string true "maintenance ID"
+// @Success		200  {object}  models.Maintenance
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetMaintenanceByID(c *gin.Context) {
+	carID, id, ok := h.maintenanceParams(c)
+	if !ok {
+		return
+	}
+
+	maintenance, err := h.Services.Maintenance().GetByID(c.Request.Context(), carID, id)
+	if err != nil {
+		handleError(c, h.Log, "error while getting maintenance", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Maintenance was successfully gotten", http.StatusOK, maintenance)
+}
+
+// UpdateMaintenance godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance/{maintenance_id} [PUT]
+// @Summary		update car maintenance
+// @Description This api reschedules a maintenance window that is not completed yet
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		maintenance_id path string true "maintenance ID"
+// @Param		maintenance body models.UpdateMaintenance true "maintenance"
+// @Success		200  {object}  models.Maintenance
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UpdateMaintenance(c *gin.Context) {
+	var req models.UpdateMaintenance
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	carID, id, ok := h.maintenanceParams(c)
+	if !ok {
+		return
+	}
+	req.CarId, req.Id = carID, id
+
+	maintenance, err := h.Services.Maintenance().Update(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while updating maintenance", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Maintenance was successfully updated", http.StatusOK, maintenance)
+}
+
+// CompleteMaintenance godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance/{maintenance_id}/complete [POST]
+// @Summary		complete car maintenance
+// @Description This api records the cost and odometer reading of finished maintenance and releases the rest of its window, the interval it was done for restarts from today
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		maintenance_id path string true "maintenance ID"
+// @Param		completion body models.CompleteMaintenance true "completion"
+// @Success		200  {object}  models.Maintenance
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CompleteMaintenance(c *gin.Context) {
+	var req models.CompleteMaintenance
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	carID, id, ok := h.maintenanceParams(c)
+	if !ok {
+		return
+	}
+	req.CarId, req.Id = carID, id
+
+	maintenance, err := h.Services.Maintenance().Complete(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while completing maintenance", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Maintenance was successfully completed", http.StatusOK, maintenance)
+}
+
+// DeleteMaintenance godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance/{maintenance_id} [DELETE]
+// @Summary		cancel car maintenance
+// @Description This api removes a scheduled maintenance window, completed maintenance is kept in the car's history
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		maintenance_id path string true "maintenance ID"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) DeleteMaintenance(c *gin.Context) {
+	carID, id, ok := h.maintenanceParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Services.Maintenance().Delete(c.Request.Context(), carID, id); err != nil {
+		handleError(c, h.Log, "error while deleting maintenance", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Maintenance was successfully deleted", http.StatusOK, id)
+}
+
+// CreateMaintenanceInterval godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance/intervals [POST]
+// @Summary		create a maintenance interval
+// @Description This api sets up recurring maintenance for a car every so many kilometres and/or days, counting from the last service or from today and the car's current mileage
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		interval body models.CreateMaintenanceInterval true "interval"
+// @Success		201  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CreateMaintenanceInterval(c *gin.Context) {
+	var req models.CreateMaintenanceInterval
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	req.CarId = c.Param("id")
+
+	if err := uuid.Validate(req.CarId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.Services.Maintenance().CreateInterval(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while creating maintenance interval", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Maintenance interval was successfully created", http.StatusCreated, id)
+}
+
+// GetMaintenanceIntervals godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance/intervals [GET]
+// @Summary		get maintenance intervals
+// @Description This api gets a car's maintenance intervals with the date and mileage each one is next due at
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Success		200  {object}  []models.MaintenanceInterval
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetMaintenanceIntervals(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	intervals, err := h.Services.Maintenance().GetIntervals(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, h.Log, "error while getting maintenance intervals", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Maintenance intervals were successfully gotten", http.StatusOK, intervals)
+}
+
+// DeleteMaintenanceInterval godoc
+// @Security ApiKeyAuth
+// @Router		/car/{id}/maintenance/intervals/{interval_id} [DELETE]
+// @Summary		delete a maintenance interval
+// @Description This api stops a recurring maintenance interval, maintenance already done for it is kept
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "car ID"
+// @Param		interval_id path string true "interval ID"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) DeleteMaintenanceInterval(c *gin.Context) {
+	carID, id := c.Param("id"), c.Param("interval_id")
+
+	if err := uuid.Validate(carID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating interval ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.Services.Maintenance().DeleteInterval(c.Request.Context(), carID, id); err != nil {
+		handleError(c, h.Log, "error while deleting maintenance interval", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Maintenance interval was successfully deleted", http.StatusOK, id)
+}
+
+// GetDueMaintenance godoc
+// @Security ApiKeyAuth
+// @Router		/maintenance/due [GET]
+// @Summary		get due maintenance
+// @Description This api lists the maintenance intervals of all cars that fall due within the next days or kilometres, overdue ones first
+// @Tags		maintenance
+// @Accept		json
+// @Produce		json
+// @Param		days query int false "days to look ahead, defaults to 14"
+// @Param		km query int false "kilometres to look ahead, defaults to 1000"
+// @Success		200  {object}  models.GetDueMaintenanceResponse
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetDueMaintenance(c *gin.Context) {
+	var req models.GetDueMaintenanceRequest
+
+	ranges := map[string]*int64{
+		"days": &req.Days,
+		"km":   &req.Km,
+	}
+	for key, value := range ranges {
+		if c.Query(key) == "" {
+			continue
+		}
+
+		parsed, err := strconv.ParseInt(c.Query(key), 10, 64)
+		if err != nil {
+			handleResponseLog(c, h.Log, "error while parsing "+key, http.StatusBadRequest, err.Error())
+			return
+		}
+		*value = parsed
+	}
+
+	if err := check.Struct(req); err != nil {
+		handleError(c, h.Log, "error while validating due maintenance request", err)
+		return
+	}
+
+	due, err := h.Services.Maintenance().GetDue(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while getting due maintenance", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Due maintenance was successfully gotten", http.StatusOK, due)
+}
+
+func (h Handler) maintenanceParams(c *gin.Context) (string, string, bool) {
+	carID, id := c.Param("id"), c.Param("maintenance_id")
+
+	if err := uuid.Validate(carID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating car ID", http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating maintenance ID", http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+
+	return carID, id, true
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const authInfoKey = "auth_info"
+
+// AuthMiddleware validates the access token once per request and stores the
+// caller in the context for getAuthInfo and the role policies below.
+func (h Handler) AuthMiddleware(c *gin.Context) {
+	accessToken := getAccessToken(c)
+	if accessToken == "" {
+		handleResponseLog(c, h.Log, "missing access token", http.StatusUnauthorized, "unauthorized")
+		c.Abort()
+		return
+	}
+
+	authInfo, err := h.Services.Auth().Authenticate(c.Request.Context(), accessToken)
+	if err != nil {
+		handleError(c, h.Log, "error while validating access token", err)
+		c.Abort()
+		return
+	}
+
+	c.Set(authInfoKey, authInfo)
+
+	c.Next()
+}
+
+func getAccessToken(c *gin.Context) string {
+	return strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+}
+
+// Allow lets the request through only for the given roles.
+func (h Handler) Allow(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authInfo, err := getAuthInfo(c)
+		if err != nil {
+			handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		if !slices.Contains(roles, authInfo.UserRole) {
+			handleResponseLog(c, h.Log, "access denied", http.StatusForbidden, "forbidden")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// CustomerOwner lets customers act only on their own profile, given by the id path param.
+func (h Handler) CustomerOwner(c *gin.Context) {
+	authInfo, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		c.Abort()
+		return
+	}
+
+	if authInfo.UserRole == config.CUSTOMER_ROLE && c.Param("id") != authInfo.UserID {
+		handleResponseLog(c, h.Log, "access denied", http.StatusForbidden, "forbidden")
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// OrderOwner lets customers act only on their own orders, given by the id path param.
+func (h Handler) OrderOwner(c *gin.Context) {
+	authInfo, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		c.Abort()
+		return
+	}
+
+	if authInfo.UserRole != config.CUSTOMER_ROLE {
+		c.Next()
+		return
+	}
+
+	customerID, err := h.Services.Order().GetCustomerID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handleError(c, h.Log, "error while getting order owner", err)
+		c.Abort()
+		return
+	}
+
+	if customerID != authInfo.UserID {
+		handleResponseLog(c, h.Log, "access denied", http.StatusForbidden, "forbidden")
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// RateLimit allows at most limit requests per sliding window for every key
+// keyFunc derives from the request. Requests without a key are not limited.
+func (h Handler) RateLimit(scope string, limit int64, window time.Duration, keyFunc func(*gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := keyFunc(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		allowed, err := h.Services.RateLimit().Allow(c.Request.Context(), scope+":"+key, limit, window)
+		if err != nil {
+			// fail open, an unavailable redis must not lock everybody out
+			h.Log.Error("error while checking rate limit", logger.Error(err))
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(window.Seconds())))
+			handleResponseLog(c, h.Log, "too many requests", http.StatusTooManyRequests, "too many requests, try again later")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ByIP keys rate limits by the client address.
+func ByIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// ByBodyField keys rate limits by a string field of the JSON body, leaving
+// the body in place for the handler.
+func ByBodyField(field string) func(*gin.Context) string {
+	return func(c *gin.Context) string {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return ""
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var m map[string]interface{}
+		if err := json.Unmarshal(body, &m); err != nil {
+			return ""
+		}
+
+		value, _ := m[field].(string)
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+}
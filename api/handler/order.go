@@ -6,6 +6,7 @@ import (
 	"rent-car/api/models"
 	"rent-car/config"
 	"rent-car/pkg"
+	"rent-car/pkg/check"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -16,14 +17,16 @@ import (
 // @Security ApiKeyAuth
 // @Router		/order [POST]
 // @Summary		create an order
-// @Description This api creates a new order and returns its id
+// @Description This api creates a new order and returns its id, an optional promo_code is checked against its validity window, usage limits, minimum rental days and car restrictions and its discount is taken off the total
 // @Tags		order
 // @Accept		json
 // @Produce		json
 // @Param		order body models.CreateOrder true "order"
 // @Success		200  {string}  string
 // @Failure		400  {object}  models.Response
+// @Failure		403  {object}  models.Response
 // @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
 // @Failure		500  {object}  models.Response
 func (h Handler) CreateOrder(c *gin.Context) {
 	var order models.CreateOrder
@@ -35,27 +38,72 @@ func (h Handler) CreateOrder(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&order); err != nil {
-		handleResponseLog(c, h.Log, "error while decoding request body", http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while decoding request body", err)
 		return
 	}
 
 	order.Status = config.STATUS_NEW
-	order.CustomerId = data.UserID
+
+	// admins book on behalf of the customer given in the body
+	if data.UserRole == config.CUSTOMER_ROLE {
+		order.CustomerId = data.UserID
+	}
+
+	if err := uuid.Validate(order.CustomerId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating customer ID", http.StatusBadRequest, err.Error())
+		return
+	}
 
 	id, err := h.Services.Order().Create(c.Request.Context(), order)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while creating order", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while creating order", err)
 		return
 	}
 
 	handleResponseLog(c, h.Log, "Order was successfully created", http.StatusOK, id)
 }
 
+// QuoteOrder godoc
+// @Security ApiKeyAuth
+// @Router		/order/quote [POST]
+// @Summary		quote an order
+// @Description This api calculates the itemized price of a rental without creating an order
+// @Tags		order
+// @Accept		json
+// @Produce		json
+// @Param		quote body models.OrderQuoteRequest true "quote"
+// @Success		200  {object}  models.OrderQuoteResponse
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) QuoteOrder(c *gin.Context) {
+	var req models.OrderQuoteRequest
+
+	_, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	quote, err := h.Services.Order().Quote(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while quoting order", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Order was successfully quoted", http.StatusOK, quote)
+}
+
 // UpdateOrder godoc
 // @Security ApiKeyAuth
 // @Router		/order/{id} [PUT]
 // @Summary		update an order
-// @Description This api updates a order by its id and returns its id
+// @Description This api updates a order by its id and returns its id, customers can edit their order while it is new, once confirmed only admins can
 // @Tags		order
 // @Accept		json
 // @Produce		json
@@ -64,45 +112,43 @@ func (h Handler) CreateOrder(c *gin.Context) {
 // @Success		200  {string}  string
 // @Failure		400  {object}  models.Response
 // @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
 // @Failure		500  {object}  models.Response
 func (h Handler) UpdateOrder(c *gin.Context) {
-	var order models.UpdateOrder
-
 	data, err := getAuthInfo(c)
 	if err != nil {
 		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
 		return
 	}
 
+	// set before binding so they are validated with the body
+	order := models.UpdateOrder{
+		Id:            c.Param("id"),
+		ChangedByRole: data.UserRole,
+	}
+
 	if err := c.ShouldBindJSON(&order); err != nil {
-		handleResponseLog(c, h.Log, "error while decoding request body", http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while decoding request body", err)
 		return
 	}
 
-	id := c.Param("id")
-	order.Id = id
-
-	order.Status = config.STATUS_NEW
-	order.CustomerId = data.UserID
-
-	if err := uuid.Validate(order.Id); err != nil {
-		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
-		return
+	if data.UserRole == config.CUSTOMER_ROLE {
+		order.CustomerId = data.UserID
 	}
 
 	if _, err := h.Services.Order().Update(c.Request.Context(), order); err != nil {
-		handleResponseLog(c, h.Log, "error while updating order", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while updating order", err)
 		return
 	}
 
-	handleResponseLog(c, h.Log, "Order was successfully updated", http.StatusOK, id)
+	handleResponseLog(c, h.Log, "Order was successfully updated", http.StatusOK, order.Id)
 }
 
-// UpdateOrder godoc
+// UpdateOrderStatus godoc
 // @Security ApiKeyAuth
 // @Router		/order [PATCH]
-// @Summary		update an order
-// @Description This api updates a order by its id and returns its id
+// @Summary		update an order status
+// @Description This api moves an order to the next status of its lifecycle and returns its order number, a pickup inspection is required to hand the car over and a return inspection to take it back, excess mileage, fuel and late return charges are added to the total on return, an order can not be confirmed while its car is overdue from a previous rental, cancelling keeps the fee of the cancellation policy
 // @Tags		order
 // @Accept		json
 // @Produce		json
@@ -110,34 +156,31 @@ func (h Handler) UpdateOrder(c *gin.Context) {
 // @Success		200  {string}  string
 // @Failure		400  {object}  models.Response
 // @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
 // @Failure		500  {object}  models.Response
 func (h Handler) UpdateOrderStatus(c *gin.Context) {
 	var order models.UpdateOrderStatus
 
-	_, err := getAuthInfo(c)
+	data, err := getAuthInfo(c)
 	if err != nil {
 		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
 		return
 	}
 
 	if err := c.ShouldBindJSON(&order); err != nil {
-		handleResponseLog(c, h.Log, "error while decoding request body", http.StatusBadRequest, err.Error())
+		handleBindError(c, h.Log, "error while decoding request body", err)
 		return
 	}
 
-	order.Status = config.STATUS_NEW
-
-	if err := uuid.Validate(order.Id); err != nil {
-		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
-		return
-	}
+	order.ChangedBy = data.UserID
+	order.ChangedByRole = data.UserRole
 
 	updated, err := h.Services.Order().UpdateStatus(c.Request.Context(), order)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while updating order", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while updating order status", err)
 		return
 	}
-	
+
 	success, err := pkg.TelegramBotFunc(updated)
 	if err != nil {
 		handleResponseLog(c, h.Log, "error while sending message to telegram bot", http.StatusBadRequest, err.Error())
@@ -178,24 +221,64 @@ func (h Handler) GetOrderByID(c *gin.Context) {
 
 	order, err := h.Services.Order().GetByID(c.Request.Context(), id)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while getting order by ID", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while getting order by ID", err)
 		return
 	}
 
 	handleResponseLog(c, h.Log, "Order was successfully gotten by Id", http.StatusOK, order)
 }
 
+// GetOrderStatusHistory godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/history [GET]
+// @Summary		get an order status history
+// @Description This api gets all status changes of an order in chronological order
+// @Tags		order
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Success		200  {object}  models.GetOrderStatusHistoryResponse
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetOrderStatusHistory(c *gin.Context) {
+	_, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	history, err := h.Services.Order().GetStatusHistory(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, h.Log, "error while getting order status history", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Order status history was successfully gotten", http.StatusOK, history)
+}
+
 // GetAllOrders godoc
 // @Security ApiKeyAuth
 // @Router		/order [GET]
 // @Summary		get all orders
-// @Description This api gets all orders and returns their info
+// @Description This api gets all orders and returns their info with the total matching the filters, pass next_cursor back as cursor to get the following page without offset paging
 // @Tags		order
 // @Accept		json
 // @Produce		json
 // @Param		order query string true "orders"
 // @Param		page query int false "page"
 // @Param		limit query int false "limit"
+// @Param		status query string false "comma separated order statuses"
+// @Param		cursor query string false "next_cursor of the previous page, replaces page"
+// @Param		sort query string false "comma separated sort keys, '-' prefix sorts descending: created_at, from_date, total_price; can not be combined with cursor"
+// @Param		fields query string false "comma separated fields to load, the others come back empty; all by default"
 // @Success		200  {object}  models.GetAllOrdersResponse
 // @Failure		400  {object}  models.Response
 // @Failure		404  {object}  models.Response
@@ -205,13 +288,18 @@ func (h Handler) GetAllOrders(c *gin.Context) {
 		req = models.GetAllOrdersRequest{}
 	)
 
-	_, err := getAuthInfo(c)
+	data, err := getAuthInfo(c)
 	if err != nil {
 		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
 		return
 	}
 
 	req.Search = c.Query("search")
+	req.Cursor = c.Query("cursor")
+
+	if data.UserRole == config.CUSTOMER_ROLE {
+		req.CustomerId = data.UserID
+	}
 
 	page, err := strconv.ParseUint(c.DefaultQuery("page", "1"), 10, 64)
 	if err != nil {
@@ -227,10 +315,18 @@ func (h Handler) GetAllOrders(c *gin.Context) {
 
 	req.Page = page
 	req.Limit = limit
+	req.Status = splitQueryParam(c, "status")
+	req.Sort = ParseSortQueryParam(c)
+	req.Fields = ParseFieldsQueryParam(c)
+
+	if err := check.Struct(req); err != nil {
+		handleError(c, h.Log, "error while validating orders request", err)
+		return
+	}
 
 	orders, err := h.Services.Order().GetAll(c.Request.Context(), req)
 	if err != nil {
-		handleResponseLog(c, h.Log, "error while getting all orders", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while getting all orders", err)
 		return
 	}
 
@@ -270,7 +366,7 @@ func (h Handler) DeleteOrder(c *gin.Context) {
 	}
 
 	if err := h.Services.Order().Delete(c.Request.Context(), id); err != nil {
-		handleResponseLog(c, h.Log, "error while deleting order", http.StatusInternalServerError, err.Error())
+		handleError(c, h.Log, "error while deleting order", err)
 		return
 	}
 
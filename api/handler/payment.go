@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"rent-car/api/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateOrderPayment godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/payments [POST]
+// @Summary		record an order payment
+// @Description This api records a charge or partial payment against an order, deposits are recorded by the deposit flow and returns its id
+// @Tags		payment
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		payment body models.CreatePayment true "payment"
+// @Success		201  {string}  string
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CreateOrderPayment(c *gin.Context) {
+	var payment models.CreatePayment
+
+	_, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&payment); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	payment.OrderId = c.Param("id")
+
+	if err := uuid.Validate(payment.OrderId); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.Services.Payment().Create(c.Request.Context(), payment)
+	if err != nil {
+		handleError(c, h.Log, "error while creating payment", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Payment was successfully created", http.StatusCreated, id)
+}
+
+// RefundOrderPayment godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/refunds [POST]
+// @Summary		refund an order payment
+// @Description This api refunds part or all of the amount paid for an order and returns the refund id
+// @Tags		payment
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Param		refund body models.CreateRefund true "refund"
+// @Success		201  {string}  string
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) RefundOrderPayment(c *gin.Context) {
+	var refund models.CreateRefund
+
+	_, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&refund); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	orderID := c.Param("id")
+
+	if err := uuid.Validate(orderID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.Services.Payment().Refund(c.Request.Context(), orderID, refund)
+	if err != nil {
+		handleError(c, h.Log, "error while refunding payment", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Refund was successfully created", http.StatusCreated, id)
+}
+
+// GetOrderPayments godoc
+// @Security ApiKeyAuth
+// @Router		/order/{id}/payments [GET]
+// @Summary		get order payments
+// @Description This api gets all payments of an order together with its paid and outstanding balance
+// @Tags		payment
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "order id"
+// @Success		200  {object}  models.GetOrderPaymentsResponse
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetOrderPayments(c *gin.Context) {
+	_, err := getAuthInfo(c)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while getting auth", http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	orderID := c.Param("id")
+
+	if err := uuid.Validate(orderID); err != nil {
+		handleResponseLog(c, h.Log, "error while validating order ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	payments, err := h.Services.Payment().GetByOrderID(c.Request.Context(), orderID)
+	if err != nil {
+		handleError(c, h.Log, "error while getting order payments", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Order payments were successfully gotten", http.StatusOK, payments)
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"net/http"
+	"rent-car/api/models"
+	"rent-car/pkg/check"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreatePromoCode godoc
+// @Security ApiKeyAuth
+// @Router		/promo [POST]
+// @Summary		create a promo code
+// @Description This api creates a percent or fixed promo code, an empty window bound, a zero limit or an empty brand and model leave that restriction off
+// @Tags		promo
+// @Accept		json
+// @Produce		json
+// @Param		promo body models.CreatePromoCode true "promo code"
+// @Success		201  {object}  models.PromoCode
+// @Failure		400  {object}  models.Response
+// @Failure		409  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) CreatePromoCode(c *gin.Context) {
+	var req models.CreatePromoCode
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	promo, err := h.Services.Promo().Create(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while creating promo code", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Promo code was successfully created", http.StatusCreated, promo)
+}
+
+// GetAllPromoCodes godoc
+// @Security ApiKeyAuth
+// @Router		/promo [GET]
+// @Summary		get all promo codes
+// @Description This api gets promo codes with how many times each was used
+// @Tags		promo
+// @Accept		json
+// @Produce		json
+// @Param		search query string false "code"
+// @Param		active query string false "true or false"
+// @Param		page query uint64 false "page"
+// @Param		limit query uint64 false "limit"
+// @Success		200  {object}  models.GetAllPromoCodesResponse
+// @Failure		400  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetAllPromoCodes(c *gin.Context) {
+	var req = models.GetAllPromoCodesRequest{}
+
+	req.Search = c.Query("search")
+	req.Active = c.Query("active")
+
+	page, err := strconv.ParseUint(c.DefaultQuery("page", "1"), 10, 64)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while parsing page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.ParseUint(c.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		handleResponseLog(c, h.Log, "error while parsing limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req.Page = page
+	req.Limit = limit
+
+	if err := check.Struct(req); err != nil {
+		handleError(c, h.Log, "error while validating promo codes request", err)
+		return
+	}
+
+	promos, err := h.Services.Promo().GetAll(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while getting all promo codes", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Promo codes were successfully gotten", http.StatusOK, promos)
+}
+
+// GetPromoCodeByID godoc
+// @Security ApiKeyAuth
+// @Router		/promo/{id} [GET]
+// @Summary		get a promo code by its id
+// @Description This api gets a promo code by its id
+// @Tags		promo
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "promo code ID"
+// @Success		200  {object}  models.PromoCode
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) GetPromoCodeByID(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating promo code ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	promo, err := h.Services.Promo().GetByID(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, h.Log, "error while getting promo code", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Promo code was successfully gotten", http.StatusOK, promo)
+}
+
+// UpdatePromoCode godoc
+// @Security ApiKeyAuth
+// @Router		/promo/{id} [PUT]
+// @Summary		update a promo code
+// @Description This api changes the discount, window, limits and restrictions of a promo code, orders that already used it keep their discount
+// @Tags		promo
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "promo code ID"
+// @Param		promo body models.UpdatePromoCode true "promo code"
+// @Success		200  {object}  models.PromoCode
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) UpdatePromoCode(c *gin.Context) {
+	var req models.UpdatePromoCode
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, h.Log, "error while decoding request body", err)
+		return
+	}
+
+	req.Id = c.Param("id")
+
+	if err := uuid.Validate(req.Id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating promo code ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	promo, err := h.Services.Promo().Update(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, h.Log, "error while updating promo code", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Promo code was successfully updated", http.StatusOK, promo)
+}
+
+// DeletePromoCode godoc
+// @Security ApiKeyAuth
+// @Router		/promo/{id} [DELETE]
+// @Summary		delete a promo code
+// @Description This api deactivates a promo code so it can not be redeemed anymore
+// @Tags		promo
+// @Accept		json
+// @Produce		json
+// @Param		id path string true "promo code ID"
+// @Success		200  {object}  models.Response
+// @Failure		400  {object}  models.Response
+// @Failure		404  {object}  models.Response
+// @Failure		500  {object}  models.Response
+func (h Handler) DeletePromoCode(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := uuid.Validate(id); err != nil {
+		handleResponseLog(c, h.Log, "error while validating promo code ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.Services.Promo().Delete(c.Request.Context(), id); err != nil {
+		handleError(c, h.Log, "error while deleting promo code", err)
+		return
+	}
+
+	handleResponseLog(c, h.Log, "Promo code was successfully deleted", http.StatusOK, id)
+}
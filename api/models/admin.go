@@ -0,0 +1,28 @@
+package models
+
+type Admin struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Login     string `json:"login"`
+	Password  string `json:"-"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type CreateAdmin struct {
+	FirstName string `json:"first_name" binding:"required,max=50"`
+	LastName  string `json:"last_name" binding:"required,max=50"`
+	Login     string `json:"login" binding:"required,max=50"`
+	Password  string `json:"password" binding:"required,password"`
+}
+
+type AdminLoginRequest struct {
+	Login    string `json:"login" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type AdminLoginResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
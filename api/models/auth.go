@@ -1,8 +1,8 @@
 package models
 
 type CustomerLoginRequest struct {
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	Login    string `json:"login" binding:"required"`
+	Password string `json:"password" binding:"required,password"`
 }
 
 type CustomerLoginResponse struct {
@@ -10,23 +10,42 @@ type CustomerLoginResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type RefreshTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ForgotPasswordRequest struct {
+	Mail string `json:"mail" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Mail        string `json:"mail" binding:"required,email"`
+	Otp         string `json:"otp" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,password"`
+}
+
 type AuthInfo struct {
 	UserID   string `json:"user_id"`
 	UserRole string `json:"user_role"`
 }
 
 type ChangePassword struct {
-	Login       string `json:"login"`
-	OldPassword string `json:"old_password"`
-	NewPassword string `json:"new_password"`
+	Login       string `json:"login" binding:"required"`
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,password"`
 }
 
 type CustomerRegisterRequest struct {
-	Mail string `json:"mail"`
+	Mail string `json:"mail" binding:"required,email,max=50"`
 }
 
 type CustomerRegisterConfirm struct {
-	Mail     string         `json:"mail"`
-	Otp      string         `json:"otp"`
-	Customer CreateCustomer `json:"customer"`
+	Mail     string           `json:"mail" binding:"required,email,max=50"`
+	Otp      string           `json:"otp" binding:"required"`
+	Customer RegisterCustomer `json:"customer"`
 }
@@ -1,16 +1,21 @@
 package models
 
 type Car struct {
-	ID         string  `json:"id"`
-	Name       string  `json:"name"`
-	Year       int64   `json:"year"`
-	Brand      string  `json:"brand"`
-	Model      string  `json:"model"`
-	HorsePower int64   `json:"horse_power"`
-	Colour     string  `json:"colour"`
-	EngineCap  float32 `json:"engine_cap"`
-	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Year           int64   `json:"year"`
+	Brand          string  `json:"brand"`
+	Model          string  `json:"model"`
+	HorsePower     int64   `json:"horse_power"`
+	Colour         string  `json:"colour"`
+	EngineCap      float32 `json:"engine_cap"`
+	Price          float64 `json:"price"`
+	Deposit        float64 `json:"deposit"`
+	Mileage        int64   `json:"mileage"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+	PrimaryPhotoId string  `json:"-"`
+	ThumbnailUrl   string  `json:"thumbnail_url"`
 }
 
 type GetCar struct {
@@ -20,44 +25,57 @@ type GetCar struct {
 }
 
 type CreateCarRequest struct {
-	Name       string  `json:"name"`
-	Year       int64   `json:"year"`
-	Brand      string  `json:"brand"`
-	Model      string  `json:"model"`
-	HorsePower int64   `json:"horse_power"`
-	Colour     string  `json:"colour"`
-	EngineCap  float32 `json:"engine_cap"`
+	Name       string  `json:"name" binding:"required,max=50"`
+	Year       int64   `json:"year" binding:"car_year"`
+	Brand      string  `json:"brand" binding:"required,max=20"`
+	Model      string  `json:"model" binding:"required,max=30"`
+	HorsePower int64   `json:"horse_power" binding:"gte=0"`
+	Colour     string  `json:"colour" binding:"max=20"`
+	EngineCap  float32 `json:"engine_cap" binding:"gte=0"`
+	Price      float64 `json:"price" binding:"gte=0"`
+	Deposit    float64 `json:"deposit" binding:"gte=0"`
+	Mileage    int64   `json:"mileage" binding:"gte=0"`
 }
 
 type UpdateCarRequest struct {
-	ID         string  `json:"id"`
-	Name       string  `json:"name"`
-	Year       int64   `json:"year"`
-	Brand      string  `json:"brand"`
-	Model      string  `json:"model"`
-	HorsePower int64   `json:"horse_power"`
-	Colour     string  `json:"colour"`
-	EngineCap  float32 `json:"engine_cap"`
+	ID         string  `json:"id" binding:"required,uuid"`
+	Name       string  `json:"name" binding:"required,max=50"`
+	Year       int64   `json:"year" binding:"car_year"`
+	Brand      string  `json:"brand" binding:"required,max=20"`
+	Model      string  `json:"model" binding:"required,max=30"`
+	HorsePower int64   `json:"horse_power" binding:"gte=0"`
+	Colour     string  `json:"colour" binding:"max=20"`
+	EngineCap  float32 `json:"engine_cap" binding:"gte=0"`
+	Price      float64 `json:"price" binding:"gte=0"`
+	Deposit    float64 `json:"deposit" binding:"gte=0"`
+	Mileage    int64   `json:"mileage" binding:"gte=0"`
 }
 
 type GetCarByIDResponse struct {
-	ID         string  `json:"id"`
-	Name       string  `json:"name"`
-	Year       int64   `json:"year"`
-	Brand      string  `json:"brand"`
-	Model      string  `json:"model"`
-	HorsePower int64   `json:"horse_power"`
-	Colour     string  `json:"colour"`
-	EngineCap  float32 `json:"engine_cap"`
-	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
-	Orders     []Car   `json:"orders"`
+	ID         string         `json:"id"`
+	Name       string         `json:"name"`
+	Year       int64          `json:"year"`
+	Brand      string         `json:"brand"`
+	Model      string         `json:"model"`
+	HorsePower int64          `json:"horse_power"`
+	Colour     string         `json:"colour"`
+	EngineCap  float32        `json:"engine_cap"`
+	Price      float64        `json:"price"`
+	Deposit    float64        `json:"deposit"`
+	Mileage    int64          `json:"mileage"`
+	CreatedAt  string         `json:"created_at"`
+	UpdatedAt  string         `json:"updated_at"`
+	Orders     []Car          `json:"orders"`
+	Photos     []CarPhoto     `json:"photos"`
+	Damages    []DamageReport `json:"damages,omitempty"`
 }
 
 type GetAllCarsRequest struct {
-	Search string `json:"search"`
-	Page   uint64 `json:"page"`
-	Limit  uint64 `json:"limit"`
+	Search string   `json:"search"`
+	Page   uint64   `json:"page"`
+	Limit  uint64   `json:"limit" binding:"gte=1,lte=100"`
+	Sort   []string `json:"sort"`
+	Fields []string `json:"fields"`
 }
 
 type GetAllCarsResponse struct {
@@ -66,12 +84,21 @@ type GetAllCarsResponse struct {
 }
 
 type GetAvailableCarsRequest struct {
-	Search string `json:"search"`
-	Page   uint64    `json:"page"`
-	Limit  uint64    `json:"limit"`
+	Search         string  `json:"search"`
+	From           string  `json:"from" binding:"required_with=To,omitempty,date"`
+	To             string  `json:"to" binding:"omitempty,date,date_gte=from"`
+	Brand          string  `json:"brand"`
+	Colour         string  `json:"colour"`
+	YearFrom       int64   `json:"year_from" binding:"gte=0"`
+	YearTo         int64   `json:"year_to" binding:"gte=0"`
+	HorsePowerFrom int64   `json:"horse_power_from" binding:"gte=0"`
+	HorsePowerTo   int64   `json:"horse_power_to" binding:"gte=0"`
+	MaxPrice       float64 `json:"max_price" binding:"gte=0"`
+	Page           uint64  `json:"page"`
+	Limit          uint64  `json:"limit"`
 }
 
 type GetAvailableCarsResponse struct {
-	Cars  []Car `json:"cars"`
-	Count uint64   `json:"count"`
+	Cars  []Car  `json:"cars"`
+	Count uint64 `json:"count"`
 }
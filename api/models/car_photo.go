@@ -0,0 +1,34 @@
+package models
+
+type CarPhoto struct {
+	Id           string `json:"id"`
+	CarId        string `json:"car_id"`
+	Position     int    `json:"position"`
+	IsPrimary    bool   `json:"is_primary"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Checksum     string `json:"checksum"`
+	StorageKey   string `json:"-"`
+	ThumbnailKey string `json:"-"`
+	Url          string `json:"url"`
+	ThumbnailUrl string `json:"thumbnail_url"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type CreateCarPhoto struct {
+	CarId        string
+	ContentType  string
+	Size         int64
+	Width        int
+	Height       int
+	Checksum     string
+	StorageKey   string
+	ThumbnailKey string
+}
+
+type ReorderCarPhotos struct {
+	CarId    string   `json:"-"`
+	PhotoIds []string `json:"photo_ids" binding:"required,min=1,dive,uuid"`
+}
@@ -10,47 +10,78 @@ type GetCustomer struct {
 }
 
 type Customer struct {
-	ID              string  `json:"id"`
-	FirstName       string  `json:"first_name"`
-	LastName        string  `json:"last_name"`
-	Email           string  `json:"email"`
-	Phone           string  `json:"phone"`
-	Address         string  `json:"address"`
-	CreatedAt       string  `json:"created_at,omitempty"`
-	UpdatedAt       string  `json:"updated_at"`
-	Orders          []Order `json:"orders,omitempty"`
-	OrdersCount     int64   `json:"orders_count"`
-	UniqueCarsCount int64   `json:"unique_cars_count"`
-	Password        string  `json:"password"`
+	ID                 string  `json:"id"`
+	FirstName          string  `json:"first_name"`
+	LastName           string  `json:"last_name"`
+	Email              string  `json:"email"`
+	Phone              string  `json:"phone"`
+	Address            string  `json:"address"`
+	LicenceNumber      string  `json:"licence_number"`
+	LicenceCountry     string  `json:"licence_country"`
+	LicenceExpiry      string  `json:"licence_expiry"`
+	DateOfBirth        string  `json:"date_of_birth"`
+	VerificationStatus string  `json:"verification_status"`
+	VerificationNote   string  `json:"verification_note"`
+	CreatedAt          string  `json:"created_at,omitempty"`
+	UpdatedAt          string  `json:"updated_at"`
+	Orders             []Order `json:"orders,omitempty"`
+	OrdersCount        int64   `json:"orders_count"`
+	UniqueCarsCount    int64   `json:"unique_cars_count"`
+	Password           string  `json:"password"`
 }
 
 type CreateCustomer struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
-	Login     string `json:"login"`
-	Password  string `json:"password"`
-	Address   string `json:"address"`
+	FirstName          string `json:"first_name" binding:"required,max=50"`
+	LastName           string `json:"last_name" binding:"required,max=50"`
+	Email              string `json:"email" binding:"required,email,max=50"`
+	Phone              string `json:"phone" binding:"required,phone,max=20"`
+	Login              string `json:"login" binding:"required,max=255"`
+	Password           string `json:"password" binding:"required,password"`
+	Address            string `json:"address" binding:"max=20"`
+	LicenceNumber      string `json:"licence_number" binding:"max=50"`
+	LicenceCountry     string `json:"licence_country" binding:"max=100"`
+	LicenceExpiry      string `json:"licence_expiry" binding:"omitempty,date"`
+	DateOfBirth        string `json:"date_of_birth" binding:"omitempty,date"`
+	VerificationStatus string `json:"-"`
+}
+
+// RegisterCustomer is the customer of a register confirmation, its email is
+// the mail the otp was sent to.
+type RegisterCustomer struct {
+	FirstName      string `json:"first_name" binding:"required,max=50"`
+	LastName       string `json:"last_name" binding:"required,max=50"`
+	Phone          string `json:"phone" binding:"required,phone,max=20"`
+	Login          string `json:"login" binding:"required,max=255"`
+	Password       string `json:"password" binding:"required,password"`
+	Address        string `json:"address" binding:"max=20"`
+	LicenceNumber  string `json:"licence_number" binding:"max=50"`
+	LicenceCountry string `json:"licence_country" binding:"max=100"`
+	LicenceExpiry  string `json:"licence_expiry" binding:"omitempty,date"`
+	DateOfBirth    string `json:"date_of_birth" binding:"omitempty,date"`
 }
 
 type UpdateCustomer struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
-	Address   string `json:"address"`
+	FirstName string `json:"first_name" binding:"required,max=50"`
+	LastName  string `json:"last_name" binding:"required,max=50"`
+	Email     string `json:"email" binding:"required,email,max=50"`
+	Phone     string `json:"phone" binding:"required,phone,max=20"`
+	Address   string `json:"address" binding:"max=20"`
 }
 
 type GetAllCustomersRequest struct {
-	Search string `json:"search"`
-	Page   uint64 `json:"page"`
-	Limit  uint64 `json:"limit"`
+	Search             string   `json:"search"`
+	VerificationStatus string   `json:"verification_status" binding:"omitempty,oneof=unverified pending verified rejected"`
+	Page               uint64   `json:"page"`
+	Limit              uint64   `json:"limit"`
+	Cursor             string   `json:"cursor"`
+	Sort               []string `json:"sort"`
+	Fields             []string `json:"fields"`
 }
 
 type GetAllCustomersResponse struct {
-	Customers []Customer `json:"customers"`
-	Count     int64      `json:"count"`
+	Customers  []Customer `json:"customers"`
+	Count      int64      `json:"count"`
+	NextCursor string     `json:"next_cursor"`
 }
 
 type GetCustomerCarsResponse struct {
@@ -64,3 +95,65 @@ type GetCustomerCars struct {
 	Duration       float64 `json:"duration"`
 	Price          float64 `json:"price"`
 }
+
+type BlockCustomer struct {
+	CustomerId string `json:"-"`
+	Reason     string `json:"reason" binding:"required"`
+	Until      string `json:"until" binding:"omitempty,date"`
+	AdminId    string `json:"-"`
+}
+
+type UnblockCustomer struct {
+	CustomerId string `json:"-" uri:"id" binding:"required,uuid"`
+	Reason     string `json:"reason"`
+	AdminId    string `json:"-"`
+}
+
+type CustomerBlockStatus struct {
+	IsBlocked    bool   `json:"is_blocked"`
+	Reason       string `json:"reason"`
+	BlockedUntil string `json:"blocked_until"`
+}
+
+type CustomerBlock struct {
+	Id           string `json:"id"`
+	CustomerId   string `json:"customer_id"`
+	Action       string `json:"action"`
+	Reason       string `json:"reason"`
+	BlockedUntil string `json:"blocked_until"`
+	AdminId      string `json:"admin_id"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type GetCustomerBlocksResponse struct {
+	Status CustomerBlockStatus `json:"status"`
+	Blocks []CustomerBlock     `json:"blocks"`
+	Count  int                 `json:"count"`
+}
+
+type UpdateCustomerLicence struct {
+	CustomerId     string `json:"-"`
+	LicenceNumber  string `json:"licence_number" binding:"required,max=50"`
+	LicenceCountry string `json:"licence_country" binding:"required,max=100"`
+	LicenceExpiry  string `json:"licence_expiry" binding:"required,date"`
+	DateOfBirth    string `json:"date_of_birth" binding:"required,date"`
+}
+
+type ReviewCustomerVerification struct {
+	CustomerId string `json:"-"`
+	Status     string `json:"status" binding:"required,oneof=verified rejected"`
+	Note       string `json:"note"`
+	AdminId    string `json:"-"`
+}
+
+type CustomerVerification struct {
+	CustomerId     string `json:"customer_id"`
+	LicenceNumber  string `json:"licence_number"`
+	LicenceCountry string `json:"licence_country"`
+	LicenceExpiry  string `json:"licence_expiry"`
+	DateOfBirth    string `json:"date_of_birth"`
+	Status         string `json:"status"`
+	Note           string `json:"note"`
+	VerifiedBy     string `json:"verified_by"`
+	VerifiedAt     string `json:"verified_at"`
+}
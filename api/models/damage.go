@@ -0,0 +1,61 @@
+package models
+
+type DamageReport struct {
+	Id                string        `json:"id"`
+	OrderId           string        `json:"order_id"`
+	CarId             string        `json:"car_id"`
+	Location          string        `json:"location"`
+	Severity          string        `json:"severity"`
+	Description       string        `json:"description"`
+	EstimatedCost     float64       `json:"estimated_cost"`
+	ActualCost        *float64      `json:"actual_cost"`
+	ChargedToCustomer bool          `json:"charged_to_customer"`
+	ReportedBy        string        `json:"reported_by"`
+	Photos            []DamagePhoto `json:"photos"`
+	CreatedAt         string        `json:"created_at"`
+	UpdatedAt         string        `json:"updated_at"`
+}
+
+type CreateDamageReport struct {
+	OrderId       string  `json:"-"`
+	Location      string  `json:"location" binding:"required,max=100"`
+	Severity      string  `json:"severity" binding:"required,oneof=minor moderate severe"`
+	Description   string  `json:"description"`
+	EstimatedCost float64 `json:"estimated_cost" binding:"gte=0"`
+	ReportedBy    string  `json:"-"`
+}
+
+type UpdateDamageReport struct {
+	Id                string   `json:"-"`
+	OrderId           string   `json:"-"`
+	Location          string   `json:"location" binding:"required,max=100"`
+	Severity          string   `json:"severity" binding:"required,oneof=minor moderate severe"`
+	Description       string   `json:"description"`
+	EstimatedCost     float64  `json:"estimated_cost" binding:"gte=0"`
+	ActualCost        *float64 `json:"actual_cost" binding:"omitempty,gte=0"`
+	ChargedToCustomer bool     `json:"charged_to_customer"`
+}
+
+type GetDamageReportsResponse struct {
+	Damages []DamageReport `json:"damages"`
+	Count   int            `json:"count"`
+}
+
+type DamagePhoto struct {
+	Id          string `json:"id"`
+	DamageId    string `json:"damage_id"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	StorageKey  string `json:"-"`
+	Url         string `json:"url"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type CreateDamagePhoto struct {
+	DamageId    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	StorageKey  string
+}
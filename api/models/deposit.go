@@ -0,0 +1,46 @@
+package models
+
+type OrderDeposit struct {
+	OrderId        string             `json:"order_id"`
+	Amount         float64            `json:"amount"`
+	Status         string             `json:"status"`
+	WithheldAmount float64            `json:"withheld_amount"`
+	ReleasedAmount float64            `json:"released_amount"`
+	CapturedAt     string             `json:"captured_at"`
+	ReleasedAt     string             `json:"released_at"`
+	Deductions     []DepositDeduction `json:"deductions"`
+}
+
+type DepositDeduction struct {
+	Id        string  `json:"id"`
+	Reason    string  `json:"reason"`
+	Amount    float64 `json:"amount"`
+	Note      string  `json:"note"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type CreateDepositDeduction struct {
+	Reason string  `json:"reason" binding:"required,oneof=damage fuel late_return"`
+	Amount float64 `json:"amount" binding:"gt=0"`
+	Note   string  `json:"note"`
+}
+
+type ReleaseDeposit struct {
+	OrderId    string                   `json:"-"`
+	Deductions []CreateDepositDeduction `json:"deductions" binding:"dive"`
+}
+
+type GetAllDepositsRequest struct {
+	Status string `json:"status" binding:"omitempty,oneof=held released partially_released withheld"`
+	From   string `json:"from" binding:"omitempty,date"`
+	To     string `json:"to" binding:"omitempty,date,date_gte=from"`
+	Page   uint64 `json:"page"`
+	Limit  uint64 `json:"limit"`
+}
+
+type GetAllDepositsResponse struct {
+	Deposits       []OrderDeposit `json:"deposits"`
+	HeldAmount     float64        `json:"held_amount"`
+	WithheldAmount float64        `json:"withheld_amount"`
+	Count          int            `json:"count"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "io"
+
+type Document struct {
+	Id          string `json:"id"`
+	CustomerId  string `json:"customer_id,omitempty"`
+	CarId       string `json:"car_id,omitempty"`
+	Type        string `json:"type"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	StorageKey  string `json:"-"`
+	UploadedBy  string `json:"uploaded_by"`
+	CreatedAt   string `json:"created_at"`
+	Url         string `json:"url"`
+	UrlExpires  string `json:"url_expires_at"`
+}
+
+type UploadDocument struct {
+	CustomerId string
+	CarId      string
+	Type       string
+	FileName   string
+	UploadedBy string
+	File       io.Reader
+}
+
+type CreateDocument struct {
+	CustomerId  string
+	CarId       string
+	Type        string
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	StorageKey  string
+	UploadedBy  string
+}
+
+type GetAllDocumentsRequest struct {
+	CustomerId string
+	CarId      string
+	Type       string
+}
+
+type GetAllDocumentsResponse struct {
+	Documents []Document `json:"documents"`
+	Count     int64      `json:"count"`
+}
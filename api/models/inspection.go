@@ -0,0 +1,33 @@
+package models
+
+type OrderInspection struct {
+	Id          string `json:"id"`
+	OrderId     string `json:"order_id"`
+	Kind        string `json:"kind"`
+	Odometer    int64  `json:"odometer"`
+	FuelLevel   int    `json:"fuel_level"`
+	InspectedBy string `json:"inspected_by"`
+	Notes       string `json:"notes"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type CreateOrderInspection struct {
+	OrderId     string `json:"-"`
+	Kind        string `json:"kind" binding:"required,oneof=pickup return"`
+	Odometer    int64  `json:"odometer" binding:"gte=0"`
+	FuelLevel   int    `json:"fuel_level" binding:"gte=0,lte=100"`
+	Notes       string `json:"notes"`
+	InspectedBy string `json:"-"`
+}
+
+type ReturnCharges struct {
+	Days                int     `json:"days"`
+	Driven              int64   `json:"driven"`
+	MileageAllowance    int64   `json:"mileage_allowance"`
+	ExcessMileage       int64   `json:"excess_mileage"`
+	ExcessMileageCharge float64 `json:"excess_mileage_charge"`
+	FuelShortfall       int     `json:"fuel_shortfall"`
+	FuelCharge          float64 `json:"fuel_charge"`
+	HoursLate           int     `json:"hours_late"`
+	LateFee             float64 `json:"late_fee"`
+}
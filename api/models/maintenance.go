@@ -0,0 +1,89 @@
+package models
+
+type Maintenance struct {
+	Id          string  `json:"id"`
+	CarId       string  `json:"car_id"`
+	IntervalId  string  `json:"interval_id"`
+	Title       string  `json:"title"`
+	Status      string  `json:"status"`
+	StartDate   string  `json:"start_date"`
+	EndDate     string  `json:"end_date"`
+	Mileage     int64   `json:"mileage"`
+	Cost        float64 `json:"cost"`
+	Notes       string  `json:"notes"`
+	CompletedAt string  `json:"completed_at"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type CreateMaintenance struct {
+	CarId      string `json:"-"`
+	IntervalId string `json:"interval_id" binding:"omitempty,uuid"`
+	Title      string `json:"title" binding:"required,max=200"`
+	StartDate  string `json:"start_date" binding:"required,date"`
+	EndDate    string `json:"end_date" binding:"omitempty,date,date_gte=start_date"`
+	Notes      string `json:"notes"`
+}
+
+type UpdateMaintenance struct {
+	Id        string `json:"-"`
+	CarId     string `json:"-"`
+	Title     string `json:"title" binding:"required,max=200"`
+	StartDate string `json:"start_date" binding:"required,date"`
+	EndDate   string `json:"end_date" binding:"omitempty,date,date_gte=start_date"`
+	Notes     string `json:"notes"`
+}
+
+type CompleteMaintenance struct {
+	Id      string  `json:"-"`
+	CarId   string  `json:"-"`
+	Mileage int64   `json:"mileage" binding:"gte=0"`
+	Cost    float64 `json:"cost" binding:"gte=0"`
+	Notes   string  `json:"notes"`
+}
+
+type GetAllMaintenanceResponse struct {
+	Maintenance []Maintenance `json:"maintenance"`
+	TotalCost   float64       `json:"total_cost"`
+	Count       int64         `json:"count"`
+}
+
+type MaintenanceInterval struct {
+	Id                 string `json:"id"`
+	CarId              string `json:"car_id"`
+	Name               string `json:"name"`
+	EveryKm            int64  `json:"every_km"`
+	EveryDays          int64  `json:"every_days"`
+	LastServiceDate    string `json:"last_service_date"`
+	LastServiceMileage int64  `json:"last_service_mileage"`
+	DueDate            string `json:"due_date"`
+	DueMileage         int64  `json:"due_mileage"`
+	CreatedAt          string `json:"created_at"`
+}
+
+type CreateMaintenanceInterval struct {
+	CarId              string `json:"-"`
+	Name               string `json:"name" binding:"required,max=100"`
+	EveryKm            int64  `json:"every_km" binding:"gte=0"`
+	EveryDays          int64  `json:"every_days" binding:"gte=0"`
+	LastServiceDate    string `json:"last_service_date" binding:"omitempty,date"`
+	LastServiceMileage int64  `json:"last_service_mileage" binding:"gte=0"`
+}
+
+type GetDueMaintenanceRequest struct {
+	Days int64 `json:"days" binding:"gte=0"`
+	Km   int64 `json:"km" binding:"gte=0"`
+}
+
+type DueMaintenance struct {
+	Interval               MaintenanceInterval `json:"interval"`
+	CarName                string              `json:"car_name"`
+	CarMileage             int64               `json:"car_mileage"`
+	Overdue                bool                `json:"overdue"`
+	ScheduledMaintenanceId string              `json:"scheduled_maintenance_id"`
+}
+
+type GetDueMaintenanceResponse struct {
+	Due   []DueMaintenance `json:"due"`
+	Count int64            `json:"count"`
+}
@@ -11,22 +11,27 @@ type Order struct {
 }
 
 type CreateOrder struct {
-	CarId      string `json:"car_id"`
-	CustomerId string `json:"customer_id"`
-	FromDate   string `json:"from_date"`
-	ToDate     string `json:"to_date"`
-	Status     string `json:"status"`
-	Paid       bool   `json:"payment_status"`
+	CarId      string  `json:"car_id" binding:"required,uuid"`
+	CustomerId string  `json:"customer_id" binding:"omitempty,uuid"`
+	FromDate   string  `json:"from_date" binding:"required,date"`
+	ToDate     string  `json:"to_date" binding:"required,date,date_gte=from_date"`
+	Status     string  `json:"status"`
+	PromoCode  string  `json:"promo_code"`
+	TotalPrice float64 `json:"-"`
+	RentalDays int     `json:"-"`
 }
 
 type UpdateOrder struct {
-	Id         string `json:"id"`
-	CarId      string `json:"car_id"`
-	CustomerId string `json:"customer_id"`
-	FromDate   string `json:"from_date"`
-	ToDate     string `json:"to_date"`
-	Status     string `json:"status"`
-	Paid       bool   `json:"payment_status"`
+	Id         string  `json:"-" uri:"id" binding:"required,uuid"`
+	CarId      string  `json:"car_id" binding:"required,uuid"`
+	CustomerId string  `json:"customer_id" binding:"required_unless=ChangedByRole customer,omitempty,uuid"`
+	FromDate   string  `json:"from_date" binding:"required,date"`
+	ToDate     string  `json:"to_date" binding:"required,date,date_gte=from_date"`
+	TotalPrice float64 `json:"-"`
+	RentalDays int     `json:"-"`
+	// the order is only changed while its status is still one of these
+	EditableStatuses []string `json:"-"`
+	ChangedByRole    string   `json:"-"`
 }
 
 type GetOrderRequest struct {
@@ -34,31 +39,72 @@ type GetOrderRequest struct {
 }
 
 type GetOrderResponse struct {
-	Id        string      `json:"id"`
-	Car       GetCar      `json:"car,omitempty"`
-	Customer  GetCustomer `json:"customer,omitempty"`
-	FromDate  string      `json:"from_date"`
-	ToDate    string      `json:"to_date"`
-	Status    string      `json:"status"`
-	Paid      bool        `json:"payment_status"`
-	CreatedAt string      `json:"created_at"`
-	UpdatedAt string      `json:"updated_at"`
+	Id         string        `json:"id"`
+	Car        GetCar        `json:"car,omitempty"`
+	Customer   GetCustomer   `json:"customer,omitempty"`
+	FromDate   string        `json:"from_date"`
+	ToDate     string        `json:"to_date"`
+	Status     string        `json:"status"`
+	Paid       bool          `json:"payment_status"`
+	TotalPrice float64       `json:"total_price"`
+	Discount   float64       `json:"discount"`
+	Deposit    *OrderDeposit `json:"deposit,omitempty"`
+	CreatedAt  string        `json:"created_at"`
+	UpdatedAt  string        `json:"updated_at"`
+
+	ExcessMileageCharge float64            `json:"excess_mileage_charge"`
+	FuelCharge          float64            `json:"fuel_charge"`
+	IsLate              bool               `json:"is_late"`
+	LateFee             float64            `json:"late_fee"`
+	Inspections         []OrderInspection  `json:"inspections,omitempty"`
+	Cancellation        *OrderCancellation `json:"cancellation,omitempty"`
 }
 
 type GetAllOrdersRequest struct {
-	Search string `json:"search"`
-	Page   uint64 `json:"page"`
-	Limit  uint64 `json:"limit"`
+	Search     string   `json:"search"`
+	CustomerId string   `json:"customer_id" binding:"omitempty,uuid"`
+	Status     []string `json:"status" binding:"dive,oneof=new confirmed picked_up returned closed cancelled no_show"`
+	Page       uint64   `json:"page"`
+	Limit      uint64   `json:"limit" binding:"gte=1,lte=100"`
+	Cursor     string   `json:"cursor"`
+	Sort       []string `json:"sort"`
+	Fields     []string `json:"fields"`
 }
 
 type GetAllOrdersResponse struct {
-	Orders []GetOrderResponse `json:"orders"`
-	Count  int                `json:"count"`
+	Orders     []GetOrderResponse `json:"orders"`
+	Count      int                `json:"count"`
+	NextCursor string             `json:"next_cursor"`
 }
 
 type UpdateOrderStatus struct {
-	Id     string `json:"id"`
-	Status string `json:"status"`
+	Id             string `json:"id" binding:"required,uuid"`
+	Status         string `json:"status" binding:"required"`
+	Note           string `json:"note"`
+	FromStatus     string `json:"-"`
+	ChangedBy      string `json:"-"`
+	ChangedByRole  string `json:"-"`
+	CaptureDeposit bool   `json:"-"`
+	// set when the car comes back, added on top of the order total
+	ReturnCharges *ReturnCharges `json:"-"`
+	// set when the order is cancelled, the fee replaces the order total
+	Cancellation *OrderCancellation `json:"-"`
+}
+
+type OrderStatusHistory struct {
+	Id            string `json:"id"`
+	OrderId       string `json:"order_id"`
+	FromStatus    string `json:"from_status"`
+	ToStatus      string `json:"to_status"`
+	ChangedBy     string `json:"changed_by"`
+	ChangedByRole string `json:"changed_by_role"`
+	Note          string `json:"note"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type GetOrderStatusHistoryResponse struct {
+	History []OrderStatusHistory `json:"history"`
+	Count   int                  `json:"count"`
 }
 
 type UpdateStatus struct {
@@ -73,3 +119,61 @@ type UpdateStatus struct {
 	ToDate         string  `json:"to_date"`
 	Paid           bool    `json:"paid"`
 }
+
+type OrderQuoteRequest struct {
+	CarId    string `json:"car_id" binding:"required,uuid"`
+	FromDate string `json:"from_date" binding:"required,date"`
+	ToDate   string `json:"to_date" binding:"required,date,date_gte=from_date"`
+}
+
+type QuoteLine struct {
+	Description string  `json:"description"`
+	Days        int     `json:"days"`
+	Rate        float64 `json:"rate"`
+	Amount      float64 `json:"amount"`
+}
+
+type OrderQuoteResponse struct {
+	CarId      string      `json:"car_id"`
+	FromDate   string      `json:"from_date"`
+	ToDate     string      `json:"to_date"`
+	Days       int         `json:"days"`
+	DailyPrice float64     `json:"daily_price"`
+	Lines      []QuoteLine `json:"lines"`
+	Subtotal   float64     `json:"subtotal"`
+	Tier       string      `json:"tier"`
+	Discount   float64     `json:"discount"`
+	Total      float64     `json:"total"`
+}
+
+type LateOrder struct {
+	OrderId       string  `json:"order_id"`
+	OrderNumber   string  `json:"order_number"`
+	CarId         string  `json:"car_id"`
+	CarName       string  `json:"car_name"`
+	DailyPrice    float64 `json:"daily_price"`
+	CustomerName  string  `json:"customer_name"`
+	CustomerEmail string  `json:"-"`
+	ToDate        string  `json:"to_date"`
+	HoursLate     int     `json:"hours_late"`
+	LateFee       float64 `json:"late_fee"`
+}
+
+type CancelOrder struct {
+	OrderId         string `json:"-" uri:"id" binding:"required,uuid"`
+	Reason          string `json:"reason" binding:"required"`
+	CancelledBy     string `json:"-"`
+	CancelledByRole string `json:"-"`
+}
+
+type OrderCancellation struct {
+	OrderId         string  `json:"order_id"`
+	Reason          string  `json:"reason"`
+	HoursBefore     int     `json:"hours_before"`
+	FeeRate         float64 `json:"fee_rate"`
+	Fee             float64 `json:"fee"`
+	RefundDue       float64 `json:"refund_due"`
+	CancelledBy     string  `json:"cancelled_by"`
+	CancelledByRole string  `json:"cancelled_by_role"`
+	CreatedAt       string  `json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+type Payment struct {
+	Id        string  `json:"id"`
+	OrderId   string  `json:"order_id"`
+	Kind      string  `json:"kind"`
+	Method    string  `json:"method"`
+	Amount    float64 `json:"amount"`
+	Reference string  `json:"reference"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type CreatePayment struct {
+	OrderId   string  `json:"-"`
+	Kind      string  `json:"kind" binding:"required,oneof=charge partial"`
+	Method    string  `json:"method" binding:"required,oneof=cash card transfer"`
+	Amount    float64 `json:"amount" binding:"gt=0"`
+	Reference string  `json:"reference" binding:"max=255"`
+}
+
+type CreateRefund struct {
+	Method    string  `json:"method" binding:"required,oneof=cash card transfer"`
+	Amount    float64 `json:"amount" binding:"gt=0"`
+	Reference string  `json:"reference" binding:"max=255"`
+}
+
+type OrderBalance struct {
+	OrderId         string  `json:"order_id"`
+	TotalPrice      float64 `json:"total_price"`
+	PaidAmount      float64 `json:"paid_amount"`
+	RefundedAmount  float64 `json:"refunded_amount"`
+	Outstanding     float64 `json:"outstanding"`
+	PaymentStatus   bool    `json:"payment_status"`
+	DepositHeld     float64 `json:"deposit_held"`
+	DepositWithheld float64 `json:"deposit_withheld"`
+}
+
+type GetOrderPaymentsResponse struct {
+	Payments []Payment    `json:"payments"`
+	Balance  OrderBalance `json:"balance"`
+	Count    int          `json:"count"`
+}
@@ -0,0 +1,59 @@
+package models
+
+type PromoCode struct {
+	Id                 string  `json:"id"`
+	Code               string  `json:"code"`
+	DiscountType       string  `json:"discount_type"`
+	DiscountValue      float64 `json:"discount_value"`
+	ValidFrom          string  `json:"valid_from"`
+	ValidTo            string  `json:"valid_to"`
+	MaxUses            int     `json:"max_uses"`
+	MaxUsesPerCustomer int     `json:"max_uses_per_customer"`
+	MinRentalDays      int     `json:"min_rental_days"`
+	Brand              string  `json:"brand"`
+	Model              string  `json:"model"`
+	Uses               int     `json:"uses"`
+	Active             bool    `json:"active"`
+	CreatedAt          string  `json:"created_at"`
+	UpdatedAt          string  `json:"updated_at"`
+}
+
+// CreatePromoCode leaves a limit or restriction unset when it is zero or empty.
+type CreatePromoCode struct {
+	Code               string  `json:"code" binding:"required,max=40"`
+	DiscountType       string  `json:"discount_type" binding:"required,oneof=percent fixed"`
+	DiscountValue      float64 `json:"discount_value" binding:"gt=0"`
+	ValidFrom          string  `json:"valid_from" binding:"omitempty,date"`
+	ValidTo            string  `json:"valid_to" binding:"omitempty,date,date_gte=valid_from"`
+	MaxUses            int     `json:"max_uses" binding:"gte=0"`
+	MaxUsesPerCustomer int     `json:"max_uses_per_customer" binding:"gte=0"`
+	MinRentalDays      int     `json:"min_rental_days" binding:"gte=0"`
+	Brand              string  `json:"brand" binding:"max=50"`
+	Model              string  `json:"model" binding:"max=50"`
+}
+
+type UpdatePromoCode struct {
+	Id                 string  `json:"-"`
+	DiscountType       string  `json:"discount_type" binding:"required,oneof=percent fixed"`
+	DiscountValue      float64 `json:"discount_value" binding:"gt=0"`
+	ValidFrom          string  `json:"valid_from" binding:"omitempty,date"`
+	ValidTo            string  `json:"valid_to" binding:"omitempty,date,date_gte=valid_from"`
+	MaxUses            int     `json:"max_uses" binding:"gte=0"`
+	MaxUsesPerCustomer int     `json:"max_uses_per_customer" binding:"gte=0"`
+	MinRentalDays      int     `json:"min_rental_days" binding:"gte=0"`
+	Brand              string  `json:"brand" binding:"max=50"`
+	Model              string  `json:"model" binding:"max=50"`
+	Active             bool    `json:"active"`
+}
+
+type GetAllPromoCodesRequest struct {
+	Search string `json:"search"`
+	Active string `json:"active" binding:"omitempty,oneof=true false"`
+	Page   uint64 `json:"page"`
+	Limit  uint64 `json:"limit" binding:"gte=1,lte=100"`
+}
+
+type GetAllPromoCodesResponse struct {
+	PromoCodes []PromoCode `json:"promo_codes"`
+	Count      int64       `json:"count"`
+}
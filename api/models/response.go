@@ -1,7 +1,17 @@
 package models
 
+import "rent-car/pkg/apperr"
+
 type Response struct {
 	StatusCode  int
 	Description string
 	Data        interface{}
 }
+
+// ErrorResponse is the Data of every failed response, Code is one of the
+// apperr codes and stays stable while Message may change.
+type ErrorResponse struct {
+	Code    string              `json:"code"`
+	Message string              `json:"message"`
+	Fields  []apperr.FieldError `json:"fields,omitempty"`
+}
@@ -1,14 +1,16 @@
 package api
 
 import (
-	"errors"
 	"fmt"
-	"net/http"
 	"rent-car/api/handler"
+	"rent-car/config"
+	"rent-car/pkg/check"
 	"rent-car/pkg/logger"
 	"rent-car/service"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
@@ -25,47 +27,130 @@ import (
 func New(services service.IServiceManager, log logger.ILogger) *gin.Engine {
 	h := handler.NewStrg(services, log)
 
+	// bound requests are checked against the binding tags of their models
+	binding.Validator = check.Binding{}
+
 	r := gin.Default()
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	r.POST("/customer/login", h.LoginCustomer)
-	r.POST("/customer/register", h.CustomerRegister)
-	r.POST("/customer/register-confirm", h.CustomerRegisterConfirm)
+	// brute force protection, per client address and per account
+	customerLoginLimit := []gin.HandlerFunc{
+		h.RateLimit("customer_login_ip", 20, time.Minute, handler.ByIP),
+		h.RateLimit("customer_login", 10, time.Minute, handler.ByBodyField("login")),
+	}
+	// admins get their own buckets so customer traffic can not lock them out
+	adminLoginLimit := []gin.HandlerFunc{
+		h.RateLimit("admin_login_ip", 20, time.Minute, handler.ByIP),
+		h.RateLimit("admin_login", 10, time.Minute, handler.ByBodyField("login")),
+	}
+	otpSendLimit := []gin.HandlerFunc{
+		h.RateLimit("otp_send_ip", 10, time.Minute, handler.ByIP),
+		h.RateLimit("otp_send", 3, 10*time.Minute, handler.ByBodyField("mail")),
+	}
+	otpVerifyLimit := []gin.HandlerFunc{
+		h.RateLimit("otp_verify_ip", 10, time.Minute, handler.ByIP),
+		h.RateLimit("otp_verify", 10, 10*time.Minute, handler.ByBodyField("mail")),
+	}
+
+	r.POST("/customer/login", append(customerLoginLimit, h.LoginCustomer)...)
+	r.POST("/customer/register", append(otpSendLimit, h.CustomerRegister)...)
+	r.POST("/customer/register-confirm", append(otpVerifyLimit, h.CustomerRegisterConfirm)...)
 	r.POST("/customer", h.CreateCustomer)
+	r.POST("/customer/password/forgot", append(otpSendLimit, h.ForgotPassword)...)
+	r.POST("/customer/password/reset", append(otpVerifyLimit, h.ResetPassword)...)
+	r.POST("/admin/login", append(adminLoginLimit, h.LoginAdmin)...)
+	r.POST("/auth/refresh", h.RefreshToken)
+
+	// download links carry their own signature in place of a token
+	r.GET("/document/:id/download", h.DownloadDocument)
 
-	//r.Use(authMiddleware)
+	// catalogue photos are public so clients and caches can load them directly
+	r.GET("/photo/:id", h.GetPhoto)
+	r.GET("/photo/:id/thumbnail", h.GetPhotoThumbnail)
+
+	r.Use(h.AuthMiddleware)
 	//r.Use(logMiddleware)
 
-	r.POST("/car", h.CreateCar)
-	r.PUT("/car/:id", h.UpdateCar)
+	// routes without a policy are open to any signed in user
+	adminOnly := h.Allow(config.ADMIN_ROLE)
+
+	r.POST("/auth/logout", h.Logout)
+
+	r.POST("/admin", adminOnly, h.CreateAdmin)
+
+	r.POST("/car", adminOnly, h.CreateCar)
+	r.PUT("/car/:id", adminOnly, h.UpdateCar)
 	r.GET("/car/:id", h.GetCarByID)
 	r.GET("/car", h.GetAllCars)
 	r.GET("car/available", h.GetAvailableCars)
-	r.DELETE("/car/:id", h.DeleteCar)
+	r.DELETE("/car/:id", adminOnly, h.DeleteCar)
+	r.POST("/car/:id/documents", adminOnly, h.UploadCarDocument)
+	r.GET("/car/:id/documents", adminOnly, h.GetCarDocuments)
+	r.POST("/car/:id/photos", adminOnly, h.UploadCarPhoto)
+	r.GET("/car/:id/photos", h.GetCarPhotos)
+	r.PUT("/car/:id/photos", adminOnly, h.ReorderCarPhotos)
+	r.PUT("/car/:id/photos/:photo_id/primary", adminOnly, h.SetPrimaryCarPhoto)
+	r.DELETE("/car/:id/photos/:photo_id", adminOnly, h.DeleteCarPhoto)
+	r.POST("/car/:id/maintenance", adminOnly, h.CreateMaintenance)
+	r.GET("/car/:id/maintenance", adminOnly, h.GetCarMaintenance)
+	r.POST("/car/:id/maintenance/intervals", adminOnly, h.CreateMaintenanceInterval)
+	r.GET("/car/:id/maintenance/intervals", adminOnly, h.GetMaintenanceIntervals)
+	r.DELETE("/car/:id/maintenance/intervals/:interval_id", adminOnly, h.DeleteMaintenanceInterval)
+	r.GET("/car/:id/maintenance/:maintenance_id", adminOnly, h.GetMaintenanceByID)
+	r.PUT("/car/:id/maintenance/:maintenance_id", adminOnly, h.UpdateMaintenance)
+	r.DELETE("/car/:id/maintenance/:maintenance_id", adminOnly, h.DeleteMaintenance)
+	r.POST("/car/:id/maintenance/:maintenance_id/complete", adminOnly, h.CompleteMaintenance)
+	r.GET("/maintenance/due", adminOnly, h.GetDueMaintenance)
 
-	r.PUT("/customer/:id", h.UpdateCustomer)
+	r.PUT("/customer/:id", h.CustomerOwner, h.UpdateCustomer)
 	r.PATCH("/customer", h.ChangePasswordCustomer)
-	r.GET("/customer/:id", h.GetCustomerByID)
-	r.GET("/customer", h.GetAllCustomers)
+	r.GET("/customer/:id", h.CustomerOwner, h.GetCustomerByID)
+	r.GET("/customer", adminOnly, h.GetAllCustomers)
 	r.GET("/customer/cars", h.GetCustomerCars)
-	r.DELETE("/customer/:id", h.DeleteCustomer)
+	r.DELETE("/customer/:id", h.CustomerOwner, h.DeleteCustomer)
+	r.POST("/customer/:id/block", adminOnly, h.BlockCustomer)
+	r.POST("/customer/:id/unblock", adminOnly, h.UnblockCustomer)
+	r.GET("/customer/:id/blocks", adminOnly, h.GetCustomerBlocks)
+	r.PUT("/customer/:id/licence", h.CustomerOwner, h.UpdateCustomerLicence)
+	r.GET("/customer/:id/verification", h.CustomerOwner, h.GetCustomerVerification)
+	r.POST("/customer/:id/verification", adminOnly, h.ReviewCustomerVerification)
+	r.POST("/customer/:id/documents", h.CustomerOwner, h.UploadCustomerDocument)
+	r.GET("/customer/:id/documents", h.CustomerOwner, h.GetCustomerDocuments)
+
+	r.DELETE("/document/:id", adminOnly, h.DeleteDocument)
 
 	r.POST("/order", h.CreateOrder)
-	r.PUT("/order/:id", h.UpdateOrder)
-	r.PATCH("/order", h.UpdateOrderStatus)
-	r.GET("/order/:id", h.GetOrderByID)
+	r.POST("/order/quote", h.QuoteOrder)
+	r.PUT("/order/:id", h.OrderOwner, h.UpdateOrder)
+	r.PATCH("/order", adminOnly, h.UpdateOrderStatus)
+	r.GET("/order/:id", h.OrderOwner, h.GetOrderByID)
+	r.GET("/order/:id/history", h.OrderOwner, h.GetOrderStatusHistory)
+	r.POST("/order/:id/cancel", h.OrderOwner, h.CancelOrder)
+	r.POST("/order/:id/inspections", adminOnly, h.CreateOrderInspection)
+	r.GET("/order/:id/inspections", h.OrderOwner, h.GetOrderInspections)
+	r.POST("/order/:id/damages", adminOnly, h.CreateDamageReport)
+	r.GET("/order/:id/damages", h.OrderOwner, h.GetOrderDamageReports)
+	r.GET("/order/:id/damages/:damage_id", h.OrderOwner, h.GetDamageReportByID)
+	r.PUT("/order/:id/damages/:damage_id", adminOnly, h.UpdateDamageReport)
+	r.POST("/order/:id/damages/:damage_id/photos", adminOnly, h.UploadDamagePhoto)
+	r.GET("/order/:id/damages/:damage_id/photos/:photo_id", h.OrderOwner, h.GetDamagePhoto)
+	r.POST("/order/:id/payments", adminOnly, h.CreateOrderPayment)
+	r.GET("/order/:id/payments", h.OrderOwner, h.GetOrderPayments)
+	r.POST("/order/:id/refunds", adminOnly, h.RefundOrderPayment)
+	r.GET("/order/:id/deposit", h.OrderOwner, h.GetOrderDeposit)
+	r.POST("/order/:id/deposit/release", adminOnly, h.ReleaseOrderDeposit)
 	r.GET("/order", h.GetAllOrders)
-	r.DELETE("/order/:id", h.DeleteOrder)
+	r.DELETE("/order/:id", adminOnly, h.DeleteOrder)
 
-	return r
-}
+	r.GET("/deposit", adminOnly, h.GetAllDeposits)
 
-func authMiddleware(c *gin.Context) {
-	auth := c.GetHeader("Authorization")
-	if auth == "" {
-		c.AbortWithError(http.StatusUnauthorized, errors.New("unauthorized"))
-	}
-	c.Next()
+	r.POST("/promo", adminOnly, h.CreatePromoCode)
+	r.GET("/promo", adminOnly, h.GetAllPromoCodes)
+	r.GET("/promo/:id", adminOnly, h.GetPromoCodeByID)
+	r.PUT("/promo/:id", adminOnly, h.UpdatePromoCode)
+	r.DELETE("/promo/:id", adminOnly, h.DeletePromoCode)
+
+	return r
 }
 
 func logMiddleware(c *gin.Context) {
@@ -7,6 +7,7 @@ import (
 	"rent-car/config"
 	"rent-car/pkg/logger"
 	"rent-car/service"
+	"rent-car/storage/local"
 	"rent-car/storage/postgres"
 	"rent-car/storage/redis"
 
@@ -27,7 +28,24 @@ func main() {
 	}
 	defer store.CloseDB()
 
-	services := service.New(store, log, newRedis)
+	files, err := local.New(cfg.FileStorageDir)
+	if err != nil {
+		fmt.Println("error while opening file storage, err: ", err)
+		return
+	}
+
+	services := service.New(store, files, log, newRedis, cfg.Cancellation)
+
+	if err := services.Admin().Bootstrap(context.Background(), cfg.AdminLogin, cfg.AdminPassword); err != nil {
+		fmt.Println("error while creating the first admin, err: ", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go services.LateReturn().Start(ctx, config.LATE_CHECK_INTERVAL)
+
 	server := api.New(services, log)
 
 	fmt.Println("programm is running on localhost:8080...")
@@ -3,23 +3,161 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
 )
 
+const (
+	STATUS_CONFIRMED = "confirmed"
+	STATUS_PICKED_UP = "picked_up"
+	STATUS_RETURNED  = "returned"
+	STATUS_CLOSED    = "closed"
+	STATUS_CANCELLED = "cancelled"
+	STATUS_NO_SHOW   = "no_show"
+)
+
+const (
+	PAYMENT_CHARGE  = "charge"
+	PAYMENT_PARTIAL = "partial"
+	PAYMENT_REFUND  = "refund"
+
+	// deposit movements are written by the deposit flow only
+	PAYMENT_DEPOSIT_HOLD     = "deposit_hold"
+	PAYMENT_DEPOSIT_RELEASE  = "deposit_release"
+	PAYMENT_DEPOSIT_WITHHOLD = "deposit_withhold"
+
+	METHOD_CASH     = "cash"
+	METHOD_CARD     = "card"
+	METHOD_TRANSFER = "transfer"
+)
+
+const (
+	DEPOSIT_HELD               = "held"
+	DEPOSIT_RELEASED           = "released"
+	DEPOSIT_PARTIALLY_RELEASED = "partially_released"
+	DEPOSIT_WITHHELD           = "withheld"
+
+	DEDUCTION_DAMAGE      = "damage"
+	DEDUCTION_FUEL        = "fuel"
+	DEDUCTION_LATE_RETURN = "late_return"
+)
+
+const (
+	BLOCK_ACTION   = "block"
+	UNBLOCK_ACTION = "unblock"
+)
+
+const (
+	VERIFICATION_UNVERIFIED = "unverified"
+	VERIFICATION_PENDING    = "pending"
+	VERIFICATION_VERIFIED   = "verified"
+	VERIFICATION_REJECTED   = "rejected"
+
+	MIN_DRIVER_AGE = 21
+)
+
+const (
+	DOCUMENT_LICENCE      = "licence"
+	DOCUMENT_PASSPORT     = "passport"
+	DOCUMENT_REGISTRATION = "registration"
+	DOCUMENT_INSURANCE    = "insurance"
+	DOCUMENT_PHOTO        = "photo"
+
+	MAX_UPLOAD_SIZE = 10 << 20
+	THUMBNAIL_SIZE  = 400
+)
+
+const (
+	MAINTENANCE_SCHEDULED = "scheduled"
+	MAINTENANCE_COMPLETED = "completed"
+
+	MAINTENANCE_DUE_DAYS = 14
+	MAINTENANCE_DUE_KM   = 1000
+)
+
+const (
+	INSPECTION_PICKUP = "pickup"
+	INSPECTION_RETURN = "return"
+
+	// kilometres included per rental day and the price of each one over
+	DAILY_MILEAGE_LIMIT = 300
+	EXCESS_MILEAGE_RATE = 0.25
+	// price of each percent of the tank missing on return
+	FUEL_CHARGE_PER_PERCENT = 0.9
+)
+
+const (
+	// default cancellation policy, see CancellationPolicy
+	CANCEL_FREE_HOURS = 48
+	CANCEL_LATE_HOURS = 24
+	CANCEL_FEE        = 0.2
+	CANCEL_LATE_FEE   = 0.5
+	CANCEL_NO_SHOW    = 1.0
+)
+
+const (
+	// a car is due back at the start of the order's to_date, the day the next
+	// rental may begin, late fees start after the grace period and are a
+	// share of the car's daily price
+	LATE_GRACE_HOURS = 1
+	LATE_HOURLY_RATE = 0.1
+	LATE_DAILY_RATE  = 1.5
+
+	LATE_CHECK_INTERVAL = 15 * time.Minute
+)
+
+const (
+	DAMAGE_MINOR    = "minor"
+	DAMAGE_MODERATE = "moderate"
+	DAMAGE_SEVERE   = "severe"
+)
+
+const (
+	PROMO_PERCENT = "percent"
+	PROMO_FIXED   = "fixed"
+)
+
+const (
+	WEEKEND_RATE     = 1.2
+	WEEKLY_MIN_DAYS  = 7
+	WEEKLY_DISCOUNT  = 0.10
+	MONTHLY_MIN_DAYS = 30
+	MONTHLY_DISCOUNT = 0.25
+)
+
 type Config struct {
 	PostgresHost     string
 	PostgresPort     int
 	PostgresPassword string
 	PostgresUser     string
 	PostgresDatabase string
-	
+
 	RedisHost     string
 	RedisPort     string
 	RedisPassword string
 
 	ServiceName string
+
+	AdminLogin    string
+	AdminPassword string
+
+	FileStorageDir string
+
+	Cancellation CancellationPolicy
+}
+
+// CancellationPolicy is the share of the order total kept when an order is
+// cancelled. Cancelling is free until FreeHours before the rental starts,
+// after that Fee and from LateHours LateFee of the total is kept. NoShow is
+// kept once the rental has started without the customer.
+type CancellationPolicy struct {
+	FreeHours int
+	LateHours int
+	Fee       float64
+	LateFee   float64
+	NoShow    float64
 }
 
 func Load() Config {
@@ -34,13 +172,26 @@ func Load() Config {
 	cfg.PostgresDatabase = cast.ToString(getOrReturnDefault("POSTGRES_DATABASE", "rentcar"))
 	cfg.PostgresUser = cast.ToString(getOrReturnDefault("POSTGRES_USER", "admin"))
 	cfg.PostgresPassword = cast.ToString(getOrReturnDefault("POSTGRES_PASSWORD", "admin"))
-	
+
 	cfg.ServiceName = cast.ToString(getOrReturnDefault("SERVICE_NAME", "rent_car_api_gateway"))
-	
+
 	cfg.RedisHost = cast.ToString(getOrReturnDefault("REDIS_HOST", "localhost"))
 	cfg.RedisPort = cast.ToString(getOrReturnDefault("REDIS_PORT", "6379"))
 	cfg.RedisPassword = cast.ToString(getOrReturnDefault("REDIS_PASSWORD", "password"))
 
+	cfg.AdminLogin = cast.ToString(getOrReturnDefault("ADMIN_LOGIN", ""))
+	cfg.AdminPassword = cast.ToString(getOrReturnDefault("ADMIN_PASSWORD", ""))
+
+	cfg.FileStorageDir = cast.ToString(getOrReturnDefault("FILE_STORAGE_DIR", "./uploads"))
+
+	cfg.Cancellation = CancellationPolicy{
+		FreeHours: cast.ToInt(getOrReturnDefault("CANCEL_FREE_HOURS", CANCEL_FREE_HOURS)),
+		LateHours: cast.ToInt(getOrReturnDefault("CANCEL_LATE_HOURS", CANCEL_LATE_HOURS)),
+		Fee:       cast.ToFloat64(getOrReturnDefault("CANCEL_FEE", CANCEL_FEE)),
+		LateFee:   cast.ToFloat64(getOrReturnDefault("CANCEL_LATE_FEE", CANCEL_LATE_FEE)),
+		NoShow:    cast.ToFloat64(getOrReturnDefault("CANCEL_NO_SHOW", CANCEL_NO_SHOW)),
+	}
+
 	return cfg
 }
 
@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- an order holds its car up to but not including to_date, a same day rental
+-- still holds that day
+ALTER TABLE orders
+ADD CONSTRAINT orders_car_id_period_excl EXCLUDE USING gist (
+  car_id WITH =,
+  daterange(from_date, GREATEST(to_date, from_date + 1), '[)') WITH &&
+) WHERE (deleted_at = 0 AND status NOT IN ('cancelled', 'finished'));
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+DROP CONSTRAINT orders_car_id_period_excl;
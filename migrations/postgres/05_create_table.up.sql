@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS order_status_history (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  from_status VARCHAR(255) NOT NULL,
+  to_status VARCHAR(255) NOT NULL,
+  changed_by UUID,
+  changed_by_role VARCHAR(20),
+  note TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id_idx ON order_status_history (order_id, created_at);
+
+UPDATE orders SET status = 'closed' WHERE status = 'finished';
+
+ALTER TABLE orders
+DROP CONSTRAINT orders_car_id_period_excl;
+
+ALTER TABLE orders
+ADD CONSTRAINT orders_car_id_period_excl EXCLUDE USING gist (
+  car_id WITH =,
+  daterange(from_date, GREATEST(to_date, from_date + 1), '[)') WITH &&
+) WHERE (deleted_at = 0 AND status NOT IN ('returned', 'closed', 'cancelled', 'no_show'));
@@ -0,0 +1,12 @@
+ALTER TABLE orders
+DROP CONSTRAINT orders_car_id_period_excl;
+
+UPDATE orders SET status = 'finished' WHERE status IN ('returned', 'closed');
+
+ALTER TABLE orders
+ADD CONSTRAINT orders_car_id_period_excl EXCLUDE USING gist (
+  car_id WITH =,
+  daterange(from_date, GREATEST(to_date, from_date + 1), '[)') WITH &&
+) WHERE (deleted_at = 0 AND status NOT IN ('cancelled', 'finished'));
+
+DROP TABLE IF EXISTS order_status_history;
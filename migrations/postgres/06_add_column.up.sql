@@ -0,0 +1,2 @@
+ALTER TABLE orders
+ADD COLUMN total_price DECIMAL(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS payments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  kind VARCHAR(20) NOT NULL CHECK (kind IN ('charge', 'partial', 'refund')),
+  method VARCHAR(20) NOT NULL CHECK (method IN ('cash', 'card', 'transfer')),
+  amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+  reference VARCHAR(255),
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS payments_order_id_idx ON payments (order_id, created_at);
+
+-- orders booked before pricing have no total, price them at the car's daily
+-- rate so they are not read as paid once the status comes from the ledger
+UPDATE orders o
+SET total_price = c.price * GREATEST(o.to_date - o.from_date, 1)
+FROM cars c
+WHERE c.id = o.car_id AND o.total_price = 0 AND c.price > 0;
+
+INSERT INTO payments (order_id, kind, method, amount)
+SELECT id, 'charge', 'cash', total_price
+FROM orders
+WHERE payment_status AND total_price > 0;
+
+ALTER TABLE orders
+DROP COLUMN payment_status;
+
+CREATE OR REPLACE VIEW order_balances AS
+SELECT
+  o.id AS order_id,
+  o.total_price,
+  p.paid_amount,
+  p.refunded_amount,
+  o.total_price - p.paid_amount AS outstanding,
+  o.total_price > 0 AND p.paid_amount >= o.total_price AS payment_status
+FROM orders o
+CROSS JOIN LATERAL (
+  SELECT
+    COALESCE(SUM(amount) FILTER (WHERE kind IN ('charge', 'partial')), 0)
+      - COALESCE(SUM(amount) FILTER (WHERE kind = 'refund'), 0) AS paid_amount,
+    COALESCE(SUM(amount) FILTER (WHERE kind = 'refund'), 0) AS refunded_amount
+  FROM payments
+  WHERE payments.order_id = o.id
+) p;
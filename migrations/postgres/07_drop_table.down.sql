@@ -0,0 +1,10 @@
+ALTER TABLE orders
+ADD COLUMN payment_status BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE orders o SET payment_status = b.payment_status
+FROM order_balances b
+WHERE b.order_id = o.id;
+
+DROP VIEW IF EXISTS order_balances;
+
+DROP TABLE IF EXISTS payments;
@@ -0,0 +1,59 @@
+ALTER TABLE cars
+ADD COLUMN deposit DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS order_deposits (
+  order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+  amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+  status VARCHAR(20) NOT NULL DEFAULT 'held' CHECK (status IN ('held', 'released', 'partially_released', 'withheld')),
+  withheld_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+  captured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  released_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS order_deposits_status_idx ON order_deposits (status, captured_at);
+
+CREATE TABLE IF NOT EXISTS deposit_deductions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_id UUID NOT NULL REFERENCES order_deposits(order_id) ON DELETE CASCADE,
+  reason VARCHAR(20) NOT NULL CHECK (reason IN ('damage', 'fuel', 'late_return')),
+  amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+  note TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- deposits move through the payments ledger too, holds and releases carry no
+-- method of their own
+ALTER TABLE payments
+DROP CONSTRAINT payments_kind_check;
+
+ALTER TABLE payments
+ADD CONSTRAINT payments_kind_check CHECK (kind IN ('charge', 'partial', 'refund', 'deposit_hold', 'deposit_release', 'deposit_withhold'));
+
+ALTER TABLE payments
+ALTER COLUMN method DROP NOT NULL;
+
+ALTER TABLE payments
+ADD CONSTRAINT payments_method_required CHECK (method IS NOT NULL OR kind IN ('deposit_hold', 'deposit_release', 'deposit_withhold'));
+
+CREATE OR REPLACE VIEW order_balances AS
+SELECT
+  o.id AS order_id,
+  o.total_price,
+  p.paid_amount,
+  p.refunded_amount,
+  o.total_price - p.paid_amount AS outstanding,
+  o.total_price > 0 AND p.paid_amount >= o.total_price AS payment_status,
+  p.deposit_held,
+  p.deposit_withheld
+FROM orders o
+CROSS JOIN LATERAL (
+  SELECT
+    COALESCE(SUM(amount) FILTER (WHERE kind IN ('charge', 'partial')), 0)
+      - COALESCE(SUM(amount) FILTER (WHERE kind = 'refund'), 0) AS paid_amount,
+    COALESCE(SUM(amount) FILTER (WHERE kind = 'refund'), 0) AS refunded_amount,
+    COALESCE(SUM(amount) FILTER (WHERE kind = 'deposit_hold'), 0)
+      - COALESCE(SUM(amount) FILTER (WHERE kind IN ('deposit_release', 'deposit_withhold')), 0) AS deposit_held,
+    COALESCE(SUM(amount) FILTER (WHERE kind = 'deposit_withhold'), 0) AS deposit_withheld
+  FROM payments
+  WHERE payments.order_id = o.id
+) p;
@@ -0,0 +1,39 @@
+DROP VIEW IF EXISTS order_balances;
+
+DELETE FROM payments WHERE kind IN ('deposit_hold', 'deposit_release', 'deposit_withhold');
+
+ALTER TABLE payments
+DROP CONSTRAINT payments_method_required;
+
+ALTER TABLE payments
+ALTER COLUMN method SET NOT NULL;
+
+ALTER TABLE payments
+DROP CONSTRAINT payments_kind_check;
+
+ALTER TABLE payments
+ADD CONSTRAINT payments_kind_check CHECK (kind IN ('charge', 'partial', 'refund'));
+
+CREATE VIEW order_balances AS
+SELECT
+  o.id AS order_id,
+  o.total_price,
+  p.paid_amount,
+  p.refunded_amount,
+  o.total_price - p.paid_amount AS outstanding,
+  o.total_price > 0 AND p.paid_amount >= o.total_price AS payment_status
+FROM orders o
+CROSS JOIN LATERAL (
+  SELECT
+    COALESCE(SUM(amount) FILTER (WHERE kind IN ('charge', 'partial')), 0)
+      - COALESCE(SUM(amount) FILTER (WHERE kind = 'refund'), 0) AS paid_amount,
+    COALESCE(SUM(amount) FILTER (WHERE kind = 'refund'), 0) AS refunded_amount
+  FROM payments
+  WHERE payments.order_id = o.id
+) p;
+
+DROP TABLE IF EXISTS deposit_deductions;
+DROP TABLE IF EXISTS order_deposits;
+
+ALTER TABLE cars
+DROP COLUMN deposit;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS admins (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  first_name VARCHAR(50) NOT NULL,
+  last_name VARCHAR(50),
+  login VARCHAR(50) NOT NULL,
+  password VARCHAR(255) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP,
+  deleted_at INTEGER DEFAULT 0,
+  CONSTRAINT admins_deleted_at_login_unique UNIQUE (deleted_at, login)
+);
@@ -0,0 +1,15 @@
+ALTER TABLE customers
+ADD COLUMN block_reason TEXT,
+ADD COLUMN blocked_until TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS customer_blocks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+  action VARCHAR(10) NOT NULL CHECK (action IN ('block', 'unblock')),
+  reason TEXT NOT NULL,
+  blocked_until TIMESTAMPTZ,
+  admin_id UUID REFERENCES admins(id) ON DELETE SET NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS customer_blocks_customer_id_idx ON customer_blocks (customer_id, created_at);
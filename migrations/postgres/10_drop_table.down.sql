@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS customer_blocks;
+
+ALTER TABLE customers
+DROP COLUMN block_reason,
+DROP COLUMN blocked_until;
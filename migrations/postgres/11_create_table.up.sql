@@ -0,0 +1,12 @@
+ALTER TABLE customers
+ADD COLUMN licence_number VARCHAR(50),
+ADD COLUMN licence_country VARCHAR(100),
+ADD COLUMN licence_expiry DATE,
+ADD COLUMN date_of_birth DATE,
+ADD COLUMN verification_status VARCHAR(20) NOT NULL DEFAULT 'unverified'
+  CHECK (verification_status IN ('unverified', 'pending', 'verified', 'rejected')),
+ADD COLUMN verification_note TEXT,
+ADD COLUMN verified_by UUID REFERENCES admins(id) ON DELETE SET NULL,
+ADD COLUMN verified_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS customers_verification_status_idx ON customers (verification_status) WHERE deleted_at = 0;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS customers_verification_status_idx;
+
+ALTER TABLE customers
+DROP COLUMN licence_number,
+DROP COLUMN licence_country,
+DROP COLUMN licence_expiry,
+DROP COLUMN date_of_birth,
+DROP COLUMN verification_status,
+DROP COLUMN verification_note,
+DROP COLUMN verified_by,
+DROP COLUMN verified_at;
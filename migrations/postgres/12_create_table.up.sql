@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS documents (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  customer_id UUID REFERENCES customers(id) ON DELETE CASCADE,
+  car_id UUID REFERENCES cars(id) ON DELETE CASCADE,
+  type VARCHAR(20) NOT NULL CHECK (type IN ('licence', 'passport', 'registration', 'insurance', 'photo')),
+  file_name VARCHAR(255) NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL CHECK (size > 0),
+  checksum CHAR(64) NOT NULL,
+  storage_key TEXT NOT NULL UNIQUE,
+  uploaded_by UUID,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT documents_single_owner CHECK (num_nonnulls(customer_id, car_id) = 1)
+);
+
+CREATE INDEX IF NOT EXISTS documents_customer_id_idx ON documents (customer_id, created_at) WHERE customer_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS documents_car_id_idx ON documents (car_id, created_at) WHERE car_id IS NOT NULL;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS car_photos (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL CHECK (position >= 0),
+  is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL CHECK (size > 0),
+  width INTEGER NOT NULL,
+  height INTEGER NOT NULL,
+  checksum CHAR(64) NOT NULL,
+  storage_key TEXT NOT NULL UNIQUE,
+  thumbnail_key TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS car_photos_car_id_idx ON car_photos (car_id, position);
+CREATE UNIQUE INDEX IF NOT EXISTS car_photos_primary_idx ON car_photos (car_id) WHERE is_primary;
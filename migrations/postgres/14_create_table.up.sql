@@ -0,0 +1,35 @@
+ALTER TABLE cars
+ADD COLUMN mileage INTEGER NOT NULL DEFAULT 0 CHECK (mileage >= 0);
+
+CREATE TABLE IF NOT EXISTS maintenance_intervals (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  every_km INTEGER CHECK (every_km > 0),
+  every_days INTEGER CHECK (every_days > 0),
+  last_service_date DATE NOT NULL DEFAULT CURRENT_DATE,
+  last_service_mileage INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT maintenance_intervals_has_interval CHECK (every_km IS NOT NULL OR every_days IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS maintenance_intervals_car_id_idx ON maintenance_intervals (car_id);
+
+CREATE TABLE IF NOT EXISTS maintenance (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+  interval_id UUID REFERENCES maintenance_intervals(id) ON DELETE SET NULL,
+  title VARCHAR(200) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'completed')),
+  start_date DATE NOT NULL,
+  end_date DATE NOT NULL,
+  mileage INTEGER,
+  cost DECIMAL(10, 2) CHECK (cost >= 0),
+  notes TEXT,
+  completed_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT maintenance_window CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS maintenance_car_id_idx ON maintenance (car_id, start_date);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS maintenance;
+DROP TABLE IF EXISTS maintenance_intervals;
+
+ALTER TABLE cars
+DROP COLUMN mileage;
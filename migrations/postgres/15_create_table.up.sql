@@ -0,0 +1,15 @@
+ALTER TABLE orders
+ADD COLUMN excess_mileage_charge DECIMAL(10, 2) NOT NULL DEFAULT 0,
+ADD COLUMN fuel_charge DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS order_inspections (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  kind VARCHAR(10) NOT NULL CHECK (kind IN ('pickup', 'return')),
+  odometer INTEGER NOT NULL CHECK (odometer >= 0),
+  fuel_level SMALLINT NOT NULL CHECK (fuel_level BETWEEN 0 AND 100),
+  inspected_by UUID REFERENCES admins(id) ON DELETE SET NULL,
+  notes TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT order_inspections_order_id_kind_unique UNIQUE (order_id, kind)
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS order_inspections;
+
+ALTER TABLE orders
+DROP COLUMN excess_mileage_charge,
+DROP COLUMN fuel_charge;
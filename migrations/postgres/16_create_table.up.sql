@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS damage_reports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  car_id UUID NOT NULL REFERENCES cars(id) ON DELETE CASCADE,
+  location VARCHAR(100) NOT NULL,
+  severity VARCHAR(10) NOT NULL CHECK (severity IN ('minor', 'moderate', 'severe')),
+  description TEXT,
+  estimated_cost DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (estimated_cost >= 0),
+  actual_cost DECIMAL(10, 2) CHECK (actual_cost >= 0),
+  charged_to_customer BOOLEAN NOT NULL DEFAULT FALSE,
+  reported_by UUID REFERENCES admins(id) ON DELETE SET NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS damage_reports_order_id_idx ON damage_reports (order_id);
+CREATE INDEX IF NOT EXISTS damage_reports_car_id_idx ON damage_reports (car_id, created_at);
+
+CREATE TABLE IF NOT EXISTS damage_photos (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  damage_id UUID NOT NULL REFERENCES damage_reports(id) ON DELETE CASCADE,
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL CHECK (size > 0),
+  checksum CHAR(64) NOT NULL,
+  storage_key VARCHAR(255) NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS damage_photos_damage_id_idx ON damage_photos (damage_id, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS damage_photos;
+DROP TABLE IF EXISTS damage_reports;
@@ -0,0 +1,6 @@
+ALTER TABLE orders
+ADD COLUMN is_late BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN late_fee DECIMAL(10, 2) NOT NULL DEFAULT 0,
+ADD COLUMN late_notified_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS orders_late_idx ON orders (car_id) WHERE is_late;
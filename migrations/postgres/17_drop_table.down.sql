@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS orders_late_idx;
+
+ALTER TABLE orders
+DROP COLUMN is_late,
+DROP COLUMN late_fee,
+DROP COLUMN late_notified_at;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS order_cancellations (
+  order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+  reason TEXT NOT NULL,
+  hours_before INTEGER NOT NULL,
+  fee_rate DECIMAL(5, 4) NOT NULL CHECK (fee_rate BETWEEN 0 AND 1),
+  fee DECIMAL(10, 2) NOT NULL CHECK (fee >= 0),
+  refund_due DECIMAL(10, 2) NOT NULL CHECK (refund_due >= 0),
+  cancelled_by UUID,
+  cancelled_by_role VARCHAR(20),
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS promo_codes (
+  id UUID PRIMARY KEY,
+  code VARCHAR(40) NOT NULL UNIQUE,
+  discount_type VARCHAR(10) NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+  discount_value DECIMAL(10, 2) NOT NULL CHECK (discount_value > 0),
+  valid_from DATE,
+  valid_to DATE,
+  max_uses INTEGER CHECK (max_uses > 0),
+  max_uses_per_customer INTEGER CHECK (max_uses_per_customer > 0),
+  min_rental_days INTEGER NOT NULL DEFAULT 0 CHECK (min_rental_days >= 0),
+  brand VARCHAR(50),
+  model VARCHAR(50),
+  uses INTEGER NOT NULL DEFAULT 0,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CHECK (discount_type <> 'percent' OR discount_value <= 100),
+  CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to >= valid_from)
+);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+  id UUID PRIMARY KEY,
+  promo_code_id UUID NOT NULL REFERENCES promo_codes(id),
+  order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+  customer_id UUID NOT NULL REFERENCES customers(id),
+  discount DECIMAL(10, 2) NOT NULL CHECK (discount >= 0),
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS promo_redemptions_customer_idx ON promo_redemptions (promo_code_id, customer_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount DECIMAL(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS discount;
+
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_codes;
@@ -0,0 +1,65 @@
+// Package apperr defines the domain errors storage and service layers raise.
+// Each carries a code clients can act on, the API maps codes to HTTP statuses
+// in one place.
+package apperr
+
+import "errors"
+
+type Code string
+
+const (
+	Validation      Code = "validation"
+	Unauthorized    Code = "unauthorized"
+	Forbidden       Code = "forbidden"
+	NotFound        Code = "not_found"
+	Conflict        Code = "conflict"
+	TooLarge        Code = "too_large"
+	Locked          Code = "locked"
+	TooManyRequests Code = "too_many_requests"
+	Internal        Code = "internal"
+)
+
+// FieldError tells which request field failed validation and why.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+}
+
+// New makes a domain error, declare it once as a sentinel and wrap it with
+// fmt.Errorf("%w: ...") to add details.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Invalid makes a validation error listing every field that failed.
+func Invalid(fields ...FieldError) *Error {
+	return &Error{Code: Validation, Message: "validation failed", Fields: fields}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// As finds the domain error in err's chain, errors without one are internal.
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// CodeOf returns the code of the domain error in err's chain, Internal when
+// there is none.
+func CodeOf(err error) Code {
+	if appErr, ok := As(err); ok {
+		return appErr.Code
+	}
+	return Internal
+}
@@ -0,0 +1,33 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOf(t *testing.T) {
+	errNotFound := New(NotFound, "car not found")
+
+	wrapped := fmt.Errorf("%w: id 42", errNotFound)
+	assert.ErrorIs(t, wrapped, errNotFound)
+	assert.Equal(t, NotFound, CodeOf(wrapped))
+	assert.Equal(t, "car not found: id 42", wrapped.Error())
+
+	assert.Equal(t, Internal, CodeOf(errors.New("connection refused")))
+}
+
+func TestInvalid(t *testing.T) {
+	err := fmt.Errorf("checking request: %w", Invalid(
+		FieldError{Field: "year", Message: "year is not valid"},
+		FieldError{Field: "price", Message: "must be positive"},
+	))
+
+	appErr, ok := As(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, Validation, appErr.Code)
+		assert.Len(t, appErr.Fields, 2)
+	}
+}
@@ -0,0 +1,219 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"rent-car/pkg"
+	"rent-car/pkg/apperr"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate evaluates the binding tags of every request model. Besides the
+// builtin rules it knows phone, car_year, password, date and date_gte.
+var validate = newValidate()
+
+// Binding runs Struct for gin, install it as binding.Validator so every bound
+// request is checked before it reaches a handler.
+type Binding struct{}
+
+func (Binding) ValidateStruct(obj interface{}) error {
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return Struct(obj)
+	case reflect.Slice, reflect.Array:
+		// every element is checked, their fields are reported under [i]
+		var fields []apperr.FieldError
+		for i := 0; i < value.Len(); i++ {
+			err := (Binding{}).ValidateStruct(value.Index(i).Interface())
+			if err == nil {
+				continue
+			}
+
+			appErr, ok := apperr.As(err)
+			if !ok {
+				return err
+			}
+			for _, field := range appErr.Fields {
+				field.Field = fmt.Sprintf("[%d].%s", i, field.Field)
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) > 0 {
+			return apperr.Invalid(fields...)
+		}
+	}
+	return nil
+}
+
+func (Binding) Engine() interface{} {
+	return validate
+}
+
+// Struct checks obj against its binding tags and reports every field that
+// failed at once, named the way clients send them.
+func Struct(obj interface{}) error {
+	err := validate.Struct(obj)
+	if err == nil {
+		return nil
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	fields := make([]apperr.FieldError, 0, len(errs))
+	for _, fe := range errs {
+		fields = append(fields, apperr.FieldError{
+			Field:   fieldName(fe),
+			Message: message(fe),
+		})
+	}
+	return apperr.Invalid(fields...)
+}
+
+func newValidate() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return jsonName(field)
+	})
+
+	v.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		_, err := ValidatePhone(fl.Field().String())
+		return err == nil
+	})
+	v.RegisterValidation("car_year", func(fl validator.FieldLevel) bool {
+		return ValidateCarYear(int(fl.Field().Int())) == nil
+	})
+	v.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		return ValidatePassword(fl.Field().String()) == nil
+	})
+	v.RegisterValidation("date", func(fl validator.FieldLevel) bool {
+		_, err := pkg.ParseDate(fl.Field().String())
+		return err == nil
+	})
+	v.RegisterValidation("date_gte", dateGTE)
+
+	return v
+}
+
+// dateGTE passes when the date is not before the date in the sibling field
+// named by the param, a missing or unparsable bound is left to its own rules.
+func dateGTE(fl validator.FieldLevel) bool {
+	other, ok := siblingByJSON(fl.Parent(), fl.Param())
+	if !ok {
+		return false
+	}
+
+	from, err := pkg.ParseDate(other.String())
+	if err != nil {
+		return true
+	}
+
+	to, err := pkg.ParseDate(fl.Field().String())
+	if err != nil {
+		return true
+	}
+
+	return !to.Before(from)
+}
+
+func siblingByJSON(parent reflect.Value, name string) (reflect.Value, bool) {
+	for parent.Kind() == reflect.Pointer {
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	for i := 0; i < parent.NumField(); i++ {
+		if jsonName(parent.Type().Field(i)) == name {
+			return parent.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		// path parameters are not in the body, they go by their uri name
+		return field.Tag.Get("uri")
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// fieldName drops the struct type from the namespace, nested fields keep
+// their path like customer.password or deductions[0].amount.
+func fieldName(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_unless":
+		return "is required"
+	case "required_with":
+		return "is required when " + strings.ToLower(fe.Param()) + " is set"
+	case "email":
+		return "must be a valid email"
+	case "uuid":
+		return "must be a valid uuid"
+	case "phone":
+		return "must be a valid phone number"
+	case "car_year":
+		if err := ValidateCarYear(int(reflect.ValueOf(fe.Value()).Int())); err != nil {
+			return err.Error()
+		}
+	case "password":
+		if err := ValidatePassword(fmt.Sprint(fe.Value())); err != nil {
+			return err.Error()
+		}
+	case "date":
+		return "must be a date like 2024-06-01 or 2024-06-01T10:00:00Z"
+	case "date_gte":
+		return "must not be before " + fe.Param()
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte", "min":
+		return "must be at least " + fe.Param() + unit(fe.Kind())
+	case "lte", "max":
+		return "must be at most " + fe.Param() + unit(fe.Kind())
+	}
+	return "is not valid"
+}
+
+// unit names what a length rule counts, numbers are compared as they are.
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+	return ""
+}
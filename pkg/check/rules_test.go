@@ -0,0 +1,125 @@
+package check
+
+import (
+	"rent-car/pkg/apperr"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type rental struct {
+	CarId    string  `json:"car_id" binding:"required,uuid"`
+	FromDate string  `json:"from_date" binding:"required,date"`
+	ToDate   string  `json:"to_date" binding:"required,date,date_gte=from_date"`
+	Driver   driver  `json:"driver"`
+	Extras   []extra `json:"extras" binding:"dive"`
+}
+
+type driver struct {
+	Phone    string `json:"phone" binding:"required,phone"`
+	Password string `json:"password" binding:"required,password"`
+	Year     int64  `json:"year" binding:"car_year"`
+}
+
+type extra struct {
+	Price float64 `json:"price" binding:"gt=0"`
+}
+
+func TestStruct(t *testing.T) {
+	valid := rental{
+		CarId:    "8d6d4d1e-8b8c-4a5e-9a59-2d7f3f1f7c3b",
+		FromDate: "2024-06-01",
+		ToDate:   "2024-06-03T10:00:00Z",
+		Driver:   driver{Phone: "+998 90 123 45 67", Password: "Secret#123", Year: 2020},
+		Extras:   []extra{{Price: 5}},
+	}
+	assert.NoError(t, Struct(valid))
+	assert.NoError(t, Binding{}.ValidateStruct(&valid))
+	assert.NoError(t, Binding{}.ValidateStruct([]rental{valid}))
+
+	err := Binding{}.ValidateStruct([]extra{{Price: -1}, {Price: 5}, {Price: 0}})
+	if appErr, ok := apperr.As(err); assert.True(t, ok) {
+		assert.Equal(t, []apperr.FieldError{
+			{Field: "[0].price", Message: "must be greater than 0"},
+			{Field: "[2].price", Message: "must be greater than 0"},
+		}, appErr.Fields)
+	}
+
+	err = Struct(rental{
+		CarId:    "42",
+		FromDate: "2024-06-03",
+		ToDate:   "2024-06-01",
+		Driver:   driver{Phone: "12", Password: "secret", Year: -1},
+		Extras:   []extra{{Price: 5}, {Price: -1}},
+	})
+
+	appErr, ok := apperr.As(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, apperr.Validation, appErr.Code)
+		assert.Equal(t, []apperr.FieldError{
+			{Field: "car_id", Message: "must be a valid uuid"},
+			{Field: "to_date", Message: "must not be before from_date"},
+			{Field: "driver.phone", Message: "must be a valid phone number"},
+			{Field: "driver.password", Message: "password must be at least 8 characters"},
+			{Field: "driver.year", Message: "year is not valid"},
+			{Field: "extras[1].price", Message: "must be greater than 0"},
+		}, appErr.Fields)
+	}
+}
+
+func TestPathRules(t *testing.T) {
+	type edit struct {
+		Id         string `json:"-" uri:"id" binding:"required,uuid"`
+		CustomerId string `json:"customer_id" binding:"required_unless=Role customer,omitempty,uuid"`
+		Role       string `json:"-"`
+	}
+
+	id := "8d6d4d1e-8b8c-4a5e-9a59-2d7f3f1f7c3b"
+	assert.NoError(t, Struct(edit{Id: id, Role: "customer"}))
+	assert.NoError(t, Struct(edit{Id: id, CustomerId: id, Role: "admin"}))
+
+	err := Struct(edit{Id: "42", Role: "admin"})
+	if appErr, ok := apperr.As(err); assert.True(t, ok) {
+		assert.Equal(t, []apperr.FieldError{
+			{Field: "id", Message: "must be a valid uuid"},
+			{Field: "customer_id", Message: "is required"},
+		}, appErr.Fields)
+	}
+}
+
+func TestDateRules(t *testing.T) {
+	type window struct {
+		From string `json:"from" binding:"required_with=To,omitempty,date"`
+		To   string `json:"to" binding:"omitempty,date,date_gte=from"`
+	}
+
+	assert.NoError(t, Struct(window{}))
+	assert.NoError(t, Struct(window{From: "2024-06-01"}))
+	assert.NoError(t, Struct(window{From: "2024-06-01", To: "2024-06-01"}))
+
+	err := Struct(window{From: "June 1st", To: "2024-06-01"})
+	if appErr, ok := apperr.As(err); assert.True(t, ok) {
+		assert.Equal(t, []apperr.FieldError{
+			{Field: "from", Message: "must be a date like 2024-06-01 or 2024-06-01T10:00:00Z"},
+		}, appErr.Fields)
+	}
+
+	err = Struct(window{To: "2024-06-01"})
+	if appErr, ok := apperr.As(err); assert.True(t, ok) {
+		assert.Equal(t, []apperr.FieldError{
+			{Field: "from", Message: "is required when to is set"},
+		}, appErr.Fields)
+	}
+}
+
+func TestValidatePhone(t *testing.T) {
+	for _, phone := range []string{"+998901234567", "+998 90 123-45-67", "(90) 123 45 67", "1234567"} {
+		_, err := ValidatePhone(phone)
+		assert.NoError(t, err, phone)
+	}
+
+	for _, phone := range []string{"abc1234567xyz", "123456", "+998 90 123 45 67 89 01", "90+1234567", ""} {
+		_, err := ValidatePhone(phone)
+		assert.Error(t, err, phone)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
-	"unicode"
 )
 
 // regex
@@ -26,7 +26,7 @@ func ValidateCarYear(year int) error {
 // }
 
 func ValidateEmail(email string) (bool, error) {
-	mail := regexp.MustCompile(`(?i)\w+@\w+(\.[a-z]{2,})+`).MatchString(email)  // `\w+\@w\.(com|ru|org|edu)]`
+	mail := regexp.MustCompile(`(?i)\w+@\w+(\.[a-z]{2,})+`).MatchString(email) // `\w+\@w\.(com|ru|org|edu)]`
 	if !mail {
 		fmt.Println(mail)
 
@@ -49,27 +49,18 @@ func ValidateEmail(email string) (bool, error) {
 // 	return len(phone) == 12
 // }
 
+// phoneFormat is an optional + and 7 to 15 digits, once spaces, dashes and
+// brackets around the area code are dropped.
+var phoneFormat = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
 func ValidatePhone(phone string) (bool, error) {
-	ret := regexp.MustCompile(`\+?|\s?998\s?|-?\(?\d{2}\)?\s?|-?\d{3}\s?|-?\d{2}\s?|-?\d{2}`).MatchString(phone)
+	digits := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(phone)
 
-	if !ret {
-		err := errors.New("error in validate phone")
-		return false, err
+	if !phoneFormat.MatchString(digits) {
+		return false, errors.New("phone number must be 7 to 15 digits with an optional leading +")
 	}
-	
-	digitCount := 0
-    for _, char := range phone {
-        if unicode.IsDigit(char) {
-            digitCount++
-        }
-    }
-
-    if digitCount < 7 {
-        err := errors.New("phone number must have at least 7 digits")
-        return false, err
-    }
-
-    return true, nil
+
+	return true, nil
 }
 
 func ValidatePassword(password string) error {
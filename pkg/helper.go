@@ -2,10 +2,11 @@ package pkg
 
 import (
 	"bytes"
+	"crypto/rand"
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"math/rand"
+	"math/big"
 	"net/http"
 	"rent-car/config"
 	"sync"
@@ -21,12 +22,12 @@ func NullStringToString(s sql.NullString) string {
 }
 
 func Duration(fD, tD string) (duration float64, err error) {
-	fromDate, err := time.Parse(time.RFC3339, fD)
+	fromDate, err := ParseDate(fD)
 	if err != nil {
 		return 0, err
 	}
 
-	toDate, err := time.Parse(time.RFC3339, tD)
+	toDate, err := ParseDate(tD)
 	if err != nil {
 		return 0, err
 	}
@@ -35,6 +36,16 @@ func Duration(fD, tD string) (duration float64, err error) {
 	return duration, nil
 }
 
+// ParseDate accepts both RFC3339 timestamps and plain YYYY-MM-DD dates
+func ParseDate(date string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, date)
+	if err == nil {
+		return parsed, nil
+	}
+
+	return time.Parse(time.DateOnly, date)
+}
+
 func GetSerialId(n *int) string {
 	var mutex sync.Mutex
 	mutex.Lock()
@@ -49,7 +60,7 @@ func TelegramBotFunc(msg interface{}) (string, error) {
 
 	botToken := config.BotToken
 	chatID := config.ChatID
-	
+
 	messageBytes, err := json.Marshal(msg)
 	if err != nil {
 		return "", err
@@ -80,7 +91,12 @@ func TelegramBotFunc(msg interface{}) (string, error) {
 	return success, nil
 }
 
-func GenerateOTP() int {
+// GenerateOTP returns a random 6 digit code from a cryptographically secure source
+func GenerateOTP() (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(900000))
+	if err != nil {
+		return 0, err
+	}
 
-	return rand.Intn(900000) + 100000
+	return int(n.Int64()) + 100000, nil
 }
@@ -0,0 +1,16 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateOTP(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		otp, err := GenerateOTP()
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, otp, 100000)
+		assert.LessOrEqual(t, otp, 999999)
+	}
+}
@@ -6,8 +6,23 @@ import (
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 )
 
+// token types, stored in the typ claim
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+const (
+	AccessTokenTTL  = 24 * time.Hour
+	RefreshTokenTTL = 10 * 24 * time.Hour
+)
+
+// GenJWT signs an access and a refresh token carrying the claims in m.
+// Both get their own jti and share the fam claim, which is taken from m
+// when present so that rotated refresh tokens stay in the same family.
 func GenJWT(m map[interface{}]interface{}) (string, string, error) {
 	var (
 		accessToken, refreshToken *jwt.Token
@@ -25,13 +40,23 @@ func GenJWT(m map[interface{}]interface{}) (string, string, error) {
 		rClaims[k.(string)] = v
 	}
 
+	if _, ok := claims["fam"]; !ok {
+		family := uuid.New().String()
+		claims["fam"] = family
+		rClaims["fam"] = family
+	}
+
 	claims["iss"] = "user"
-	claims["iat"] = time.Now().Unix()
-	claims["exp"] = time.Now().AddDate(0, 0, 1).Unix()
+	claims["typ"] = AccessToken
+	claims["jti"] = uuid.New().String()
+	claims["iat"] = Timestamp(time.Now())
+	claims["exp"] = time.Now().Add(AccessTokenTTL).Unix()
 
 	rClaims["iss"] = "user"
-	rClaims["iat"] = time.Now().Unix()
-	rClaims["exp"] = time.Now().AddDate(0, 0, 10).Unix()
+	rClaims["typ"] = RefreshToken
+	rClaims["jti"] = uuid.New().String()
+	rClaims["iat"] = Timestamp(time.Now())
+	rClaims["exp"] = time.Now().Add(RefreshTokenTTL).Unix()
 
 	accessTokenString, err := accessToken.SignedString(config.SignedKey)
 	if err != nil {
@@ -48,23 +73,16 @@ func GenJWT(m map[interface{}]interface{}) (string, string, error) {
 	return accessTokenString, refreshTokenString, nil
 }
 
-func ExtractClaims(tokenStr string) (jwt.MapClaims, error) {
-	var (
-		token *jwt.Token
-		err   error
-	)
-	token, err = jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		// check token signing method etc
+// ExtractClaims validates tokenStr and returns its claims if it is a token of the given type.
+func ExtractClaims(tokenStr string, typ string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
 		return config.SignedKey, nil
 	})
 	if err != nil {
-		token, err = jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			// check token signing method etc
-			return config.SignedKey, nil
-		})
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
@@ -72,7 +90,23 @@ func ExtractClaims(tokenStr string) (jwt.MapClaims, error) {
 		err = fmt.Errorf("invalid JWT Token")
 		return nil, err
 	}
+
+	if claims["typ"] != typ {
+		return nil, fmt.Errorf("expected %s token", typ)
+	}
+
 	return claims, nil
 }
 
+// Timestamp returns t in unix seconds with microsecond precision, the form
+// of the iat claim, so a token can be told apart from a revocation made in
+// the same second.
+func Timestamp(t time.Time) float64 {
+	return float64(t.UnixMicro()) / 1e6
+}
 
+// TTL returns how long the token with these claims stays valid.
+func TTL(claims jwt.MapClaims) time.Duration {
+	exp, _ := claims["exp"].(float64)
+	return time.Until(time.Unix(int64(exp), 0))
+}
@@ -0,0 +1,54 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenTypes(t *testing.T) {
+	m := map[interface{}]interface{}{
+		"user_id":   "42",
+		"user_role": "customer",
+	}
+
+	accessToken, refreshToken, err := GenJWT(m)
+	assert.NoError(t, err)
+
+	access, err := ExtractClaims(accessToken, AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", access["user_id"])
+
+	refresh, err := ExtractClaims(refreshToken, RefreshToken)
+	assert.NoError(t, err)
+	assert.Equal(t, access["fam"], refresh["fam"])
+	assert.NotEqual(t, access["jti"], refresh["jti"])
+
+	_, err = ExtractClaims(refreshToken, AccessToken)
+	assert.Error(t, err)
+	_, err = ExtractClaims(accessToken, RefreshToken)
+	assert.Error(t, err)
+
+	m["fam"] = refresh["fam"]
+	_, rotated, err := GenJWT(m)
+	assert.NoError(t, err)
+
+	claims, err := ExtractClaims(rotated, RefreshToken)
+	assert.NoError(t, err)
+	assert.Equal(t, refresh["fam"], claims["fam"])
+}
+
+func TestIssuedAt(t *testing.T) {
+	assert.Equal(t, 100.5, Timestamp(time.Unix(100, 500_000_000)))
+	assert.Less(t, Timestamp(time.Unix(100, 0)), Timestamp(time.Unix(100, 1_000)))
+
+	revokedBefore := Timestamp(time.Now())
+
+	accessToken, _, err := GenJWT(map[interface{}]interface{}{"user_id": "42"})
+	assert.NoError(t, err)
+
+	claims, err := ExtractClaims(accessToken, AccessToken)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, claims["iat"].(float64), revokedBefore)
+}
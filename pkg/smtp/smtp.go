@@ -6,10 +6,13 @@ import (
 )
 
 func SendMail(toEmail string, msg string) error {
+	return SendMailWithSubject(toEmail, "Register for RENT_CAR", msg)
+}
+
+func SendMailWithSubject(toEmail string, subject string, msg string) error {
 
 	from := config.SmtpUsername
 	to := []string{toEmail}
-	subject := "Register for RENT_CAR"
 	message := msg
 
 	body := "To: " + to[0] + "\r\n" +
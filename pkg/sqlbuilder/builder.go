@@ -0,0 +1,389 @@
+// Package sqlbuilder composes the filters, sorting and pagination of list
+// queries. Values only ever reach the query as bind parameters, column names
+// come from the code and sort keys are checked against a whitelist.
+package sqlbuilder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"rent-car/pkg/apperr"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownSort   = apperr.New(apperr.Validation, "unknown sort field")
+	ErrUnknownField  = apperr.New(apperr.Validation, "unknown field")
+	ErrInvalidCursor = apperr.New(apperr.Validation, "invalid cursor")
+	ErrUnknownFilter = apperr.New(apperr.Validation, "unknown filter")
+)
+
+// Columns maps the sort keys a resource accepts to the columns behind them.
+type Columns map[string]string
+
+// Field is a column of a list query under the name clients ask for it by,
+// several columns can share one name.
+type Field struct {
+	Name   string
+	Column string
+}
+
+// Projection is the select list of a list query in scan order.
+type Projection []Field
+
+// FilterKind is how a filter compares its columns with the value asked for,
+// each kind works like the builder method of the same name.
+type FilterKind int
+
+const (
+	FilterEquals FilterKind = iota + 1
+	FilterRange
+	FilterIn
+	FilterILike
+	FilterILikePattern
+)
+
+// Filter is a typed filter over the columns of a list query. Only FilterILike
+// takes several columns, it matches when any of them does.
+type Filter struct {
+	Kind    FilterKind
+	Columns []string
+}
+
+// Filters maps the filters a resource accepts to how they apply.
+type Filters map[string]Filter
+
+// Values are the values a list is filtered by, keyed by filter name. A zero
+// value leaves its filter off, FilterRange takes Bounds and FilterIn a slice.
+type Values map[string]interface{}
+
+// Bounds are the inclusive bounds of a FilterRange, a zero bound is open.
+type Bounds struct {
+	From interface{}
+	To   interface{}
+}
+
+type Builder struct {
+	conds  []string
+	args   []interface{}
+	order  string
+	limit  uint64
+	offset uint64
+	keyset []string
+	cursor []string
+}
+
+// New starts a builder for a base query that already uses args as $1..$n,
+// the first filter is bound after them.
+func New(args ...interface{}) *Builder {
+	return &Builder{args: args}
+}
+
+// Where adds a condition, each ? in it is bound to the next value.
+func (b *Builder) Where(cond string, values ...interface{}) *Builder {
+	for _, value := range values {
+		cond = strings.Replace(cond, "?", b.bind(value), 1)
+	}
+
+	b.conds = append(b.conds, cond)
+	return b
+}
+
+// Equals filters column by value, a zero value leaves the filter off.
+func (b *Builder) Equals(column string, value interface{}) *Builder {
+	if isZero(value) {
+		return b
+	}
+	return b.Where(column+" = ?", value)
+}
+
+// ILikePattern keeps rows where column matches the ILIKE pattern, so % and _
+// in it are wildcards. An empty pattern leaves the filter off.
+func (b *Builder) ILikePattern(column, pattern string) *Builder {
+	if pattern == "" {
+		return b
+	}
+	return b.Where(column+" ILIKE ?", pattern)
+}
+
+// Range keeps rows with column between from and to inclusive, a zero bound
+// leaves that side open.
+func (b *Builder) Range(column string, from, to interface{}) *Builder {
+	if !isZero(from) {
+		b.Where(column+" >= ?", from)
+	}
+	if !isZero(to) {
+		b.Where(column+" <= ?", to)
+	}
+	return b
+}
+
+// In keeps rows with column in values, an empty slice leaves the filter off.
+func (b *Builder) In(column string, values interface{}) *Builder {
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return b
+	}
+	return b.Where(column+" = ANY(?)", values)
+}
+
+// ILike keeps rows where any of the columns contains value, ignoring case.
+// Wildcards in value match literally.
+func (b *Builder) ILike(value string, columns ...string) *Builder {
+	if value == "" || len(columns) == 0 {
+		return b
+	}
+
+	param := b.bind("%" + escapeLike(value) + "%")
+
+	conds := make([]string, 0, len(columns))
+	for _, column := range columns {
+		conds = append(conds, column+" ILIKE "+param)
+	}
+
+	b.conds = append(b.conds, "("+strings.Join(conds, " OR ")+")")
+	return b
+}
+
+// Filter applies the declared filters to values in name order, so the same
+// values always bind the same way. Names missing from filters and values of
+// the wrong type for their kind are rejected with ErrUnknownFilter.
+func (b *Builder) Filter(filters Filters, values Values) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		filter, ok := filters[name]
+		if !ok || len(filter.Columns) == 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownFilter, name)
+		}
+
+		value := values[name]
+		column := filter.Columns[0]
+
+		switch filter.Kind {
+		case FilterEquals:
+			b.Equals(column, value)
+		case FilterRange:
+			bounds, ok := value.(Bounds)
+			if !ok {
+				return fmt.Errorf("%w: %s takes bounds", ErrUnknownFilter, name)
+			}
+			b.Range(column, bounds.From, bounds.To)
+		case FilterIn:
+			if kind := reflect.ValueOf(value).Kind(); kind != reflect.Slice {
+				return fmt.Errorf("%w: %s takes a list", ErrUnknownFilter, name)
+			}
+			b.In(column, value)
+		case FilterILike, FilterILikePattern:
+			text, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%w: %s takes text", ErrUnknownFilter, name)
+			}
+			if filter.Kind == FilterILike {
+				b.ILike(text, filter.Columns...)
+			} else {
+				b.ILikePattern(column, text)
+			}
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownFilter, name)
+		}
+	}
+	return nil
+}
+
+// OrderBy sorts by the columns behind keys in turn, a leading '-' sorts that
+// key descending, and last by the unique idColumn so rows with equal keys do
+// not move between pages. Keys missing from sortable are rejected with
+// ErrUnknownSort.
+func (b *Builder) OrderBy(sortable Columns, idColumn string, keys ...string) error {
+	order := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		direction := "ASC"
+		if strings.HasPrefix(key, "-") {
+			direction = "DESC"
+			key = key[1:]
+		}
+
+		column, ok := sortable[key]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownSort, key)
+		}
+		order = append(order, column+" "+direction)
+	}
+	order = append(order, idColumn+" ASC")
+
+	b.order = strings.Join(order, ", ")
+	return nil
+}
+
+// Keyset orders by the creation time in createdColumn, then by the unique id
+// in idColumn, and when cursor is set continues right after the row it was
+// made from instead of skipping rows with OFFSET. A cursor that does not hold
+// an RFC 3339 time and a uuid is rejected with ErrInvalidCursor.
+func (b *Builder) Keyset(cursor, createdColumn, idColumn string) error {
+	b.order = createdColumn + " ASC, " + idColumn + " ASC"
+	b.keyset = []string{createdColumn, idColumn}
+
+	if cursor == "" {
+		return nil
+	}
+
+	values, err := decodeCursor(cursor)
+	if err != nil || len(values) != 2 {
+		return ErrInvalidCursor
+	}
+	if _, err = time.Parse(time.RFC3339Nano, values[0]); err != nil {
+		return ErrInvalidCursor
+	}
+	if _, err = uuid.Parse(values[1]); err != nil {
+		return ErrInvalidCursor
+	}
+
+	b.cursor = values
+	return nil
+}
+
+// Cursor points after the row created at createdAt with the given id.
+func Cursor(createdAt, id string) string {
+	data, _ := json.Marshal([]string{createdAt, id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Page limits the query to the given page, pages start at 1.
+func (b *Builder) Page(page, limit uint64) *Builder {
+	if page < 1 {
+		page = 1
+	}
+	b.limit = limit
+	b.offset = (page - 1) * limit
+	return b
+}
+
+// Select appends the filters, sorting and page to base.
+func (b *Builder) Select(base string) (string, []interface{}) {
+	conds := append([]string{}, b.conds...)
+	args := append([]interface{}{}, b.args...)
+
+	if len(b.cursor) > 0 {
+		params := make([]string, 0, len(b.cursor))
+		for _, value := range b.cursor {
+			args = append(args, value)
+			params = append(params, fmt.Sprintf("$%d", len(args)))
+		}
+		conds = append(conds, "("+strings.Join(b.keyset, ", ")+") > ("+strings.Join(params, ", ")+")")
+	}
+
+	query := where(base, conds)
+
+	if b.order != "" {
+		query += " ORDER BY " + b.order
+	}
+	switch {
+	case b.limit > 0 && len(b.cursor) > 0:
+		args = append(args, b.limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	case b.limit > 0:
+		args = append(args, b.offset, b.limit)
+		query += fmt.Sprintf(" OFFSET $%d LIMIT $%d", len(args)-1, len(args))
+	}
+
+	return query, args
+}
+
+// Count appends only the filters to base, for counting every matching row
+// whatever page or cursor is asked for.
+func (b *Builder) Count(base string) (string, []interface{}) {
+	return where(base, b.conds), append([]interface{}{}, b.args...)
+}
+
+// Columns returns the select list with only the requested fields and the
+// always ones, the columns of the other fields are selected as NULL so rows
+// still scan into the same destinations. No requested fields selects every
+// column, names missing from the projection are rejected with ErrUnknownField.
+// Fields without a column are loaded outside the query and only checked here.
+func (p Projection) Columns(fields []string, always ...string) (string, error) {
+	for _, name := range fields {
+		if !p.has(name) {
+			return "", fmt.Errorf("%w: %s", ErrUnknownField, name)
+		}
+	}
+
+	columns := make([]string, 0, len(p))
+	for _, field := range p {
+		if field.Column == "" {
+			continue
+		}
+		if Picked(fields, field.Name) || contains(always, field.Name) {
+			columns = append(columns, field.Column)
+		} else {
+			columns = append(columns, "NULL")
+		}
+	}
+	return strings.Join(columns, ", "), nil
+}
+
+// Picked reports whether name is among the requested fields, no requested
+// fields means all of them.
+func Picked(fields []string, name string) bool {
+	return len(fields) == 0 || contains(fields, name)
+}
+
+func (p Projection) has(name string) bool {
+	for _, field := range p {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func where(base string, conds []string) string {
+	if len(conds) == 0 {
+		return base
+	}
+	return base + " WHERE " + strings.Join(conds, " AND ")
+}
+
+func (b *Builder) bind(value interface{}) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func isZero(value interface{}) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func decodeCursor(cursor string) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	if err = json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
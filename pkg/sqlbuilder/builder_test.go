@@ -0,0 +1,141 @@
+package sqlbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	b := New("2024-06-01")
+	b.Where("c.deleted_at = 0").
+		ILike("50%_off", "c.name", "c.brand").
+		Equals("c.colour", "").
+		Equals("c.brand", "BMW").
+		Range("c.year", 2015, 0).
+		In("c.status", []string{"new", "confirmed"}).
+		In("c.id", []string{}).
+		Page(3, 10)
+
+	assert.NoError(t, b.OrderBy(Columns{"price": "c.price"}, "c.id", "-price"))
+
+	query, args := b.Select("SELECT id FROM cars c")
+	assert.Equal(t, "SELECT id FROM cars c WHERE c.deleted_at = 0"+
+		" AND (c.name ILIKE $2 OR c.brand ILIKE $2)"+
+		" AND c.brand = $3 AND c.year >= $4 AND c.status = ANY($5)"+
+		" ORDER BY c.price DESC, c.id ASC OFFSET $6 LIMIT $7", query)
+	assert.Equal(t, []interface{}{"2024-06-01", `%50\%\_off%`, "BMW", 2015, []string{"new", "confirmed"}, uint64(20), uint64(10)}, args)
+
+	count, countArgs := b.Count("SELECT COUNT(*) FROM cars c")
+	assert.Equal(t, "SELECT COUNT(*) FROM cars c WHERE c.deleted_at = 0"+
+		" AND (c.name ILIKE $2 OR c.brand ILIKE $2)"+
+		" AND c.brand = $3 AND c.year >= $4 AND c.status = ANY($5)", count)
+	assert.Equal(t, args[:5], countArgs)
+}
+
+func TestFilter(t *testing.T) {
+	filters := Filters{
+		"search": {Kind: FilterILike, Columns: []string{"c.name", "c.model"}},
+		"brand":  {Kind: FilterILikePattern, Columns: []string{"c.brand"}},
+		"status": {Kind: FilterIn, Columns: []string{"c.status"}},
+		"year":   {Kind: FilterRange, Columns: []string{"c.year"}},
+		"owner":  {Kind: FilterEquals, Columns: []string{"c.owner_id"}},
+	}
+
+	b := New()
+	err := b.Filter(filters, Values{
+		"year":   Bounds{From: 2015, To: 2020},
+		"status": []string{"available"},
+		"search": "gentra",
+		"brand":  "chev%",
+		"owner":  "",
+	})
+	assert.NoError(t, err)
+
+	query, args := b.Select("SELECT id FROM cars c")
+	assert.Equal(t, "SELECT id FROM cars c WHERE c.brand ILIKE $1"+
+		" AND (c.name ILIKE $2 OR c.model ILIKE $2)"+
+		" AND c.status = ANY($3) AND c.year >= $4 AND c.year <= $5", query)
+	assert.Equal(t, []interface{}{"chev%", "%gentra%", []string{"available"}, 2015, 2020}, args)
+
+	assert.ErrorIs(t, New().Filter(filters, Values{"colour": "red"}), ErrUnknownFilter)
+	assert.ErrorIs(t, New().Filter(filters, Values{"year": 2015}), ErrUnknownFilter)
+	assert.ErrorIs(t, New().Filter(filters, Values{"status": "available"}), ErrUnknownFilter)
+}
+
+func TestWhere(t *testing.T) {
+	b := New()
+	b.Where("a BETWEEN ? AND ?", 1, 2)
+
+	query, args := b.Count("SELECT 1 FROM t")
+	assert.Equal(t, "SELECT 1 FROM t WHERE a BETWEEN $1 AND $2", query)
+	assert.Equal(t, []interface{}{1, 2}, args)
+}
+
+func TestOrderBy(t *testing.T) {
+	sortable := Columns{"created_at": "created_at"}
+
+	b := New()
+	assert.ErrorIs(t, b.OrderBy(sortable, "id", "name; DROP TABLE cars"), ErrUnknownSort)
+	assert.NoError(t, b.OrderBy(sortable, "id", "created_at"))
+
+	query, _ := b.Select("SELECT 1 FROM t")
+	assert.Equal(t, "SELECT 1 FROM t ORDER BY created_at ASC, id ASC", query)
+
+	sortable["price"] = "c.price"
+	assert.NoError(t, b.OrderBy(sortable, "id", "-price", "created_at"))
+
+	query, _ = b.Select("SELECT 1 FROM t")
+	assert.Equal(t, "SELECT 1 FROM t ORDER BY c.price DESC, created_at ASC, id ASC", query)
+}
+
+func TestProjection(t *testing.T) {
+	projection := Projection{
+		{"id", "c.id"},
+		{"name", "c.name"},
+		{"car", "c.brand"},
+		{"car", "c.model"},
+		{"created_at", "c.created_at"},
+		{"orders", ""},
+	}
+
+	columns, err := projection.Columns(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "c.id, c.name, c.brand, c.model, c.created_at", columns)
+
+	columns, err = projection.Columns([]string{"car"}, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "c.id, NULL, c.brand, c.model, NULL", columns)
+
+	_, err = projection.Columns([]string{"name", "password"})
+	assert.ErrorIs(t, err, ErrUnknownField)
+
+	columns, err = projection.Columns([]string{"orders"}, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "c.id, NULL, NULL, NULL, NULL", columns)
+
+	assert.True(t, Picked(nil, "orders"))
+	assert.False(t, Picked([]string{"name"}, "orders"))
+}
+
+func TestKeyset(t *testing.T) {
+	b := New().Equals("o.customer_id", "c1").Page(4, 10)
+
+	id := "8d6d4d1e-8b8c-4a5e-9a59-2d7f3f1f7c3b"
+	cursor := Cursor("2024-06-01T10:00:00.123456Z", id)
+	assert.NoError(t, b.Keyset(cursor, "o.created_at", "o.id"))
+
+	query, args := b.Select("SELECT id FROM orders o")
+	assert.Equal(t, "SELECT id FROM orders o WHERE o.customer_id = $1"+
+		" AND (o.created_at, o.id) > ($2, $3)"+
+		" ORDER BY o.created_at ASC, o.id ASC LIMIT $4", query)
+	assert.Equal(t, []interface{}{"c1", "2024-06-01T10:00:00.123456Z", id, uint64(10)}, args)
+
+	count, countArgs := b.Count("SELECT COUNT(*) FROM orders o")
+	assert.Equal(t, "SELECT COUNT(*) FROM orders o WHERE o.customer_id = $1", count)
+	assert.Equal(t, []interface{}{"c1"}, countArgs)
+
+	assert.ErrorIs(t, New().Keyset("not a cursor", "o.created_at", "o.id"), ErrInvalidCursor)
+	assert.ErrorIs(t, New().Keyset(Cursor("yesterday", id), "o.created_at", "o.id"), ErrInvalidCursor)
+	assert.ErrorIs(t, New().Keyset(Cursor("2024-06-01T10:00:00Z", "o9"), "o.created_at", "o.id"), ErrInvalidCursor)
+}
@@ -0,0 +1,119 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+)
+
+// MaxPixels caps the decoded size of an image, a small compressed file can
+// otherwise expand into gigabytes once decoded.
+const MaxPixels = 40_000_000
+
+var ErrTooManyPixels = errors.New("image dimensions are too large")
+
+type Image struct {
+	Data   []byte
+	Width  int
+	Height int
+}
+
+// Make decodes a jpeg or png image and returns a jpeg scaled down so its
+// longer side is at most size pixels, along with the original dimensions.
+// Images that are already small enough are re-encoded at their own size.
+func Make(data []byte, size int) (Image, image.Point, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, image.Point{}, err
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return Image{}, image.Point{}, ErrTooManyPixels
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, image.Point{}, err
+	}
+	bounds := src.Bounds()
+
+	// jpeg has no alpha channel, so transparent areas are flattened on white
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+
+	width, height := fit(bounds.Dx(), bounds.Dy(), size)
+	thumb := flat
+	if width != bounds.Dx() || height != bounds.Dy() {
+		thumb = shrink(flat, width, height)
+	}
+
+	var buf bytes.Buffer
+	if err := encode(&buf, thumb); err != nil {
+		return Image{}, image.Point{}, err
+	}
+
+	return Image{
+		Data:   buf.Bytes(),
+		Width:  width,
+		Height: height,
+	}, image.Point{X: bounds.Dx(), Y: bounds.Dy()}, nil
+}
+
+func encode(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+}
+
+// fit keeps the aspect ratio while bringing the longer side down to size.
+func fit(width, height, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+
+	if width >= height {
+		return size, max(1, height*size/width)
+	}
+	return max(1, width*size/height), size
+}
+
+// shrink downscales with a box filter, every destination pixel is the
+// average of the source pixels it covers.
+func shrink(src *image.RGBA, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0 := y * srcH / height
+		y1 := max(y0+1, (y+1)*srcH/height)
+
+		for x := 0; x < width; x++ {
+			x0 := x * srcW / width
+			x1 := max(x0+1, (x+1)*srcW/width)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			d := dst.Pix[y*dst.Stride+x*4 : y*dst.Stride+x*4+4]
+			d[0] = uint8(r / n)
+			d[1] = uint8(g / n)
+			d[2] = uint8(b / n)
+			d[3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,62 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 800; x++ {
+			src.Set(x, y, color.NRGBA{R: 200, G: 30, B: 30, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, src))
+
+	thumb, original, err := Make(buf.Bytes(), 200)
+	assert.NoError(t, err)
+	assert.Equal(t, image.Point{X: 800, Y: 400}, original)
+	assert.Equal(t, 200, thumb.Width)
+	assert.Equal(t, 100, thumb.Height)
+
+	decoded, err := jpeg.Decode(bytes.NewReader(thumb.Data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 200, 100), decoded.Bounds())
+
+	r, g, b, _ := decoded.At(100, 50).RGBA()
+	assert.InDelta(t, 200, r>>8, 8)
+	assert.InDelta(t, 30, g>>8, 8)
+	assert.InDelta(t, 30, b>>8, 8)
+}
+
+func TestMakeSizes(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 120, 300))))
+
+	thumb, _, err := Make(buf.Bytes(), 200)
+	assert.NoError(t, err)
+	assert.Equal(t, 80, thumb.Width)
+	assert.Equal(t, 200, thumb.Height)
+
+	buf.Reset()
+	assert.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 50, 40))))
+
+	thumb, _, err = Make(buf.Bytes(), 200)
+	assert.NoError(t, err)
+	assert.Equal(t, 50, thumb.Width)
+	assert.Equal(t, 40, thumb.Height)
+}
+
+func TestMakeRejectsGarbage(t *testing.T) {
+	_, _, err := Make([]byte("not an image"), 200)
+	assert.Error(t, err)
+}
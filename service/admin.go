@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/pkg/logger"
+	"rent-car/pkg/password"
+	"rent-car/storage"
+)
+
+type adminService struct {
+	storage storage.IStorage
+	logger  logger.ILogger
+}
+
+func NewAdminService(storage storage.IStorage, logger logger.ILogger) adminService {
+	return adminService{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+func (s adminService) Create(ctx context.Context, admin models.CreateAdmin) (string, error) {
+	hashedPassword, err := password.HashPassword(admin.Password)
+	if err != nil {
+		s.logger.Error("failed to hash admin password", logger.Error(err))
+		return "", err
+	}
+	admin.Password = hashedPassword
+
+	id, err := s.storage.Admin().Create(ctx, admin)
+	if err != nil {
+		s.logger.Error("failed to create admin", logger.Error(err))
+		return "", err
+	}
+	return id, nil
+}
+
+// Bootstrap creates the first admin account so that further admins can be
+// added through the API. It does nothing once any admin exists.
+func (s adminService) Bootstrap(ctx context.Context, login, pass string) error {
+	if login == "" || pass == "" {
+		return nil
+	}
+
+	count, err := s.storage.Admin().Count(ctx)
+	if err != nil {
+		s.logger.Error("failed to count admins", logger.Error(err))
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	_, err = s.Create(ctx, models.CreateAdmin{
+		FirstName: "Admin",
+		Login:     login,
+		Password:  pass,
+	})
+	return err
+}
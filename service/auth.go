@@ -12,7 +12,36 @@ import (
 	"rent-car/pkg/password"
 	"rent-car/pkg/smtp"
 	"rent-car/storage"
+	"strings"
 	"time"
+
+	"github.com/spf13/cast"
+)
+
+// redis key prefixes of the token store
+const (
+	refreshTokenKey  = "refresh:"
+	blacklistKey     = "blacklist:"
+	revokedFamilyKey = "revoked_family:"
+	// tokens a user got before this jwt.Timestamp are no longer accepted
+	revokedBeforeKey = "revoked_before:"
+
+	passwordResetKey         = "password_reset:"
+	passwordResetAttemptsKey = "password_reset_attempts:"
+	registerAttemptsKey      = "register_attempts:"
+
+	loginFailuresKey = "login_failures:"
+	loginLockedKey   = "login_locked:"
+)
+
+const (
+	registerOTPTTL   = 2 * time.Minute
+	passwordResetTTL = 10 * time.Minute
+	maxOTPAttempts   = 5
+
+	maxLoginFailures   = 5
+	loginFailureWindow = 15 * time.Minute
+	loginLockDuration  = 15 * time.Minute
 )
 
 type authService struct {
@@ -30,24 +59,41 @@ func NewAuthService(storage storage.IStorage, log logger.ILogger, redis storage.
 }
 
 func (a authService) CustomerLogin(ctx context.Context, loginRequest models.CustomerLoginRequest) (models.CustomerLoginResponse, error) {
-	fmt.Println(" loginRequest.Login: ", loginRequest.Login)
+	account := loginAccount(config.CUSTOMER_ROLE, loginRequest.Login)
+
+	if err := a.checkLockout(ctx, account); err != nil {
+		return models.CustomerLoginResponse{}, err
+	}
+
 	customer, err := a.storage.Customer().GetByLogin(ctx, loginRequest.Login)
 	if err != nil {
 		a.log.Error("error while getting customer credentials by login", logger.Error(err))
+		if errors.Is(err, storage.ErrCustomerNotFound) {
+			return models.CustomerLoginResponse{}, a.recordLoginFailure(ctx, account, ErrInvalidCredentials)
+		}
 		return models.CustomerLoginResponse{}, err
 	}
 
 	if err = password.CompareHashAndPassword(customer.Password, loginRequest.Password); err != nil {
 		a.log.Error("error while comparing password", logger.Error(err))
+		return models.CustomerLoginResponse{}, a.recordLoginFailure(ctx, account, ErrInvalidCredentials)
+	}
+
+	if err = checkNotBlocked(ctx, a.storage, customer.ID); err != nil {
+		a.log.Error("error while checking customer block", logger.Error(err))
 		return models.CustomerLoginResponse{}, err
 	}
 
+	if err = a.redis.Del(ctx, loginFailuresKey+account); err != nil {
+		a.log.Error("error while clearing login failures", logger.Error(err))
+	}
+
 	m := make(map[interface{}]interface{})
 
 	m["user_id"] = customer.ID
 	m["user_role"] = config.CUSTOMER_ROLE
 
-	accessToken, refreshToken, err := jwt.GenJWT(m)
+	accessToken, refreshToken, err := a.issueTokens(ctx, m)
 	if err != nil {
 		a.log.Error("error while generating tokens for customer login", logger.Error(err))
 		return models.CustomerLoginResponse{}, err
@@ -59,6 +105,48 @@ func (a authService) CustomerLogin(ctx context.Context, loginRequest models.Cust
 	}, nil
 }
 
+func (a authService) AdminLogin(ctx context.Context, loginRequest models.AdminLoginRequest) (models.AdminLoginResponse, error) {
+	account := loginAccount(config.ADMIN_ROLE, loginRequest.Login)
+
+	if err := a.checkLockout(ctx, account); err != nil {
+		return models.AdminLoginResponse{}, err
+	}
+
+	admin, err := a.storage.Admin().GetByLogin(ctx, loginRequest.Login)
+	if err != nil {
+		a.log.Error("error while getting admin credentials by login", logger.Error(err))
+		if errors.Is(err, storage.ErrAdminNotFound) {
+			return models.AdminLoginResponse{}, a.recordLoginFailure(ctx, account, ErrInvalidCredentials)
+		}
+		return models.AdminLoginResponse{}, err
+	}
+
+	if err = password.CompareHashAndPassword(admin.Password, loginRequest.Password); err != nil {
+		a.log.Error("error while comparing admin password", logger.Error(err))
+		return models.AdminLoginResponse{}, a.recordLoginFailure(ctx, account, ErrInvalidCredentials)
+	}
+
+	if err = a.redis.Del(ctx, loginFailuresKey+account); err != nil {
+		a.log.Error("error while clearing login failures", logger.Error(err))
+	}
+
+	m := make(map[interface{}]interface{})
+
+	m["user_id"] = admin.ID
+	m["user_role"] = config.ADMIN_ROLE
+
+	accessToken, refreshToken, err := a.issueTokens(ctx, m)
+	if err != nil {
+		a.log.Error("error while generating tokens for admin login", logger.Error(err))
+		return models.AdminLoginResponse{}, err
+	}
+
+	return models.AdminLoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
 func (a authService) ChangePassword(ctx context.Context, pass models.ChangePassword) (string, error) {
 	msg, err := a.storage.Customer().ChangePassword(ctx, pass)
 	if err != nil {
@@ -81,20 +169,25 @@ func (a authService) CustomerRegister(ctx context.Context, loginRequest models.C
 		return err
 	}
 	if exists {
-		return errors.New("customer with this email already exists")
+		return ErrEmailTaken
 	}
 
-	fmt.Println(" loginRequest.Login: ", loginRequest.Mail)
-	otpCode := pkg.GenerateOTP()
+	otpCode, err := pkg.GenerateOTP()
+	if err != nil {
+		a.log.Error("error while generating otp code for customer register", logger.Error(err))
+		return err
+	}
 
 	msg := fmt.Sprintf("Your OTP code is: %v, for registering RENT_CAR. Don't give it to anyone", otpCode)
 
-	err = a.redis.SetX(ctx, loginRequest.Mail, otpCode, time.Minute*2)
+	err = a.redis.SetX(ctx, loginRequest.Mail, otpCode, registerOTPTTL)
 	if err != nil {
 		a.log.Error("error while setting otpCode to redis customer register", logger.Error(err))
 		return err
 	}
 
+	// wrong guesses keep counting until their window expires, asking for a
+	// new code does not buy more attempts
 	err = smtp.SendMail(loginRequest.Mail, msg)
 	if err != nil {
 		a.log.Error("error while sending otp code to customer register", logger.Error(err))
@@ -107,18 +200,32 @@ func (a authService) CustomerRegister(ctx context.Context, loginRequest models.C
 func (a authService) CustomerRegisterConfirm(ctx context.Context, req models.CustomerRegisterConfirm) (models.CustomerLoginResponse, error) {
 	resp := models.CustomerLoginResponse{}
 
-	otp, err := a.redis.Get(ctx, req.Mail)
-	if err != nil {
-		a.log.Error("error while getting otp code for customer register confirm", logger.Error(err))
+	customer := models.CreateCustomer{
+		FirstName:      req.Customer.FirstName,
+		LastName:       req.Customer.LastName,
+		Email:          req.Mail,
+		Phone:          req.Customer.Phone,
+		Login:          req.Customer.Login,
+		Password:       req.Customer.Password,
+		Address:        req.Customer.Address,
+		LicenceNumber:  req.Customer.LicenceNumber,
+		LicenceCountry: req.Customer.LicenceCountry,
+		LicenceExpiry:  req.Customer.LicenceExpiry,
+		DateOfBirth:    req.Customer.DateOfBirth,
+	}
+
+	// checked before the otp so a typo in the licence does not burn the code
+	if err := prepareLicence(&customer); err != nil {
 		return resp, err
 	}
-	if req.Otp != otp {
-		a.log.Error("incorrect otp code for customer register confirm", logger.Error(err))
-		return resp, errors.New("incorrect otp code")
+
+	err := a.verifyOTP(ctx, req.Mail, registerAttemptsKey+req.Mail, req.Otp, registerOTPTTL)
+	if err != nil {
+		a.log.Error("error while checking otp code for customer register confirm", logger.Error(err))
+		return resp, err
 	}
 
-	req.Customer.Email = req.Mail
-	id, err := a.storage.Customer().Create(ctx, req.Customer)
+	id, err := a.storage.Customer().Create(ctx, customer)
 	if err != nil {
 		a.log.Error("error while creating customer", logger.Error(err))
 		return resp, err
@@ -128,7 +235,7 @@ func (a authService) CustomerRegisterConfirm(ctx context.Context, req models.Cus
 	m["user_id"] = id
 	m["user_role"] = config.CUSTOMER_ROLE
 
-	accessToken, refreshToken, err := jwt.GenJWT(m)
+	accessToken, refreshToken, err := a.issueTokens(ctx, m)
 	if err != nil {
 		a.log.Error("error while generating tokens for customer register confirm", logger.Error(err))
 		return resp, err
@@ -138,3 +245,345 @@ func (a authService) CustomerRegisterConfirm(ctx context.Context, req models.Cus
 
 	return resp, nil
 }
+
+// issueTokens signs a new token pair and registers its refresh token as the
+// only one of the family that may be exchanged.
+func (a authService) issueTokens(ctx context.Context, m map[interface{}]interface{}) (string, string, error) {
+	accessToken, refreshToken, err := jwt.GenJWT(m)
+	if err != nil {
+		return "", "", err
+	}
+
+	claims, err := jwt.ExtractClaims(refreshToken, jwt.RefreshToken)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = a.redis.SetX(ctx, refreshTokenKey+claims["jti"].(string), claims["fam"], jwt.RefreshTokenTTL)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func (a authService) Authenticate(ctx context.Context, accessToken string) (models.AuthInfo, error) {
+	claims, err := jwt.ExtractClaims(accessToken, jwt.AccessToken)
+	if err != nil {
+		return models.AuthInfo{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, _ := claims["user_id"].(string)
+	role, _ := claims["user_role"].(string)
+	jti, _ := claims["jti"].(string)
+	family, _ := claims["fam"].(string)
+	if userID == "" || jti == "" || !(role == config.ADMIN_ROLE || role == config.CUSTOMER_ROLE) {
+		return models.AuthInfo{}, ErrInvalidToken
+	}
+
+	for _, key := range []string{blacklistKey + jti, revokedFamilyKey + family} {
+		revoked, err := a.redis.Exists(ctx, key)
+		if err != nil {
+			a.log.Error("error while checking token revocation", logger.Error(err))
+			return models.AuthInfo{}, err
+		}
+		if revoked {
+			return models.AuthInfo{}, ErrTokenRevoked
+		}
+	}
+
+	if err = a.checkRevokedBefore(ctx, claims); err != nil {
+		return models.AuthInfo{}, err
+	}
+
+	return models.AuthInfo{
+		UserID:   userID,
+		UserRole: role,
+	}, nil
+}
+
+func (a authService) Refresh(ctx context.Context, req models.RefreshTokenRequest) (models.RefreshTokenResponse, error) {
+	claims, err := jwt.ExtractClaims(req.RefreshToken, jwt.RefreshToken)
+	if err != nil {
+		return models.RefreshTokenResponse{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	jti, _ := claims["jti"].(string)
+	family, _ := claims["fam"].(string)
+	if jti == "" || family == "" {
+		return models.RefreshTokenResponse{}, ErrInvalidToken
+	}
+
+	revoked, err := a.redis.Exists(ctx, revokedFamilyKey+family)
+	if err != nil {
+		a.log.Error("error while checking token family revocation", logger.Error(err))
+		return models.RefreshTokenResponse{}, err
+	}
+	if revoked {
+		return models.RefreshTokenResponse{}, ErrTokenRevoked
+	}
+
+	if err = a.checkRevokedBefore(ctx, claims); err != nil {
+		return models.RefreshTokenResponse{}, err
+	}
+
+	current, err := a.redis.Pop(ctx, refreshTokenKey+jti)
+	if err != nil {
+		a.log.Error("error while consuming refresh token", logger.Error(err))
+		return models.RefreshTokenResponse{}, err
+	}
+
+	// a signed, unexpired token that is no longer current was already
+	// rotated, so whoever holds the family may be an attacker
+	if !current {
+		if err = a.revokeFamily(ctx, family); err != nil {
+			return models.RefreshTokenResponse{}, err
+		}
+		a.log.Warning("refresh token reuse detected", logger.String("family", family))
+		return models.RefreshTokenResponse{}, ErrTokenRevoked
+	}
+
+	m := make(map[interface{}]interface{})
+
+	m["user_id"] = claims["user_id"]
+	m["user_role"] = claims["user_role"]
+	m["fam"] = family
+
+	accessToken, refreshToken, err := a.issueTokens(ctx, m)
+	if err != nil {
+		a.log.Error("error while generating tokens for refresh", logger.Error(err))
+		return models.RefreshTokenResponse{}, err
+	}
+
+	return models.RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Logout blacklists the access token until it expires and ends its session,
+// so the refresh tokens issued with it can no longer be exchanged either.
+func (a authService) Logout(ctx context.Context, accessToken string) error {
+	claims, err := jwt.ExtractClaims(accessToken, jwt.AccessToken)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	jti, _ := claims["jti"].(string)
+	family, _ := claims["fam"].(string)
+
+	if ttl := jwt.TTL(claims); ttl > 0 {
+		if err = a.redis.SetX(ctx, blacklistKey+jti, 1, ttl); err != nil {
+			a.log.Error("error while blacklisting access token", logger.Error(err))
+			return err
+		}
+	}
+
+	return a.revokeFamily(ctx, family)
+}
+
+func (a authService) revokeFamily(ctx context.Context, family string) error {
+	err := a.redis.SetX(ctx, revokedFamilyKey+family, 1, jwt.RefreshTokenTTL)
+	if err != nil {
+		a.log.Error("error while revoking token family", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+// checkRevokedBefore rejects tokens issued before the user's tokens were last revoked.
+func (a authService) checkRevokedBefore(ctx context.Context, claims map[string]interface{}) error {
+	userID, _ := claims["user_id"].(string)
+	key := revokedBeforeKey + userID
+
+	exists, err := a.redis.Exists(ctx, key)
+	if err != nil {
+		a.log.Error("error while checking user token revocation", logger.Error(err))
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	revokedBefore, err := a.redis.Get(ctx, key)
+	if err != nil {
+		a.log.Error("error while getting user token revocation", logger.Error(err))
+		return err
+	}
+
+	// both sides carry sub-second precision, a login right after the
+	// revocation keeps working
+	issuedAt, _ := claims["iat"].(float64)
+	if issuedAt < cast.ToFloat64(revokedBefore) {
+		return ErrTokenRevoked
+	}
+
+	return nil
+}
+
+func (a authService) ForgotPassword(ctx context.Context, req models.ForgotPasswordRequest) error {
+	exists, err := a.storage.Customer().CheckEmailExists(ctx, req.Mail)
+	if err != nil {
+		a.log.Error("error while checking email existence for password reset", logger.Error(err))
+		return err
+	}
+	// answer the same way for unknown emails so they cannot be probed
+	if !exists {
+		return nil
+	}
+
+	otpCode, err := pkg.GenerateOTP()
+	if err != nil {
+		a.log.Error("error while generating otp code for password reset", logger.Error(err))
+		return err
+	}
+
+	msg := fmt.Sprintf("Your OTP code is: %v, for resetting your RENT_CAR password. Don't give it to anyone", otpCode)
+
+	err = a.redis.SetX(ctx, passwordResetKey+req.Mail, otpCode, passwordResetTTL)
+	if err != nil {
+		a.log.Error("error while setting otpCode to redis for password reset", logger.Error(err))
+		return err
+	}
+
+	// wrong guesses keep counting until their window expires, asking for a
+	// new code does not buy more attempts
+	err = smtp.SendMailWithSubject(req.Mail, "Reset your RENT_CAR password", msg)
+	if err != nil {
+		a.log.Error("error while sending otp code for password reset", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (a authService) ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error {
+	err := a.verifyOTP(ctx, passwordResetKey+req.Mail, passwordResetAttemptsKey+req.Mail, req.Otp, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := password.HashPassword(req.NewPassword)
+	if err != nil {
+		a.log.Error("error while hashing new password", logger.Error(err))
+		return err
+	}
+
+	id, err := a.storage.Customer().ResetPassword(ctx, req.Mail, hashedPassword)
+	if err != nil {
+		a.log.Error("error while resetting customer password", logger.Error(err))
+		return err
+	}
+
+	err = a.redis.SetX(ctx, revokedBeforeKey+id, jwt.Timestamp(time.Now()), jwt.RefreshTokenTTL)
+	if err != nil {
+		a.log.Error("error while revoking customer tokens", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// verifyOTP checks code against the otp stored at otpKey. Codes are single
+// use and are burnt after maxOTPAttempts wrong guesses.
+func (a authService) verifyOTP(ctx context.Context, otpKey, attemptsKey, code string, ttl time.Duration) error {
+	attempts, err := a.redis.Incr(ctx, attemptsKey, ttl)
+	if err != nil {
+		a.log.Error("error while counting otp attempts", logger.Error(err))
+		return err
+	}
+	if attempts > maxOTPAttempts {
+		if _, err = a.redis.Pop(ctx, otpKey); err != nil {
+			a.log.Error("error while deleting otp code", logger.Error(err))
+			return err
+		}
+		return ErrTooManyAttempts
+	}
+
+	exists, err := a.redis.Exists(ctx, otpKey)
+	if err != nil {
+		a.log.Error("error while checking otp code", logger.Error(err))
+		return err
+	}
+	if !exists {
+		return ErrInvalidOTP
+	}
+
+	otp, err := a.redis.Get(ctx, otpKey)
+	if err != nil {
+		a.log.Error("error while getting otp code", logger.Error(err))
+		return err
+	}
+	if code != cast.ToString(otp) {
+		return ErrInvalidOTP
+	}
+
+	// a concurrent request with the same code loses here
+	used, err := a.redis.Pop(ctx, otpKey)
+	if err != nil {
+		a.log.Error("error while deleting otp code", logger.Error(err))
+		return err
+	}
+	if !used {
+		return ErrInvalidOTP
+	}
+
+	if err = a.redis.Del(ctx, attemptsKey); err != nil {
+		a.log.Error("error while clearing otp attempts", logger.Error(err))
+	}
+
+	return nil
+}
+
+// loginAccount names the account a login attempt counts against, the login
+// is trimmed and lowercased the same way the login rate limit keys it.
+func loginAccount(role, login string) string {
+	return role + ":" + strings.ToLower(strings.TrimSpace(login))
+}
+
+// checkLockout refuses logins to an account locked after too many failures.
+func (a authService) checkLockout(ctx context.Context, account string) error {
+	locked, err := a.redis.Exists(ctx, loginLockedKey+account)
+	if err != nil {
+		a.log.Error("error while checking account lockout", logger.Error(err))
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	unlockAt, err := a.redis.Get(ctx, loginLockedKey+account)
+	if err != nil {
+		a.log.Error("error while getting account unlock time", logger.Error(err))
+		return err
+	}
+
+	return fmt.Errorf("%w until %s", ErrAccountLocked, time.Unix(cast.ToInt64(unlockAt), 0).UTC().Format(time.RFC3339))
+}
+
+// recordLoginFailure counts a failed login and locks the account once it
+// reaches maxLoginFailures within loginFailureWindow. It returns loginErr
+// or the lockout error.
+func (a authService) recordLoginFailure(ctx context.Context, account string, loginErr error) error {
+	failures, err := a.redis.Incr(ctx, loginFailuresKey+account, loginFailureWindow)
+	if err != nil {
+		a.log.Error("error while counting login failures", logger.Error(err))
+		return loginErr
+	}
+	if failures < maxLoginFailures {
+		return loginErr
+	}
+
+	unlockAt := time.Now().Add(loginLockDuration)
+	if err = a.redis.SetX(ctx, loginLockedKey+account, unlockAt.Unix(), loginLockDuration); err != nil {
+		a.log.Error("error while locking account", logger.Error(err))
+		return loginErr
+	}
+	if err = a.redis.Del(ctx, loginFailuresKey+account); err != nil {
+		a.log.Error("error while clearing login failures", logger.Error(err))
+	}
+
+	a.log.Warning("account locked after failed logins", logger.String("account", account))
+
+	return fmt.Errorf("%w until %s", ErrAccountLocked, unlockAt.UTC().Format(time.RFC3339))
+}
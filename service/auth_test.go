@@ -0,0 +1,28 @@
+package service
+
+import (
+	"rent-car/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginAccount(t *testing.T) {
+	testCases := []struct {
+		name    string
+		role    string
+		login   string
+		account string
+	}{
+		{"Plain", config.CUSTOMER_ROLE, "john", config.CUSTOMER_ROLE + ":john"},
+		{"Mixed case", config.CUSTOMER_ROLE, "John", config.CUSTOMER_ROLE + ":john"},
+		{"Padded", config.CUSTOMER_ROLE, "  JOHN ", config.CUSTOMER_ROLE + ":john"},
+		{"Admin", config.ADMIN_ROLE, "John", config.ADMIN_ROLE + ":john"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.account, loginAccount(tc.role, tc.login))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg"
+	"rent-car/pkg/logger"
+	"strings"
+	"time"
+)
+
+// Cancel moves the order to cancelled through the regular status logic, which
+// charges the fee due under the cancellation policy and frees the car.
+func (s orderService) Cancel(ctx context.Context, req models.CancelOrder) (models.OrderCancellation, error) {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		return models.OrderCancellation{}, fmt.Errorf("%w: reason is required", ErrInvalidCancellation)
+	}
+
+	_, err := s.UpdateStatus(ctx, models.UpdateOrderStatus{
+		Id:            req.OrderId,
+		Status:        config.STATUS_CANCELLED,
+		Note:          req.Reason,
+		ChangedBy:     req.CancelledBy,
+		ChangedByRole: req.CancelledByRole,
+	})
+	if err != nil {
+		return models.OrderCancellation{}, err
+	}
+
+	cancellation, err := s.storage.Order().GetCancellation(ctx, req.OrderId)
+	if err != nil {
+		s.logger.Error("failed to get order cancellation", logger.Error(err))
+		return models.OrderCancellation{}, err
+	}
+	return cancellation, nil
+}
+
+// cancellationFor applies the cancellation policy to an order cancelled at now.
+func cancellationFor(policy config.CancellationPolicy, order models.GetOrderResponse, reason string, now time.Time) (models.OrderCancellation, error) {
+	date, err := pkg.ParseDate(order.FromDate)
+	if err != nil {
+		return models.OrderCancellation{}, err
+	}
+
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location())
+	hours := int(math.Floor(start.Sub(now).Hours()))
+	rate := cancellationRate(policy, start.Sub(now))
+
+	return models.OrderCancellation{
+		OrderId:     order.Id,
+		Reason:      reason,
+		HoursBefore: hours,
+		FeeRate:     rate,
+		Fee:         roundPrice(order.TotalPrice * rate),
+	}, nil
+}
+
+// noShowFor keeps the no-show share of the order total whenever the customer
+// is marked as not having turned up.
+func noShowFor(policy config.CancellationPolicy, order models.GetOrderResponse, reason string, now time.Time) (models.OrderCancellation, error) {
+	cancellation, err := cancellationFor(policy, order, reason, now)
+	if err != nil {
+		return models.OrderCancellation{}, err
+	}
+
+	cancellation.FeeRate = policy.NoShow
+	cancellation.Fee = roundPrice(order.TotalPrice * policy.NoShow)
+
+	return cancellation, nil
+}
+
+// cancellationRate is the share of the order total kept when the order is
+// cancelled the given time before the rental starts.
+func cancellationRate(policy config.CancellationPolicy, before time.Duration) float64 {
+	switch {
+	case before >= time.Duration(policy.FreeHours)*time.Hour:
+		return 0
+	case before >= time.Duration(policy.LateHours)*time.Hour:
+		return policy.Fee
+	case before > 0:
+		return policy.LateFee
+	default:
+		// the rental has started without the customer, a no-show
+		return policy.NoShow
+	}
+}
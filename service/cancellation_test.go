@@ -0,0 +1,49 @@
+package service
+
+import (
+	"rent-car/api/models"
+	"rent-car/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancellationFor(t *testing.T) {
+	order := models.GetOrderResponse{Id: "order", FromDate: "2024-06-10", TotalPrice: 300}
+	policy := config.CancellationPolicy{
+		FreeHours: config.CANCEL_FREE_HOURS,
+		LateHours: config.CANCEL_LATE_HOURS,
+		Fee:       config.CANCEL_FEE,
+		LateFee:   config.CANCEL_LATE_FEE,
+		NoShow:    config.CANCEL_NO_SHOW,
+	}
+
+	testCases := []struct {
+		name  string
+		now   time.Time
+		hours int
+		fee   float64
+	}{
+		{"Free", time.Date(2024, 6, 7, 12, 0, 0, 0, time.Local), 60, 0},
+		{"Exactly the free window", time.Date(2024, 6, 8, 0, 0, 0, 0, time.Local), 48, 0},
+		{"Late", time.Date(2024, 6, 8, 12, 0, 0, 0, time.Local), 36, 60},
+		{"Last day", time.Date(2024, 6, 9, 18, 0, 0, 0, time.Local), 6, 150},
+		{"No-show", time.Date(2024, 6, 10, 9, 0, 0, 0, time.Local), -9, 300},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cancellation, err := cancellationFor(policy, order, "reason", tc.now)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.hours, cancellation.HoursBefore)
+			assert.Equal(t, tc.fee, cancellation.Fee)
+		})
+	}
+
+	// a no-show keeps its share whenever it is marked
+	cancellation, err := noShowFor(policy, order, "", time.Date(2024, 6, 9, 18, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Equal(t, config.CANCEL_NO_SHOW, cancellation.FeeRate)
+	assert.Equal(t, 300.0, cancellation.Fee)
+}
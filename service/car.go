@@ -40,7 +40,9 @@ func (s carService) Update(ctx context.Context, car models.UpdateCarRequest) (st
 	return id, nil
 }
 
-func (s carService) GetByID(ctx context.Context, id string) (models.GetCarByIDResponse, error) {
+// GetByID returns a car with its photos, the damage history is only
+// loaded when withDamages is set as it is meant for staff.
+func (s carService) GetByID(ctx context.Context, id string, withDamages bool) (models.GetCarByIDResponse, error) {
 
 	car, err := s.storage.Car().GetByID(ctx, id)
 	if err != nil {
@@ -48,6 +50,28 @@ func (s carService) GetByID(ctx context.Context, id string) (models.GetCarByIDRe
 		return models.GetCarByIDResponse{}, err
 	}
 
+	car.Photos, err = s.storage.CarPhoto().GetByCarID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get car photos", logger.Error(err))
+		return models.GetCarByIDResponse{}, err
+	}
+	for i := range car.Photos {
+		setPhotoURLs(&car.Photos[i])
+	}
+
+	if !withDamages {
+		return car, nil
+	}
+
+	car.Damages, err = s.storage.Damage().GetByCarID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get car damage history", logger.Error(err))
+		return models.GetCarByIDResponse{}, err
+	}
+	for i := range car.Damages {
+		setDamagePhotoURLs(&car.Damages[i])
+	}
+
 	return car, nil
 
 }
@@ -59,6 +83,7 @@ func (s carService) GetAll(ctx context.Context, req models.GetAllCarsRequest) (m
 		s.logger.Error("failed to get all cars", logger.Error(err))
 		return models.GetAllCarsResponse{}, err
 	}
+	setThumbnailURLs(cars.Cars)
 
 	return cars, nil
 }
@@ -70,6 +95,7 @@ func (s carService) GetAvailable(ctx context.Context, req models.GetAvailableCar
 		s.logger.Error("failed to get available cars", logger.Error(err))
 		return models.GetAvailableCarsResponse{}, err
 	}
+	setThumbnailURLs(car.Cars)
 
 	return car, nil
 }
@@ -84,3 +110,11 @@ func (s carService) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func setThumbnailURLs(cars []models.Car) {
+	for i := range cars {
+		if cars[i].PrimaryPhotoId != "" {
+			cars[i].ThumbnailUrl = thumbnailURL(cars[i].PrimaryPhotoId)
+		}
+	}
+}
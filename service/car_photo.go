@@ -0,0 +1,186 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/pkg/thumbnail"
+	"rent-car/storage"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// photoContentTypes are the formats the standard library can decode to make
+// thumbnails from.
+var photoContentTypes = []string{"image/jpeg", "image/png"}
+
+type carPhotoService struct {
+	storage storage.IStorage
+	files   storage.IFileStorage
+	logger  logger.ILogger
+}
+
+func NewCarPhotoService(storage storage.IStorage, files storage.IFileStorage, logger logger.ILogger) carPhotoService {
+	return carPhotoService{
+		storage: storage,
+		files:   files,
+		logger:  logger,
+	}
+}
+
+// Upload stores the photo together with a jpeg thumbnail and appends it to
+// the car's gallery.
+func (s carPhotoService) Upload(ctx context.Context, carID string, file io.Reader) (models.CarPhoto, error) {
+	data, err := io.ReadAll(io.LimitReader(file, config.MAX_UPLOAD_SIZE+1))
+	if err != nil {
+		return models.CarPhoto{}, err
+	}
+	if len(data) > config.MAX_UPLOAD_SIZE {
+		return models.CarPhoto{}, fmt.Errorf("%w: files may be at most %d MB", ErrFileTooLarge, config.MAX_UPLOAD_SIZE>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(photoContentTypes, contentType) {
+		return models.CarPhoto{}, fmt.Errorf("%w: %s files are not accepted, expected one of %v", ErrInvalidPhoto, contentType, photoContentTypes)
+	}
+
+	thumb, original, err := thumbnail.Make(data, config.THUMBNAIL_SIZE)
+	if err != nil {
+		return models.CarPhoto{}, fmt.Errorf("%w: %v", ErrInvalidPhoto, err)
+	}
+
+	key := "cars/" + carID + "/photos/" + uuid.New().String()
+	thumbnailKey := key + "-thumbnail"
+	checksum := sha256.Sum256(data)
+
+	if _, err := s.files.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		s.logger.Error("failed to store car photo", logger.Error(err))
+		return models.CarPhoto{}, err
+	}
+
+	if _, err := s.files.Put(ctx, thumbnailKey, bytes.NewReader(thumb.Data)); err != nil {
+		s.logger.Error("failed to store car photo thumbnail", logger.Error(err))
+		s.removeFiles(ctx, key)
+		return models.CarPhoto{}, err
+	}
+
+	id, err := s.storage.CarPhoto().Create(ctx, models.CreateCarPhoto{
+		CarId:        carID,
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Width:        original.X,
+		Height:       original.Y,
+		Checksum:     hex.EncodeToString(checksum[:]),
+		StorageKey:   key,
+		ThumbnailKey: thumbnailKey,
+	})
+	if err != nil {
+		s.logger.Error("failed to create car photo", logger.Error(err))
+		s.removeFiles(ctx, key, thumbnailKey)
+		return models.CarPhoto{}, err
+	}
+
+	photo, err := s.storage.CarPhoto().GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get car photo", logger.Error(err))
+		return models.CarPhoto{}, err
+	}
+	setPhotoURLs(&photo)
+
+	return photo, nil
+}
+
+func (s carPhotoService) GetByCarID(ctx context.Context, carID string) ([]models.CarPhoto, error) {
+	photos, err := s.storage.CarPhoto().GetByCarID(ctx, carID)
+	if err != nil {
+		s.logger.Error("failed to get car photos", logger.Error(err))
+		return nil, err
+	}
+
+	for i := range photos {
+		setPhotoURLs(&photos[i])
+	}
+
+	return photos, nil
+}
+
+func (s carPhotoService) SetPrimary(ctx context.Context, carID, photoID string) error {
+	if err := s.storage.CarPhoto().SetPrimary(ctx, carID, photoID); err != nil {
+		s.logger.Error("failed to set primary car photo", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s carPhotoService) Reorder(ctx context.Context, req models.ReorderCarPhotos) error {
+	if err := s.storage.CarPhoto().Reorder(ctx, req); err != nil {
+		s.logger.Error("failed to reorder car photos", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s carPhotoService) Delete(ctx context.Context, carID, photoID string) error {
+	photo, err := s.storage.CarPhoto().Delete(ctx, carID, photoID)
+	if err != nil {
+		s.logger.Error("failed to delete car photo", logger.Error(err))
+		return err
+	}
+
+	s.removeFiles(ctx, photo.StorageKey, photo.ThumbnailKey)
+
+	return nil
+}
+
+// Open returns the photo, or its thumbnail, for serving. The caller must
+// close the returned reader.
+func (s carPhotoService) Open(ctx context.Context, id string, thumb bool) (models.CarPhoto, io.ReadCloser, error) {
+	photo, err := s.storage.CarPhoto().GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get car photo", logger.Error(err))
+		return models.CarPhoto{}, nil, err
+	}
+
+	key := photo.StorageKey
+	if thumb {
+		key = photo.ThumbnailKey
+	}
+
+	file, err := s.files.Open(ctx, key)
+	if err != nil {
+		s.logger.Error("failed to open car photo file", logger.Error(err))
+		return models.CarPhoto{}, nil, err
+	}
+
+	return photo, file, nil
+}
+
+// removeFiles is best effort, a leftover file is only wasted space.
+func (s carPhotoService) removeFiles(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.files.Delete(ctx, key); err != nil {
+			s.logger.Error("failed to delete car photo file", logger.Error(err))
+		}
+	}
+}
+
+func photoURL(id string) string {
+	return "/photo/" + id
+}
+
+func thumbnailURL(id string) string {
+	return "/photo/" + id + "/thumbnail"
+}
+
+func setPhotoURLs(photo *models.CarPhoto) {
+	photo.Url = photoURL(photo.Id)
+	photo.ThumbnailUrl = thumbnailURL(photo.Id)
+}
@@ -3,9 +3,14 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"rent-car/api/models"
+	"rent-car/pkg"
+	"rent-car/pkg/jwt"
 	"rent-car/pkg/logger"
 	"rent-car/storage"
+	"strings"
+	"time"
 )
 
 type customerService struct {
@@ -23,6 +28,10 @@ func NewCustomerService(storage storage.IStorage, logger logger.ILogger, redis s
 }
 
 func (s customerService) Create(ctx context.Context, customer models.CreateCustomer) (string, error) {
+	if err := prepareLicence(&customer); err != nil {
+		return "", err
+	}
+
 	pKey, err := s.storage.Customer().Create(ctx, customer)
 	if err != nil {
 		s.logger.Error("failed to create customer", logger.Error(err))
@@ -99,3 +108,69 @@ func (s customerService) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (s customerService) Block(ctx context.Context, req models.BlockCustomer) error {
+	if strings.TrimSpace(req.Reason) == "" {
+		return fmt.Errorf("%w: reason is required", ErrInvalidBlock)
+	}
+
+	if req.Until != "" {
+		until, err := pkg.ParseDate(req.Until)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBlock, err)
+		}
+		if !until.After(time.Now()) {
+			return fmt.Errorf("%w: until must be in the future", ErrInvalidBlock)
+		}
+		req.Until = until.UTC().Format(time.RFC3339)
+	}
+
+	if err := s.storage.Customer().Block(ctx, req); err != nil {
+		s.logger.Error("failed to block customer", logger.Error(err))
+		return err
+	}
+
+	// sign the customer out of every session
+	err := s.redis.SetX(ctx, revokedBeforeKey+req.CustomerId, jwt.Timestamp(time.Now()), jwt.RefreshTokenTTL)
+	if err != nil {
+		s.logger.Error("failed to revoke blocked customer tokens", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s customerService) Unblock(ctx context.Context, req models.UnblockCustomer) error {
+	if err := s.storage.Customer().Unblock(ctx, req); err != nil {
+		s.logger.Error("failed to unblock customer", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s customerService) GetBlocks(ctx context.Context, id string) (models.GetCustomerBlocksResponse, error) {
+	blocks, err := s.storage.Customer().GetBlocks(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get customer blocks", logger.Error(err))
+		return models.GetCustomerBlocksResponse{}, err
+	}
+	return blocks, nil
+}
+
+// checkNotBlocked returns ErrCustomerBlocked, with the reason and expiry, if
+// the customer is currently blocked.
+func checkNotBlocked(ctx context.Context, store storage.IStorage, customerID string) error {
+	status, err := store.Customer().GetBlockStatus(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	if !status.IsBlocked {
+		return nil
+	}
+
+	if status.BlockedUntil == "" {
+		return fmt.Errorf("%w: %s", ErrCustomerBlocked, status.Reason)
+	}
+	return fmt.Errorf("%w until %s: %s", ErrCustomerBlocked, status.BlockedUntil, status.Reason)
+}
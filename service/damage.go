@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var damageSeverities = []string{config.DAMAGE_MINOR, config.DAMAGE_MODERATE, config.DAMAGE_SEVERE}
+
+type damageService struct {
+	storage storage.IStorage
+	files   storage.IFileStorage
+	logger  logger.ILogger
+}
+
+func NewDamageService(storage storage.IStorage, files storage.IFileStorage, logger logger.ILogger) damageService {
+	return damageService{
+		storage: storage,
+		files:   files,
+		logger:  logger,
+	}
+}
+
+func (s damageService) Create(ctx context.Context, req models.CreateDamageReport) (models.DamageReport, error) {
+	req.Location = strings.TrimSpace(req.Location)
+	req.Description = strings.TrimSpace(req.Description)
+
+	if err := validateDamage(req.Location, req.Severity, req.EstimatedCost, nil); err != nil {
+		return models.DamageReport{}, err
+	}
+
+	id, err := s.storage.Damage().Create(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to create damage report", logger.Error(err))
+		return models.DamageReport{}, err
+	}
+
+	return s.GetByID(ctx, req.OrderId, id)
+}
+
+func (s damageService) GetByID(ctx context.Context, orderID, id string) (models.DamageReport, error) {
+	damage, err := s.storage.Damage().GetByID(ctx, orderID, id)
+	if err != nil {
+		s.logger.Error("failed to get damage report", logger.Error(err))
+		return models.DamageReport{}, err
+	}
+	setDamagePhotoURLs(&damage)
+
+	return damage, nil
+}
+
+func (s damageService) GetByOrderID(ctx context.Context, orderID string) (models.GetDamageReportsResponse, error) {
+	damages, err := s.storage.Damage().GetByOrderID(ctx, orderID)
+	if err != nil {
+		s.logger.Error("failed to get order damage reports", logger.Error(err))
+		return models.GetDamageReportsResponse{}, err
+	}
+
+	for i := range damages {
+		setDamagePhotoURLs(&damages[i])
+	}
+
+	return models.GetDamageReportsResponse{Damages: damages, Count: len(damages)}, nil
+}
+
+func (s damageService) Update(ctx context.Context, req models.UpdateDamageReport) (models.DamageReport, error) {
+	req.Location = strings.TrimSpace(req.Location)
+	req.Description = strings.TrimSpace(req.Description)
+
+	if err := validateDamage(req.Location, req.Severity, req.EstimatedCost, req.ActualCost); err != nil {
+		return models.DamageReport{}, err
+	}
+
+	if err := s.storage.Damage().Update(ctx, req); err != nil {
+		s.logger.Error("failed to update damage report", logger.Error(err))
+		return models.DamageReport{}, err
+	}
+
+	return s.GetByID(ctx, req.OrderId, req.Id)
+}
+
+// UploadPhoto stores a jpeg or png photo of the damage.
+func (s damageService) UploadPhoto(ctx context.Context, orderID, damageID string, file io.Reader) (models.DamagePhoto, error) {
+	if _, err := s.storage.Damage().GetByID(ctx, orderID, damageID); err != nil {
+		s.logger.Error("failed to get damage report for photo", logger.Error(err))
+		return models.DamagePhoto{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, config.MAX_UPLOAD_SIZE+1))
+	if err != nil {
+		return models.DamagePhoto{}, err
+	}
+	if len(data) > config.MAX_UPLOAD_SIZE {
+		return models.DamagePhoto{}, fmt.Errorf("%w: files may be at most %d MB", ErrFileTooLarge, config.MAX_UPLOAD_SIZE>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(photoContentTypes, contentType) {
+		return models.DamagePhoto{}, fmt.Errorf("%w: %s files are not accepted, expected one of %v", ErrInvalidPhoto, contentType, photoContentTypes)
+	}
+
+	key := "orders/" + orderID + "/damages/" + damageID + "/" + uuid.New().String()
+	checksum := sha256.Sum256(data)
+
+	if _, err := s.files.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		s.logger.Error("failed to store damage photo", logger.Error(err))
+		return models.DamagePhoto{}, err
+	}
+
+	id, err := s.storage.Damage().CreatePhoto(ctx, models.CreateDamagePhoto{
+		DamageId:    damageID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Checksum:    hex.EncodeToString(checksum[:]),
+		StorageKey:  key,
+	})
+	if err != nil {
+		s.logger.Error("failed to create damage photo", logger.Error(err))
+		if err := s.files.Delete(ctx, key); err != nil {
+			s.logger.Error("failed to delete damage photo file", logger.Error(err))
+		}
+		return models.DamagePhoto{}, err
+	}
+
+	photo, err := s.storage.Damage().GetPhoto(ctx, damageID, id)
+	if err != nil {
+		s.logger.Error("failed to get damage photo", logger.Error(err))
+		return models.DamagePhoto{}, err
+	}
+	photo.Url = damagePhotoURL(orderID, photo)
+
+	return photo, nil
+}
+
+// OpenPhoto returns a damage photo for serving. The caller must close the
+// returned reader.
+func (s damageService) OpenPhoto(ctx context.Context, orderID, damageID, id string) (models.DamagePhoto, io.ReadCloser, error) {
+	if _, err := s.storage.Damage().GetByID(ctx, orderID, damageID); err != nil {
+		s.logger.Error("failed to get damage report for photo", logger.Error(err))
+		return models.DamagePhoto{}, nil, err
+	}
+
+	photo, err := s.storage.Damage().GetPhoto(ctx, damageID, id)
+	if err != nil {
+		s.logger.Error("failed to get damage photo", logger.Error(err))
+		return models.DamagePhoto{}, nil, err
+	}
+
+	file, err := s.files.Open(ctx, photo.StorageKey)
+	if err != nil {
+		s.logger.Error("failed to open damage photo file", logger.Error(err))
+		return models.DamagePhoto{}, nil, err
+	}
+
+	return photo, file, nil
+}
+
+// validateDamage checks a damage report, the actual cost is nil until the
+// repair is known and may be 0 for a free one.
+func validateDamage(location, severity string, estimatedCost float64, actualCost *float64) error {
+	if location == "" {
+		return fmt.Errorf("%w: location is required", ErrInvalidDamage)
+	}
+	if !slices.Contains(damageSeverities, severity) {
+		return fmt.Errorf("%w: severity must be one of %v", ErrInvalidDamage, damageSeverities)
+	}
+	if estimatedCost < 0 || (actualCost != nil && *actualCost < 0) {
+		return fmt.Errorf("%w: costs can not be negative", ErrInvalidDamage)
+	}
+	return nil
+}
+
+func damagePhotoURL(orderID string, photo models.DamagePhoto) string {
+	return "/order/" + orderID + "/damages/" + photo.DamageId + "/photos/" + photo.Id
+}
+
+func setDamagePhotoURLs(damage *models.DamageReport) {
+	for i := range damage.Photos {
+		damage.Photos[i].Url = damagePhotoURL(damage.OrderId, damage.Photos[i])
+	}
+}
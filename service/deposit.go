@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"slices"
+)
+
+var (
+	deductionReasons = []string{config.DEDUCTION_DAMAGE, config.DEDUCTION_FUEL, config.DEDUCTION_LATE_RETURN}
+	// a deposit is settled only once the car is back
+	depositReleaseStatuses = []string{config.STATUS_RETURNED, config.STATUS_CLOSED}
+)
+
+type depositService struct {
+	storage storage.IStorage
+	logger  logger.ILogger
+}
+
+func NewDepositService(storage storage.IStorage, logger logger.ILogger) depositService {
+	return depositService{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+func (s depositService) Release(ctx context.Context, req models.ReleaseDeposit) (models.OrderDeposit, error) {
+	for _, deduction := range req.Deductions {
+		if !slices.Contains(deductionReasons, deduction.Reason) {
+			return models.OrderDeposit{}, fmt.Errorf("%w: unknown reason %q", ErrInvalidDeduction, deduction.Reason)
+		}
+		if deduction.Amount <= 0 {
+			return models.OrderDeposit{}, fmt.Errorf("%w: amount must be positive", ErrInvalidDeduction)
+		}
+	}
+
+	order, err := s.storage.Order().GetByID(ctx, req.OrderId)
+	if err != nil {
+		s.logger.Error("failed to get order for deposit release", logger.Error(err))
+		return models.OrderDeposit{}, err
+	}
+
+	if !slices.Contains(depositReleaseStatuses, order.Status) {
+		return models.OrderDeposit{}, fmt.Errorf("%w: order is %s", ErrDepositNotReleasable, order.Status)
+	}
+
+	deposit, err := s.storage.Deposit().Release(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to release deposit", logger.Error(err))
+		return models.OrderDeposit{}, err
+	}
+	return deposit, nil
+}
+
+func (s depositService) GetByOrderID(ctx context.Context, orderID string) (models.OrderDeposit, error) {
+	deposit, err := s.storage.Deposit().GetByOrderID(ctx, orderID)
+	if err != nil {
+		s.logger.Error("failed to get order deposit", logger.Error(err))
+		return models.OrderDeposit{}, err
+	}
+	return deposit, nil
+}
+
+func (s depositService) GetAll(ctx context.Context, req models.GetAllDepositsRequest) (models.GetAllDepositsResponse, error) {
+	deposits, err := s.storage.Deposit().GetAll(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to get all deposits", logger.Error(err))
+		return models.GetAllDepositsResponse{}, err
+	}
+	return deposits, nil
+}
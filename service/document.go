@@ -0,0 +1,239 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DownloadURLTTL is how long a signed download link stays valid.
+const DownloadURLTTL = 15 * time.Minute
+
+// downloadKeyLabel separates the download link key from the token key.
+const downloadKeyLabel = "rent-car document download v1"
+
+var (
+	customerDocumentTypes = []string{config.DOCUMENT_LICENCE, config.DOCUMENT_PASSPORT}
+	carDocumentTypes      = []string{config.DOCUMENT_REGISTRATION, config.DOCUMENT_INSURANCE, config.DOCUMENT_PHOTO}
+
+	imageContentTypes    = []string{"image/jpeg", "image/png", "image/webp"}
+	documentContentTypes = []string{"image/jpeg", "image/png", "image/webp", "application/pdf"}
+)
+
+type documentService struct {
+	storage storage.IStorage
+	files   storage.IFileStorage
+	logger  logger.ILogger
+}
+
+func NewDocumentService(storage storage.IStorage, files storage.IFileStorage, logger logger.ILogger) documentService {
+	return documentService{
+		storage: storage,
+		files:   files,
+		logger:  logger,
+	}
+}
+
+// Upload checks the file type from its content rather than the name or the
+// header the client sent, stores it and records its size and sha256.
+func (s documentService) Upload(ctx context.Context, req models.UploadDocument) (models.Document, error) {
+	var key string
+
+	switch {
+	case req.CustomerId != "":
+		if !slices.Contains(customerDocumentTypes, req.Type) {
+			return models.Document{}, fmt.Errorf("%w: customer documents must be one of %v", ErrInvalidDocument, customerDocumentTypes)
+		}
+		key = "customers/" + req.CustomerId + "/" + uuid.New().String()
+	case req.CarId != "":
+		if !slices.Contains(carDocumentTypes, req.Type) {
+			return models.Document{}, fmt.Errorf("%w: car documents must be one of %v", ErrInvalidDocument, carDocumentTypes)
+		}
+		key = "cars/" + req.CarId + "/" + uuid.New().String()
+	default:
+		return models.Document{}, fmt.Errorf("%w: document has no owner", ErrInvalidDocument)
+	}
+
+	reader := bufio.NewReaderSize(req.File, 512)
+	head, err := reader.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return models.Document{}, err
+	}
+	if len(head) == 0 {
+		return models.Document{}, fmt.Errorf("%w: file is empty", ErrInvalidDocument)
+	}
+
+	contentType := http.DetectContentType(head)
+	allowed := documentContentTypes
+	if req.Type == config.DOCUMENT_PHOTO {
+		allowed = imageContentTypes
+	}
+	if !slices.Contains(allowed, contentType) {
+		return models.Document{}, fmt.Errorf("%w: %s files are not accepted, expected one of %v", ErrInvalidDocument, contentType, allowed)
+	}
+
+	// read one byte past the limit so an oversized file can be told apart
+	// from one that is exactly at it
+	hash := sha256.New()
+	size, err := s.files.Put(ctx, key, io.TeeReader(io.LimitReader(reader, config.MAX_UPLOAD_SIZE+1), hash))
+	if err != nil {
+		s.logger.Error("failed to store uploaded file", logger.Error(err))
+		return models.Document{}, err
+	}
+
+	if size > config.MAX_UPLOAD_SIZE {
+		s.removeFile(ctx, key)
+		return models.Document{}, fmt.Errorf("%w: files may be at most %d MB", ErrFileTooLarge, config.MAX_UPLOAD_SIZE>>20)
+	}
+
+	id, err := s.storage.Document().Create(ctx, models.CreateDocument{
+		CustomerId:  req.CustomerId,
+		CarId:       req.CarId,
+		Type:        req.Type,
+		FileName:    filepath.Base(req.FileName),
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+		UploadedBy:  req.UploadedBy,
+	})
+	if err != nil {
+		s.logger.Error("failed to create document", logger.Error(err))
+		s.removeFile(ctx, key)
+		return models.Document{}, err
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s documentService) GetByID(ctx context.Context, id string) (models.Document, error) {
+	document, err := s.storage.Document().GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get document", logger.Error(err))
+		return models.Document{}, err
+	}
+
+	signDocument(&document, time.Now())
+
+	return document, nil
+}
+
+func (s documentService) GetAll(ctx context.Context, req models.GetAllDocumentsRequest) (models.GetAllDocumentsResponse, error) {
+	documents, err := s.storage.Document().GetAll(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to get all documents", logger.Error(err))
+		return models.GetAllDocumentsResponse{}, err
+	}
+
+	now := time.Now()
+	for i := range documents.Documents {
+		signDocument(&documents.Documents[i], now)
+	}
+
+	return documents, nil
+}
+
+func (s documentService) Delete(ctx context.Context, id string) error {
+	document, err := s.storage.Document().GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get document", logger.Error(err))
+		return err
+	}
+
+	if err := s.storage.Document().Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete document", logger.Error(err))
+		return err
+	}
+
+	s.removeFile(ctx, document.StorageKey)
+
+	return nil
+}
+
+// Download checks a signed link and opens the file it points to. The caller
+// must close the returned reader.
+func (s documentService) Download(ctx context.Context, id, expires, signature string) (models.Document, io.ReadCloser, error) {
+	if err := verifyDownload(id, expires, signature, time.Now()); err != nil {
+		return models.Document{}, nil, err
+	}
+
+	document, err := s.storage.Document().GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get document", logger.Error(err))
+		return models.Document{}, nil, err
+	}
+
+	file, err := s.files.Open(ctx, document.StorageKey)
+	if err != nil {
+		s.logger.Error("failed to open document file", logger.Error(err))
+		return models.Document{}, nil, err
+	}
+
+	return document, file, nil
+}
+
+// removeFile is best effort, a leftover file is only wasted space.
+func (s documentService) removeFile(ctx context.Context, key string) {
+	if err := s.files.Delete(ctx, key); err != nil {
+		s.logger.Error("failed to delete document file", logger.Error(err))
+	}
+}
+
+func signDocument(document *models.Document, now time.Time) {
+	expires := now.Add(DownloadURLTTL)
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	query.Set("signature", downloadSignature(document.Id, expires.Unix()))
+
+	document.Url = "/document/" + document.Id + "/download?" + query.Encode()
+	document.UrlExpires = expires.UTC().Format(time.RFC3339)
+}
+
+func verifyDownload(id, expires, signature string, now time.Time) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad expiry", ErrInvalidSignature)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(downloadSignature(id, expiresAt))) {
+		return ErrInvalidSignature
+	}
+
+	if now.Unix() > expiresAt {
+		return fmt.Errorf("%w: link has expired", ErrInvalidSignature)
+	}
+
+	return nil
+}
+
+func downloadSignature(id string, expires int64) string {
+	mac := hmac.New(sha256.New, downloadKey())
+	mac.Write([]byte(id + ":" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// downloadKey derives the key of download links from the token key under its
+// own label, so a link signature never verifies as anything else.
+func downloadKey() []byte {
+	mac := hmac.New(sha256.New, config.SignedKey)
+	mac.Write([]byte(downloadKeyLabel))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"rent-car/api/models"
+	"rent-car/config"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadSignature(t *testing.T) {
+	now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	document := models.Document{Id: "8f1a0f3e-4c0a-4b55-9a57-2b1f4d0c7e11"}
+
+	signDocument(&document, now)
+	assert.True(t, strings.HasPrefix(document.Url, "/document/"+document.Id+"/download?"))
+
+	link, err := url.Parse(document.Url)
+	assert.NoError(t, err)
+	expires := link.Query().Get("expires")
+	signature := link.Query().Get("signature")
+
+	assert.NoError(t, verifyDownload(document.Id, expires, signature, now))
+	assert.NoError(t, verifyDownload(document.Id, expires, signature, now.Add(DownloadURLTTL)))
+
+	err = verifyDownload(document.Id, expires, signature, now.Add(DownloadURLTTL+time.Second))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	err = verifyDownload("another-document", expires, signature, now)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	err = verifyDownload(document.Id, "9999999999", signature, now)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// links are not signed with the token key itself
+	mac := hmac.New(sha256.New, config.SignedKey)
+	mac.Write([]byte(document.Id + ":" + expires))
+	assert.NotEqual(t, hex.EncodeToString(mac.Sum(nil)), signature)
+}
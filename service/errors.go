@@ -0,0 +1,38 @@
+package service
+
+import "rent-car/pkg/apperr"
+
+var (
+	ErrInvalidStatusTransition = apperr.New(apperr.Conflict, "invalid order status transition")
+	ErrInvalidRentalPeriod     = apperr.New(apperr.Validation, "rental end date is before its start date")
+	ErrInvalidDate             = apperr.New(apperr.Validation, "invalid date")
+	ErrCarPriceNotSet          = apperr.New(apperr.Validation, "car has no daily price")
+	ErrInvalidPayment          = apperr.New(apperr.Validation, "invalid payment")
+	ErrInvalidDeduction        = apperr.New(apperr.Validation, "invalid deposit deduction")
+	ErrDepositNotReleasable    = apperr.New(apperr.Conflict, "deposit can only be released after the car is returned")
+	ErrInvalidCredentials      = apperr.New(apperr.Unauthorized, "invalid login or password")
+	ErrEmailTaken              = apperr.New(apperr.Conflict, "customer with this email already exists")
+	ErrInvalidToken            = apperr.New(apperr.Unauthorized, "invalid token")
+	ErrTokenRevoked            = apperr.New(apperr.Unauthorized, "token was revoked")
+	ErrInvalidOTP              = apperr.New(apperr.Validation, "invalid or expired otp code")
+	ErrTooManyAttempts         = apperr.New(apperr.TooManyRequests, "too many attempts")
+	ErrAccountLocked           = apperr.New(apperr.Locked, "account is locked")
+	ErrInvalidBlock            = apperr.New(apperr.Validation, "invalid customer block")
+	ErrCustomerBlocked         = apperr.New(apperr.Forbidden, "customer is blocked")
+	ErrInvalidLicence          = apperr.New(apperr.Validation, "invalid driver's licence")
+	ErrInvalidReview           = apperr.New(apperr.Validation, "invalid verification review")
+	ErrCustomerNotVerified     = apperr.New(apperr.Forbidden, "customer's licence is not verified")
+	ErrDriverUnderage          = apperr.New(apperr.Forbidden, "driver is under the minimum age")
+	ErrLicenceExpiring         = apperr.New(apperr.Forbidden, "driver's licence expires before the rental ends")
+	ErrInvalidDocument         = apperr.New(apperr.Validation, "invalid document")
+	ErrFileTooLarge            = apperr.New(apperr.TooLarge, "file is too large")
+	ErrInvalidSignature        = apperr.New(apperr.Forbidden, "invalid download link")
+	ErrInvalidPhoto            = apperr.New(apperr.Validation, "invalid photo")
+	ErrInvalidMaintenance      = apperr.New(apperr.Validation, "invalid maintenance")
+	ErrInvalidInspection       = apperr.New(apperr.Validation, "invalid inspection")
+	ErrInspectionRequired      = apperr.New(apperr.Conflict, "order is missing an inspection")
+	ErrInvalidDamage           = apperr.New(apperr.Validation, "invalid damage report")
+	ErrCarNotReturned          = apperr.New(apperr.Conflict, "car is overdue from its previous rental")
+	ErrInvalidCancellation     = apperr.New(apperr.Validation, "invalid cancellation")
+	ErrInvalidPromo            = apperr.New(apperr.Validation, "invalid promo code")
+)
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"strings"
+)
+
+func (s orderService) CreateInspection(ctx context.Context, req models.CreateOrderInspection) (string, error) {
+	if req.Kind != config.INSPECTION_PICKUP && req.Kind != config.INSPECTION_RETURN {
+		return "", fmt.Errorf("%w: kind must be %s or %s", ErrInvalidInspection, config.INSPECTION_PICKUP, config.INSPECTION_RETURN)
+	}
+	if req.Odometer < 0 {
+		return "", fmt.Errorf("%w: odometer can not be negative", ErrInvalidInspection)
+	}
+	if req.FuelLevel < 0 || req.FuelLevel > 100 {
+		return "", fmt.Errorf("%w: fuel_level must be a percentage between 0 and 100", ErrInvalidInspection)
+	}
+	req.Notes = strings.TrimSpace(req.Notes)
+
+	id, err := s.storage.Inspection().Create(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to create order inspection", logger.Error(err))
+		return "", err
+	}
+	return id, nil
+}
+
+func (s orderService) GetInspections(ctx context.Context, orderID string) ([]models.OrderInspection, error) {
+	inspections, err := s.storage.Inspection().GetByOrderID(ctx, orderID)
+	if err != nil {
+		s.logger.Error("failed to get order inspections", logger.Error(err))
+		return nil, err
+	}
+	return inspections, nil
+}
+
+// inspectionFor returns the order's inspection of the given kind.
+func inspectionFor(inspections []models.OrderInspection, kind string) (models.OrderInspection, error) {
+	for _, inspection := range inspections {
+		if inspection.Kind == kind {
+			return inspection, nil
+		}
+	}
+	return models.OrderInspection{}, fmt.Errorf("%w: no %s inspection recorded", ErrInspectionRequired, kind)
+}
+
+// returnCharges works out what the customer owes on top of the rental for
+// driving past the daily mileage allowance and bringing the car back with
+// less fuel than it left with.
+func returnCharges(fromDate, toDate string, pickup, dropoff models.OrderInspection) (models.ReturnCharges, error) {
+	days, err := rentalDays(fromDate, toDate)
+	if err != nil {
+		return models.ReturnCharges{}, err
+	}
+
+	charges := models.ReturnCharges{
+		Days:             days,
+		Driven:           dropoff.Odometer - pickup.Odometer,
+		MileageAllowance: int64(days) * config.DAILY_MILEAGE_LIMIT,
+	}
+
+	if charges.Driven > charges.MileageAllowance {
+		charges.ExcessMileage = charges.Driven - charges.MileageAllowance
+		charges.ExcessMileageCharge = roundPrice(float64(charges.ExcessMileage) * config.EXCESS_MILEAGE_RATE)
+	}
+
+	if dropoff.FuelLevel < pickup.FuelLevel {
+		charges.FuelShortfall = pickup.FuelLevel - dropoff.FuelLevel
+		charges.FuelCharge = roundPrice(float64(charges.FuelShortfall) * config.FUEL_CHARGE_PER_PERCENT)
+	}
+
+	return charges, nil
+}
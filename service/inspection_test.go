@@ -0,0 +1,71 @@
+package service
+
+import (
+	"rent-car/api/models"
+	"rent-car/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturnCharges(t *testing.T) {
+	testCases := []struct {
+		name          string
+		from          string
+		to            string
+		pickup        models.OrderInspection
+		dropoff       models.OrderInspection
+		excessMileage int64
+		mileageCharge float64
+		fuelCharge    float64
+	}{
+		{
+			name:    "Within allowance",
+			from:    "2024-06-03",
+			to:      "2024-06-05",
+			pickup:  models.OrderInspection{Odometer: 10000, FuelLevel: 100},
+			dropoff: models.OrderInspection{Odometer: 10600, FuelLevel: 100},
+		},
+		{
+			name:          "Excess mileage",
+			from:          "2024-06-03",
+			to:            "2024-06-05",
+			pickup:        models.OrderInspection{Odometer: 10000, FuelLevel: 80},
+			dropoff:       models.OrderInspection{Odometer: 10750, FuelLevel: 90},
+			excessMileage: 150,
+			mileageCharge: 37.5,
+		},
+		{
+			name:          "Same day rental short on fuel",
+			from:          "2024-06-03T09:00:00Z",
+			to:            "2024-06-03T18:00:00Z",
+			pickup:        models.OrderInspection{Odometer: 500, FuelLevel: 100},
+			dropoff:       models.OrderInspection{Odometer: 820, FuelLevel: 75},
+			excessMileage: 20,
+			mileageCharge: 5,
+			fuelCharge:    22.5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			charges, err := returnCharges(tc.from, tc.to, tc.pickup, tc.dropoff)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.excessMileage, charges.ExcessMileage)
+			assert.Equal(t, tc.mileageCharge, charges.ExcessMileageCharge)
+			assert.Equal(t, tc.fuelCharge, charges.FuelCharge)
+		})
+	}
+}
+
+func TestInspectionFor(t *testing.T) {
+	inspections := []models.OrderInspection{{Kind: config.INSPECTION_PICKUP, Odometer: 100}}
+
+	pickup, err := inspectionFor(inspections, config.INSPECTION_PICKUP)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), pickup.Odometer)
+
+	_, err = inspectionFor(inspections, config.INSPECTION_RETURN)
+	assert.ErrorIs(t, err, ErrInspectionRequired)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg"
+	"rent-car/pkg/logger"
+	"rent-car/pkg/smtp"
+	"rent-car/storage"
+	"time"
+)
+
+const lateReturnLockKey = "late_returns:lock"
+
+type lateReturnService struct {
+	storage storage.IStorage
+	redis   storage.IRedisStorage
+	logger  logger.ILogger
+}
+
+func NewLateReturnService(storage storage.IStorage, logger logger.ILogger, redis storage.IRedisStorage) lateReturnService {
+	return lateReturnService{
+		storage: storage,
+		redis:   redis,
+		logger:  logger,
+	}
+}
+
+// Start checks for late returns every interval until ctx is done. Every
+// instance runs it, the lock makes sure only one of them does the work for
+// an interval. It is held for the whole interval and left to expire, so a
+// quick run does not let another instance in before the next tick.
+func (s lateReturnService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		locked, err := s.redis.Lock(ctx, lateReturnLockKey, interval)
+		switch {
+		case err != nil:
+			s.logger.Error("failed to take late return lock", logger.Error(err))
+		case locked:
+			if err := s.Run(ctx, time.Now()); err != nil {
+				s.logger.Error("failed to check late returns", logger.Error(err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run flags the orders that are overdue at now and brings their late fees up
+// to date. Customers and staff are notified the first time an order is
+// flagged. Running it again, or on several instances at once, is safe.
+func (s lateReturnService) Run(ctx context.Context, now time.Time) error {
+	orders, err := s.storage.Order().GetOverdue(ctx, now.Format(time.DateOnly))
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		order.HoursLate, err = lateHours(order.ToDate, now)
+		if err != nil {
+			s.logger.Error("failed to parse order to_date", logger.Error(err))
+			continue
+		}
+		if order.HoursLate <= config.LATE_GRACE_HOURS {
+			continue
+		}
+		order.LateFee = lateFee(order.DailyPrice, order.HoursLate)
+
+		first, err := s.storage.Order().FlagLate(ctx, order.OrderId, order.LateFee)
+		if err != nil {
+			s.logger.Error("failed to flag late order", logger.Error(err))
+			continue
+		}
+
+		if first {
+			s.notify(order)
+		}
+	}
+
+	return nil
+}
+
+// notify is best effort, a failed message does not undo the flag.
+func (s lateReturnService) notify(order models.LateOrder) {
+	msg := fmt.Sprintf("Dear %s,\n\nyour rental of %s (order %s) was due back on %s and has not been returned yet. "+
+		"Late fees are added to your order until the car is back.\n\nRENT_CAR",
+		order.CustomerName, order.CarName, order.OrderNumber, order.ToDate)
+
+	if err := smtp.SendMailWithSubject(order.CustomerEmail, "Your RENT_CAR rental is overdue", msg); err != nil {
+		s.logger.Error("failed to send late return mail", logger.Error(err))
+	}
+
+	if _, err := pkg.TelegramBotFunc(order); err != nil {
+		s.logger.Error("failed to send late return message to staff", logger.Error(err))
+	}
+}
+
+// lateHours counts the started hours since the car was due back, which is
+// the start of the order's to_date. Rentals are half open, the return day is
+// not charged and can already be booked by the next customer.
+func lateHours(toDate string, now time.Time) (int, error) {
+	date, err := pkg.ParseDate(toDate)
+	if err != nil {
+		return 0, err
+	}
+
+	due := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location())
+	if !now.After(due) {
+		return 0, nil
+	}
+
+	return int(math.Ceil(now.Sub(due).Hours())), nil
+}
+
+// lateFee charges every full day late at config.LATE_DAILY_RATE and the
+// remaining hours at config.LATE_HOURLY_RATE of the daily price, the hours
+// never costing more than another day would.
+func lateFee(dailyPrice float64, hours int) float64 {
+	if hours <= config.LATE_GRACE_HOURS {
+		return 0
+	}
+
+	dayFee := dailyPrice * config.LATE_DAILY_RATE
+	hourFee := math.Min(float64(hours%24)*dailyPrice*config.LATE_HOURLY_RATE, dayFee)
+
+	return roundPrice(float64(hours/24)*dayFee + hourFee)
+}
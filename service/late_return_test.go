@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLateHours(t *testing.T) {
+	testCases := []struct {
+		name  string
+		now   time.Time
+		hours int
+	}{
+		{"Before the due day", time.Date(2024, 6, 4, 20, 0, 0, 0, time.Local), 0},
+		{"At the start of the due day", time.Date(2024, 6, 5, 0, 0, 0, 0, time.Local), 0},
+		{"Started hours", time.Date(2024, 6, 5, 2, 30, 0, 0, time.Local), 3},
+		{"Through the due day", time.Date(2024, 6, 5, 20, 0, 0, 0, time.Local), 20},
+		{"Next day", time.Date(2024, 6, 6, 1, 0, 0, 0, time.Local), 25},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hours, err := lateHours("2024-06-05", tc.now)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.hours, hours)
+		})
+	}
+}
+
+func TestLateFee(t *testing.T) {
+	testCases := []struct {
+		name  string
+		hours int
+		fee   float64
+	}{
+		{"Grace period", 1, 0},
+		{"Hourly", 3, 30},
+		{"Hours capped at a day", 20, 150},
+		{"Day and hours", 26, 170},
+		{"Two days", 48, 300},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.fee, lateFee(100, tc.hours))
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"strings"
+	"time"
+)
+
+type maintenanceService struct {
+	storage storage.IStorage
+	logger  logger.ILogger
+}
+
+func NewMaintenanceService(storage storage.IStorage, logger logger.ILogger) maintenanceService {
+	return maintenanceService{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+func (s maintenanceService) Create(ctx context.Context, req models.CreateMaintenance) (models.Maintenance, error) {
+	var err error
+
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		return models.Maintenance{}, fmt.Errorf("%w: title is required", ErrInvalidMaintenance)
+	}
+	if req.StartDate, req.EndDate, err = maintenanceWindow(req.StartDate, req.EndDate); err != nil {
+		return models.Maintenance{}, err
+	}
+
+	id, err := s.storage.Maintenance().Create(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to create maintenance", logger.Error(err))
+		return models.Maintenance{}, err
+	}
+
+	return s.GetByID(ctx, req.CarId, id)
+}
+
+func (s maintenanceService) GetByID(ctx context.Context, carID, id string) (models.Maintenance, error) {
+	maintenance, err := s.storage.Maintenance().GetByID(ctx, carID, id)
+	if err != nil {
+		s.logger.Error("failed to get maintenance", logger.Error(err))
+		return models.Maintenance{}, err
+	}
+	return maintenance, nil
+}
+
+func (s maintenanceService) GetAll(ctx context.Context, carID string) (models.GetAllMaintenanceResponse, error) {
+	maintenance, err := s.storage.Maintenance().GetAll(ctx, carID)
+	if err != nil {
+		s.logger.Error("failed to get all maintenance", logger.Error(err))
+		return models.GetAllMaintenanceResponse{}, err
+	}
+	return maintenance, nil
+}
+
+func (s maintenanceService) Update(ctx context.Context, req models.UpdateMaintenance) (models.Maintenance, error) {
+	var err error
+
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		return models.Maintenance{}, fmt.Errorf("%w: title is required", ErrInvalidMaintenance)
+	}
+	if req.StartDate, req.EndDate, err = maintenanceWindow(req.StartDate, req.EndDate); err != nil {
+		return models.Maintenance{}, err
+	}
+
+	if err = s.storage.Maintenance().Update(ctx, req); err != nil {
+		s.logger.Error("failed to update maintenance", logger.Error(err))
+		return models.Maintenance{}, err
+	}
+
+	return s.GetByID(ctx, req.CarId, req.Id)
+}
+
+func (s maintenanceService) Complete(ctx context.Context, req models.CompleteMaintenance) (models.Maintenance, error) {
+	if req.Mileage < 0 {
+		return models.Maintenance{}, fmt.Errorf("%w: mileage can not be negative", ErrInvalidMaintenance)
+	}
+	if req.Cost < 0 {
+		return models.Maintenance{}, fmt.Errorf("%w: cost can not be negative", ErrInvalidMaintenance)
+	}
+
+	if err := s.storage.Maintenance().Complete(ctx, req); err != nil {
+		s.logger.Error("failed to complete maintenance", logger.Error(err))
+		return models.Maintenance{}, err
+	}
+
+	return s.GetByID(ctx, req.CarId, req.Id)
+}
+
+func (s maintenanceService) Delete(ctx context.Context, carID, id string) error {
+	if err := s.storage.Maintenance().Delete(ctx, carID, id); err != nil {
+		s.logger.Error("failed to delete maintenance", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s maintenanceService) CreateInterval(ctx context.Context, req models.CreateMaintenanceInterval) (string, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidMaintenance)
+	}
+	if req.EveryKm < 0 || req.EveryDays < 0 {
+		return "", fmt.Errorf("%w: intervals can not be negative", ErrInvalidMaintenance)
+	}
+	if req.EveryKm == 0 && req.EveryDays == 0 {
+		return "", fmt.Errorf("%w: every_km or every_days is required", ErrInvalidMaintenance)
+	}
+	if req.LastServiceMileage < 0 {
+		return "", fmt.Errorf("%w: last_service_mileage can not be negative", ErrInvalidMaintenance)
+	}
+	if req.LastServiceDate != "" {
+		date, err := pkg.ParseDate(req.LastServiceDate)
+		if err != nil {
+			return "", fmt.Errorf("%w: last_service_date: %v", ErrInvalidMaintenance, err)
+		}
+		if date.After(time.Now()) {
+			return "", fmt.Errorf("%w: last_service_date can not be in the future", ErrInvalidMaintenance)
+		}
+		req.LastServiceDate = date.Format(time.DateOnly)
+	}
+
+	id, err := s.storage.Maintenance().CreateInterval(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to create maintenance interval", logger.Error(err))
+		return "", err
+	}
+	return id, nil
+}
+
+func (s maintenanceService) GetIntervals(ctx context.Context, carID string) ([]models.MaintenanceInterval, error) {
+	intervals, err := s.storage.Maintenance().GetIntervals(ctx, carID)
+	if err != nil {
+		s.logger.Error("failed to get maintenance intervals", logger.Error(err))
+		return nil, err
+	}
+	return intervals, nil
+}
+
+func (s maintenanceService) DeleteInterval(ctx context.Context, carID, id string) error {
+	if err := s.storage.Maintenance().DeleteInterval(ctx, carID, id); err != nil {
+		s.logger.Error("failed to delete maintenance interval", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetDue lists cars whose service falls due within the look ahead window,
+// the window defaults to config.MAINTENANCE_DUE_DAYS and MAINTENANCE_DUE_KM.
+func (s maintenanceService) GetDue(ctx context.Context, req models.GetDueMaintenanceRequest) (models.GetDueMaintenanceResponse, error) {
+	if req.Days <= 0 {
+		req.Days = config.MAINTENANCE_DUE_DAYS
+	}
+	if req.Km <= 0 {
+		req.Km = config.MAINTENANCE_DUE_KM
+	}
+
+	due, err := s.storage.Maintenance().GetDue(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to get due maintenance", logger.Error(err))
+		return models.GetDueMaintenanceResponse{}, err
+	}
+	return due, nil
+}
+
+// maintenanceWindow validates a maintenance period and returns it as plain
+// dates.
+func maintenanceWindow(startDate, endDate string) (string, string, error) {
+	start, err := pkg.ParseDate(startDate)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: start_date: %v", ErrInvalidMaintenance, err)
+	}
+
+	if endDate == "" {
+		endDate = startDate
+	}
+	end, err := pkg.ParseDate(endDate)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: end_date: %v", ErrInvalidMaintenance, err)
+	}
+
+	if end.Before(start) {
+		return "", "", fmt.Errorf("%w: end_date is before start_date", ErrInvalidMaintenance)
+	}
+
+	return start.Format(time.DateOnly), end.Format(time.DateOnly), nil
+}
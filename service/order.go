@@ -2,24 +2,84 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"rent-car/api/models"
+	"rent-car/config"
 	"rent-car/pkg/logger"
 	"rent-car/storage"
+	"slices"
+	"time"
 )
 
+// statusTransitions lists the statuses an order may move to from its current one.
+// Statuses missing from the keys are final.
+var statusTransitions = map[string][]string{
+	config.STATUS_NEW:       {config.STATUS_CONFIRMED, config.STATUS_CANCELLED},
+	config.STATUS_CONFIRMED: {config.STATUS_PICKED_UP, config.STATUS_CANCELLED, config.STATUS_NO_SHOW},
+	config.STATUS_PICKED_UP: {config.STATUS_RETURNED},
+	config.STATUS_RETURNED:  {config.STATUS_CLOSED},
+}
+
+// editableStatuses lists the statuses an order can still be edited in by each
+// role, once confirmed only admins may change it.
+var editableStatuses = map[string][]string{
+	config.CUSTOMER_ROLE: {config.STATUS_NEW},
+	config.ADMIN_ROLE:    {config.STATUS_NEW, config.STATUS_CONFIRMED},
+}
+
 type orderService struct {
-	storage storage.IStorage
-	logger  logger.ILogger
+	storage      storage.IStorage
+	logger       logger.ILogger
+	pricing      pricingService
+	cancellation config.CancellationPolicy
 }
 
-func NewOrderService(storage storage.IStorage, logger logger.ILogger) orderService {
+func NewOrderService(storage storage.IStorage, logger logger.ILogger, cancellation config.CancellationPolicy) orderService {
 	return orderService{
-		storage: storage,
-		logger:  logger,
+		storage:      storage,
+		logger:       logger,
+		pricing:      NewPricingService(storage, logger),
+		cancellation: cancellation,
 	}
 }
 
 func (s orderService) Create(ctx context.Context, order models.CreateOrder) (string, error) {
+	if err := checkNotBlocked(ctx, s.storage, order.CustomerId); err != nil {
+		s.logger.Error("failed to check customer block", logger.Error(err))
+		return "", err
+	}
+
+	if err := checkDriverEligible(ctx, s.storage, order.CustomerId, order.FromDate, order.ToDate); err != nil {
+		s.logger.Error("customer is not eligible to rent", logger.Error(err))
+		return "", err
+	}
+
+	booked, err := s.storage.Order().CheckOverlap(ctx, order.CarId, order.FromDate, order.ToDate, "")
+	if err != nil {
+		s.logger.Error("failed to check order overlap", logger.Error(err))
+		return "", err
+	}
+	if booked {
+		return "", storage.ErrCarAlreadyBooked
+	}
+
+	quote, err := s.pricing.Quote(ctx, models.OrderQuoteRequest{
+		CarId:    order.CarId,
+		FromDate: order.FromDate,
+		ToDate:   order.ToDate,
+	})
+	if err != nil {
+		s.logger.Error("failed to price order", logger.Error(err))
+		return "", err
+	}
+	order.TotalPrice = quote.Total
+
+	if order.PromoCode != "" {
+		order.PromoCode = normalizePromoCode(order.PromoCode)
+		order.RentalDays = quote.Days
+	}
+
 	pKey, err := s.storage.Order().Create(ctx, order)
 	if err != nil {
 		s.logger.Error("failed to create order", logger.Error(err))
@@ -29,6 +89,48 @@ func (s orderService) Create(ctx context.Context, order models.CreateOrder) (str
 }
 
 func (s orderService) Update(ctx context.Context, order models.UpdateOrder) (string, error) {
+	current, err := s.storage.Order().GetByID(ctx, order.Id)
+	if err != nil {
+		s.logger.Error("failed to get order for update", logger.Error(err))
+		return "", err
+	}
+
+	order.EditableStatuses = editableStatuses[order.ChangedByRole]
+	if !slices.Contains(order.EditableStatuses, current.Status) {
+		return "", fmt.Errorf("%w: %s order can not be edited", ErrInvalidStatusTransition, current.Status)
+	}
+
+	if err := checkNotBlocked(ctx, s.storage, order.CustomerId); err != nil {
+		s.logger.Error("failed to check customer block", logger.Error(err))
+		return "", err
+	}
+
+	if err := checkDriverEligible(ctx, s.storage, order.CustomerId, order.FromDate, order.ToDate); err != nil {
+		s.logger.Error("customer is not eligible to rent", logger.Error(err))
+		return "", err
+	}
+
+	booked, err := s.storage.Order().CheckOverlap(ctx, order.CarId, order.FromDate, order.ToDate, order.Id)
+	if err != nil {
+		s.logger.Error("failed to check order overlap", logger.Error(err))
+		return "", err
+	}
+	if booked {
+		return "", storage.ErrCarAlreadyBooked
+	}
+
+	quote, err := s.pricing.Quote(ctx, models.OrderQuoteRequest{
+		CarId:    order.CarId,
+		FromDate: order.FromDate,
+		ToDate:   order.ToDate,
+	})
+	if err != nil {
+		s.logger.Error("failed to price order", logger.Error(err))
+		return "", err
+	}
+	order.TotalPrice = quote.Total
+	order.RentalDays = quote.Days
+
 	id, err := s.storage.Order().Update(ctx, order)
 	if err != nil {
 		s.logger.Error("failed to update order", logger.Error(err))
@@ -37,7 +139,96 @@ func (s orderService) Update(ctx context.Context, order models.UpdateOrder) (str
 	return id, nil
 }
 
+func (s orderService) Quote(ctx context.Context, req models.OrderQuoteRequest) (models.OrderQuoteResponse, error) {
+	quote, err := s.pricing.Quote(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to quote order", logger.Error(err))
+		return models.OrderQuoteResponse{}, err
+	}
+	return quote, nil
+}
+
 func (s orderService) UpdateStatus(ctx context.Context, status models.UpdateOrderStatus) (models.UpdateStatus, error) {
+	order, err := s.storage.Order().GetByID(ctx, status.Id)
+	if err != nil {
+		s.logger.Error("failed to get order for status update", logger.Error(err))
+		return models.UpdateStatus{}, err
+	}
+
+	if !slices.Contains(statusTransitions[order.Status], status.Status) {
+		return models.UpdateStatus{}, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, order.Status, status.Status)
+	}
+	status.FromStatus = order.Status
+	status.CaptureDeposit = status.Status == config.STATUS_PICKED_UP
+
+	// the car has to be inspected as it leaves and as it comes back
+	switch status.Status {
+	case config.STATUS_PICKED_UP, config.STATUS_RETURNED:
+		inspections, err := s.storage.Inspection().GetByOrderID(ctx, status.Id)
+		if err != nil {
+			s.logger.Error("failed to get order inspections", logger.Error(err))
+			return models.UpdateStatus{}, err
+		}
+
+		pickup, err := inspectionFor(inspections, config.INSPECTION_PICKUP)
+		if err != nil {
+			return models.UpdateStatus{}, err
+		}
+
+		if status.Status == config.STATUS_RETURNED {
+			dropoff, err := inspectionFor(inspections, config.INSPECTION_RETURN)
+			if err != nil {
+				return models.UpdateStatus{}, err
+			}
+
+			charges, err := returnCharges(order.FromDate, order.ToDate, pickup, dropoff)
+			if err != nil {
+				return models.UpdateStatus{}, err
+			}
+
+			car, err := s.storage.Car().GetByID(ctx, order.Car.ID)
+			if err != nil {
+				s.logger.Error("failed to get car for late fee", logger.Error(err))
+				return models.UpdateStatus{}, err
+			}
+
+			charges.HoursLate, err = lateHours(order.ToDate, time.Now())
+			if err != nil {
+				return models.UpdateStatus{}, err
+			}
+			charges.LateFee = lateFee(car.Price, charges.HoursLate)
+
+			status.ReturnCharges = &charges
+		}
+	}
+
+	switch status.Status {
+	case config.STATUS_CANCELLED:
+		cancellation, err := cancellationFor(s.cancellation, order, status.Note, time.Now())
+		if err != nil {
+			return models.UpdateStatus{}, err
+		}
+		status.Cancellation = &cancellation
+	case config.STATUS_NO_SHOW:
+		cancellation, err := noShowFor(s.cancellation, order, status.Note, time.Now())
+		if err != nil {
+			return models.UpdateStatus{}, err
+		}
+		status.Cancellation = &cancellation
+	}
+
+	// the car has to be back from its previous rental before the next one is confirmed
+	if status.Status == config.STATUS_CONFIRMED {
+		late, err := s.storage.Order().HasLateReturn(ctx, order.Car.ID)
+		if err != nil {
+			s.logger.Error("failed to check car late returns", logger.Error(err))
+			return models.UpdateStatus{}, err
+		}
+		if late {
+			return models.UpdateStatus{}, ErrCarNotReturned
+		}
+	}
+
 	updated, err := s.storage.Order().UpdateStatus(ctx, status)
 	if err != nil {
 		s.logger.Error("failed to update order status", logger.Error(err))
@@ -46,15 +237,61 @@ func (s orderService) UpdateStatus(ctx context.Context, status models.UpdateOrde
 	return updated, nil
 }
 
+func (s orderService) GetStatusHistory(ctx context.Context, id string) (models.GetOrderStatusHistoryResponse, error) {
+	history, err := s.storage.Order().GetStatusHistory(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get order status history", logger.Error(err))
+		return models.GetOrderStatusHistoryResponse{}, err
+	}
+	return history, nil
+}
+
 func (s orderService) GetByID(ctx context.Context, id string) (models.GetOrderResponse, error) {
 	order, err := s.storage.Order().GetByID(ctx, id)
 	if err != nil {
 		s.logger.Error("failed to get order by ID", logger.Error(err))
 		return models.GetOrderResponse{}, err
 	}
+
+	deposit, err := s.storage.Deposit().GetByOrderID(ctx, id)
+	switch {
+	case err == nil:
+		order.Deposit = &deposit
+	case !errors.Is(err, storage.ErrDepositNotFound):
+		s.logger.Error("failed to get order deposit", logger.Error(err))
+		return models.GetOrderResponse{}, err
+	}
+
+	order.Inspections, err = s.storage.Inspection().GetByOrderID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get order inspections", logger.Error(err))
+		return models.GetOrderResponse{}, err
+	}
+
+	// no-shows keep a cancellation record too, with the fee they were charged
+	if order.Status == config.STATUS_CANCELLED || order.Status == config.STATUS_NO_SHOW {
+		cancellation, err := s.storage.Order().GetCancellation(ctx, id)
+		switch {
+		case err == nil:
+			order.Cancellation = &cancellation
+		case !errors.Is(err, storage.ErrCancellationNotFound):
+			s.logger.Error("failed to get order cancellation", logger.Error(err))
+			return models.GetOrderResponse{}, err
+		}
+	}
+
 	return order, nil
 }
 
+func (s orderService) GetCustomerID(ctx context.Context, id string) (string, error) {
+	customerID, err := s.storage.Order().GetCustomerID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get order customer", logger.Error(err))
+		return "", err
+	}
+	return customerID, nil
+}
+
 func (s orderService) GetAll(ctx context.Context, req models.GetAllOrdersRequest) (models.GetAllOrdersResponse, error) {
 
 	orders, err := s.storage.Order().GetAll(ctx, req)
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"slices"
+)
+
+var (
+	paymentKinds   = []string{config.PAYMENT_CHARGE, config.PAYMENT_PARTIAL}
+	paymentMethods = []string{config.METHOD_CASH, config.METHOD_CARD, config.METHOD_TRANSFER}
+)
+
+type paymentService struct {
+	storage storage.IStorage
+	logger  logger.ILogger
+}
+
+func NewPaymentService(storage storage.IStorage, logger logger.ILogger) paymentService {
+	return paymentService{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+func (s paymentService) Create(ctx context.Context, payment models.CreatePayment) (string, error) {
+	if !slices.Contains(paymentKinds, payment.Kind) {
+		return "", fmt.Errorf("%w: unknown kind %q", ErrInvalidPayment, payment.Kind)
+	}
+
+	if err := validatePayment(payment.Method, payment.Amount); err != nil {
+		return "", err
+	}
+
+	id, err := s.storage.Payment().Create(ctx, payment)
+	if err != nil {
+		s.logger.Error("failed to create payment", logger.Error(err))
+		return "", err
+	}
+	return id, nil
+}
+
+func (s paymentService) Refund(ctx context.Context, orderID string, refund models.CreateRefund) (string, error) {
+	if err := validatePayment(refund.Method, refund.Amount); err != nil {
+		return "", err
+	}
+
+	id, err := s.storage.Payment().Create(ctx, models.CreatePayment{
+		OrderId:   orderID,
+		Kind:      config.PAYMENT_REFUND,
+		Method:    refund.Method,
+		Amount:    refund.Amount,
+		Reference: refund.Reference,
+	})
+	if err != nil {
+		s.logger.Error("failed to refund payment", logger.Error(err))
+		return "", err
+	}
+	return id, nil
+}
+
+func (s paymentService) GetByOrderID(ctx context.Context, orderID string) (models.GetOrderPaymentsResponse, error) {
+	payments, err := s.storage.Payment().GetByOrderID(ctx, orderID)
+	if err != nil {
+		s.logger.Error("failed to get order payments", logger.Error(err))
+		return models.GetOrderPaymentsResponse{}, err
+	}
+	return payments, nil
+}
+
+func validatePayment(method string, amount float64) error {
+	if !slices.Contains(paymentMethods, method) {
+		return fmt.Errorf("%w: unknown method %q", ErrInvalidPayment, method)
+	}
+
+	if amount <= 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidPayment)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"time"
+)
+
+type pricingService struct {
+	storage storage.IStorage
+	logger  logger.ILogger
+}
+
+func NewPricingService(storage storage.IStorage, logger logger.ILogger) pricingService {
+	return pricingService{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+func (s pricingService) Quote(ctx context.Context, req models.OrderQuoteRequest) (models.OrderQuoteResponse, error) {
+	car, err := s.storage.Car().GetByID(ctx, req.CarId)
+	if err != nil {
+		s.logger.Error("failed to get car for quote", logger.Error(err))
+		return models.OrderQuoteResponse{}, err
+	}
+
+	quote, err := calculateQuote(car.Price, req.FromDate, req.ToDate)
+	if err != nil {
+		return models.OrderQuoteResponse{}, err
+	}
+	quote.CarId = req.CarId
+
+	return quote, nil
+}
+
+// calculateQuote charges weekend days at a premium over the daily price and
+// discounts the whole rental once it reaches the weekly or monthly tier.
+func calculateQuote(dailyPrice float64, fromDate, toDate string) (models.OrderQuoteResponse, error) {
+	if dailyPrice <= 0 {
+		return models.OrderQuoteResponse{}, ErrCarPriceNotSet
+	}
+
+	days, err := rentalDays(fromDate, toDate)
+	if err != nil {
+		return models.OrderQuoteResponse{}, err
+	}
+
+	start, err := pkg.ParseDate(fromDate)
+	if err != nil {
+		return models.OrderQuoteResponse{}, fmt.Errorf("%w: %v", ErrInvalidDate, err)
+	}
+
+	weekendDays := 0
+	for i := 0; i < days; i++ {
+		switch start.AddDate(0, 0, i).Weekday() {
+		case time.Saturday, time.Sunday:
+			weekendDays++
+		}
+	}
+	weekdayDays := days - weekendDays
+
+	quote := models.OrderQuoteResponse{
+		FromDate:   fromDate,
+		ToDate:     toDate,
+		Days:       days,
+		DailyPrice: dailyPrice,
+		Lines:      []models.QuoteLine{},
+		Tier:       "daily",
+	}
+
+	if weekdayDays > 0 {
+		quote.Lines = append(quote.Lines, models.QuoteLine{
+			Description: "weekday",
+			Days:        weekdayDays,
+			Rate:        dailyPrice,
+			Amount:      roundPrice(dailyPrice * float64(weekdayDays)),
+		})
+	}
+
+	if weekendDays > 0 {
+		weekendRate := roundPrice(dailyPrice * config.WEEKEND_RATE)
+		quote.Lines = append(quote.Lines, models.QuoteLine{
+			Description: "weekend",
+			Days:        weekendDays,
+			Rate:        weekendRate,
+			Amount:      roundPrice(weekendRate * float64(weekendDays)),
+		})
+	}
+
+	for _, line := range quote.Lines {
+		quote.Subtotal += line.Amount
+	}
+	quote.Subtotal = roundPrice(quote.Subtotal)
+
+	discount := 0.0
+	switch {
+	case days >= config.MONTHLY_MIN_DAYS:
+		quote.Tier = "monthly"
+		discount = config.MONTHLY_DISCOUNT
+	case days >= config.WEEKLY_MIN_DAYS:
+		quote.Tier = "weekly"
+		discount = config.WEEKLY_DISCOUNT
+	}
+
+	quote.Discount = roundPrice(quote.Subtotal * discount)
+	quote.Total = roundPrice(quote.Subtotal - quote.Discount)
+
+	return quote, nil
+}
+
+// rentalDays counts the days charged for a rental, a same day rental counts
+// as one.
+func rentalDays(fromDate, toDate string) (int, error) {
+	duration, err := pkg.Duration(fromDate, toDate)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidDate, err)
+	}
+	if duration < 0 {
+		return 0, ErrInvalidRentalPeriod
+	}
+
+	days := int(math.Ceil(duration))
+	if days == 0 {
+		days = 1
+	}
+	return days, nil
+}
+
+func roundPrice(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateQuote(t *testing.T) {
+	testCases := []struct {
+		name     string
+		price    float64
+		from     string
+		to       string
+		days     int
+		tier     string
+		subtotal float64
+		total    float64
+	}{
+		// 2024-06-03 is a Monday
+		{"Weekdays only", 100, "2024-06-03", "2024-06-06", 3, "daily", 300, 300},
+		{"Weekend premium", 100, "2024-06-07", "2024-06-10", 3, "daily", 340, 340},
+		{"Same day rental", 100, "2024-06-03T09:00:00Z", "2024-06-03T18:00:00Z", 1, "daily", 100, 100},
+		{"Weekly tier", 100, "2024-06-03", "2024-06-10", 7, "weekly", 740, 666},
+		{"Monthly tier", 100, "2024-06-03", "2024-07-03", 30, "monthly", 3160, 2370},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			quote, err := calculateQuote(tc.price, tc.from, tc.to)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.days, quote.Days)
+			assert.Equal(t, tc.tier, quote.Tier)
+			assert.Equal(t, tc.subtotal, quote.Subtotal)
+			assert.Equal(t, tc.total, quote.Total)
+		})
+	}
+}
+
+func TestCalculateQuoteErrors(t *testing.T) {
+	_, err := calculateQuote(0, "2024-06-03", "2024-06-06")
+	assert.ErrorIs(t, err, ErrCarPriceNotSet)
+
+	_, err = calculateQuote(100, "2024-06-06", "2024-06-03")
+	assert.ErrorIs(t, err, ErrInvalidRentalPeriod)
+
+	_, err = calculateQuote(100, "June 3", "2024-06-06")
+	assert.ErrorIs(t, err, ErrInvalidDate)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"strings"
+	"time"
+)
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,40}$`)
+
+type promoService struct {
+	storage storage.IStorage
+	logger  logger.ILogger
+}
+
+func NewPromoService(storage storage.IStorage, logger logger.ILogger) promoService {
+	return promoService{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+func (s promoService) Create(ctx context.Context, req models.CreatePromoCode) (models.PromoCode, error) {
+	var err error
+
+	req.Code = normalizePromoCode(req.Code)
+	if !promoCodePattern.MatchString(req.Code) {
+		return models.PromoCode{}, fmt.Errorf("%w: code must be 3 to 40 letters, digits, '-' or '_'", ErrInvalidPromo)
+	}
+	req.Brand = strings.TrimSpace(req.Brand)
+	req.Model = strings.TrimSpace(req.Model)
+
+	req.ValidFrom, req.ValidTo, err = promoTerms(req.DiscountType, req.DiscountValue, req.ValidFrom, req.ValidTo,
+		req.MaxUses, req.MaxUsesPerCustomer, req.MinRentalDays)
+	if err != nil {
+		return models.PromoCode{}, err
+	}
+
+	id, err := s.storage.Promo().Create(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to create promo code", logger.Error(err))
+		return models.PromoCode{}, err
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s promoService) GetByID(ctx context.Context, id string) (models.PromoCode, error) {
+	promo, err := s.storage.Promo().GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get promo code", logger.Error(err))
+		return models.PromoCode{}, err
+	}
+	return promo, nil
+}
+
+func (s promoService) GetAll(ctx context.Context, req models.GetAllPromoCodesRequest) (models.GetAllPromoCodesResponse, error) {
+	req.Search = normalizePromoCode(req.Search)
+
+	promos, err := s.storage.Promo().GetAll(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to get all promo codes", logger.Error(err))
+		return models.GetAllPromoCodesResponse{}, err
+	}
+	return promos, nil
+}
+
+func (s promoService) Update(ctx context.Context, req models.UpdatePromoCode) (models.PromoCode, error) {
+	var err error
+
+	req.Brand = strings.TrimSpace(req.Brand)
+	req.Model = strings.TrimSpace(req.Model)
+
+	req.ValidFrom, req.ValidTo, err = promoTerms(req.DiscountType, req.DiscountValue, req.ValidFrom, req.ValidTo,
+		req.MaxUses, req.MaxUsesPerCustomer, req.MinRentalDays)
+	if err != nil {
+		return models.PromoCode{}, err
+	}
+
+	if err = s.storage.Promo().Update(ctx, req); err != nil {
+		s.logger.Error("failed to update promo code", logger.Error(err))
+		return models.PromoCode{}, err
+	}
+
+	return s.GetByID(ctx, req.Id)
+}
+
+func (s promoService) Delete(ctx context.Context, id string) error {
+	if err := s.storage.Promo().Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete promo code", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+// normalizePromoCode makes codes case insensitive.
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// promoTerms validates the discount and limits of a promo code and returns its
+// validity window as dates, an empty bound leaves that side open.
+func promoTerms(discountType string, value float64, validFrom, validTo string, maxUses, maxUsesPerCustomer, minRentalDays int) (string, string, error) {
+	switch discountType {
+	case config.PROMO_PERCENT:
+		if value <= 0 || value > 100 {
+			return "", "", fmt.Errorf("%w: percent discount must be between 0 and 100", ErrInvalidPromo)
+		}
+	case config.PROMO_FIXED:
+		if value <= 0 {
+			return "", "", fmt.Errorf("%w: discount_value must be positive", ErrInvalidPromo)
+		}
+	default:
+		return "", "", fmt.Errorf("%w: discount_type must be %s or %s", ErrInvalidPromo, config.PROMO_PERCENT, config.PROMO_FIXED)
+	}
+
+	if maxUses < 0 || maxUsesPerCustomer < 0 || minRentalDays < 0 {
+		return "", "", fmt.Errorf("%w: limits can not be negative", ErrInvalidPromo)
+	}
+
+	var from, to time.Time
+	if validFrom != "" {
+		date, err := pkg.ParseDate(validFrom)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: valid_from: %v", ErrInvalidPromo, err)
+		}
+		from = date
+		validFrom = date.Format(time.DateOnly)
+	}
+	if validTo != "" {
+		date, err := pkg.ParseDate(validTo)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: valid_to: %v", ErrInvalidPromo, err)
+		}
+		to = date
+		validTo = date.Format(time.DateOnly)
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return "", "", fmt.Errorf("%w: valid_to is before valid_from", ErrInvalidPromo)
+	}
+
+	return validFrom, validTo, nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"rent-car/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoTerms(t *testing.T) {
+	testCases := []struct {
+		name         string
+		discountType string
+		value        float64
+		validFrom    string
+		validTo      string
+		maxUses      int
+		err          error
+	}{
+		{"Percent", config.PROMO_PERCENT, 15, "2024-06-01", "2024-08-31", 100, nil},
+		{"Fixed without window", config.PROMO_FIXED, 25, "", "", 0, nil},
+		{"Percent over 100", config.PROMO_PERCENT, 120, "", "", 0, ErrInvalidPromo},
+		{"Zero discount", config.PROMO_FIXED, 0, "", "", 0, ErrInvalidPromo},
+		{"Unknown type", "bogo", 10, "", "", 0, ErrInvalidPromo},
+		{"Negative limit", config.PROMO_FIXED, 10, "", "", -1, ErrInvalidPromo},
+		{"Window backwards", config.PROMO_FIXED, 10, "2024-08-31", "2024-06-01", 0, ErrInvalidPromo},
+		{"Bad date", config.PROMO_FIXED, 10, "June", "", 0, ErrInvalidPromo},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := promoTerms(tc.discountType, tc.value, tc.validFrom, tc.validTo, tc.maxUses, 0, 0)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"time"
+)
+
+const rateLimitKey = "rate:"
+
+type rateLimitService struct {
+	redis  storage.IRedisStorage
+	logger logger.ILogger
+}
+
+func NewRateLimitService(redis storage.IRedisStorage, logger logger.ILogger) rateLimitService {
+	return rateLimitService{
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+// Allow records a request for key and reports whether it is within limit
+// requests per sliding window.
+func (s rateLimitService) Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error) {
+	hits, err := s.redis.Hit(ctx, rateLimitKey+key, window)
+	if err != nil {
+		s.logger.Error("failed to count rate limited request", logger.Error(err))
+		return false, err
+	}
+	return hits <= limit, nil
+}
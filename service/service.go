@@ -1,31 +1,62 @@
 package service
 
 import (
+	"rent-car/config"
 	"rent-car/pkg/logger"
 	"rent-car/storage"
 )
 
 type IServiceManager interface {
 	Car() carService
+	CarPhoto() carPhotoService
 	Customer() customerService
 	Order() orderService
+	Payment() paymentService
+	Deposit() depositService
+	Admin() adminService
+	Document() documentService
+	Damage() damageService
+	Maintenance() maintenanceService
+	Promo() promoService
+	LateReturn() lateReturnService
+	RateLimit() rateLimitService
 	Auth() authService
 }
 
 type Service struct {
 	carService      carService
+	carPhotoService carPhotoService
 	customerService customerService
 	orderService    orderService
+	paymentService  paymentService
+	depositService  depositService
+	adminService    adminService
+	documentService documentService
+	damageService   damageService
+	maintenance     maintenanceService
+	promo           promoService
+	lateReturn      lateReturnService
+	rateLimit       rateLimitService
 	auth            authService
 
 	logger logger.ILogger
 }
 
-func New(storage storage.IStorage, log logger.ILogger, redis storage.IRedisStorage) Service {
+func New(storage storage.IStorage, files storage.IFileStorage, log logger.ILogger, redis storage.IRedisStorage, cancellation config.CancellationPolicy) Service {
 	return Service{
 		carService:      NewCarService(storage, log),
+		carPhotoService: NewCarPhotoService(storage, files, log),
 		customerService: NewCustomerService(storage, log, redis),
-		orderService:    NewOrderService(storage, log),
+		orderService:    NewOrderService(storage, log, cancellation),
+		paymentService:  NewPaymentService(storage, log),
+		depositService:  NewDepositService(storage, log),
+		adminService:    NewAdminService(storage, log),
+		documentService: NewDocumentService(storage, files, log),
+		damageService:   NewDamageService(storage, files, log),
+		maintenance:     NewMaintenanceService(storage, log),
+		promo:           NewPromoService(storage, log),
+		lateReturn:      NewLateReturnService(storage, log, redis),
+		rateLimit:       NewRateLimitService(redis, log),
 		auth:            NewAuthService(storage, log, redis),
 		logger:          log,
 	}
@@ -35,6 +66,10 @@ func (s Service) Car() carService {
 	return s.carService
 }
 
+func (s Service) CarPhoto() carPhotoService {
+	return s.carPhotoService
+}
+
 func (s Service) Customer() customerService {
 	return s.customerService
 }
@@ -43,6 +78,42 @@ func (s Service) Order() orderService {
 	return s.orderService
 }
 
+func (s Service) Payment() paymentService {
+	return s.paymentService
+}
+
+func (s Service) Deposit() depositService {
+	return s.depositService
+}
+
+func (s Service) Admin() adminService {
+	return s.adminService
+}
+
+func (s Service) Maintenance() maintenanceService {
+	return s.maintenance
+}
+
+func (s Service) Promo() promoService {
+	return s.promo
+}
+
+func (s Service) Damage() damageService {
+	return s.damageService
+}
+
+func (s Service) Document() documentService {
+	return s.documentService
+}
+
+func (s Service) LateReturn() lateReturnService {
+	return s.lateReturn
+}
+
+func (s Service) RateLimit() rateLimitService {
+	return s.rateLimit
+}
+
 func (s Service) Auth() authService {
 	return s.auth
 }
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"strings"
+	"time"
+)
+
+func (s customerService) UpdateLicence(ctx context.Context, req models.UpdateCustomerLicence) error {
+	if err := normalizeLicence(&req); err != nil {
+		return err
+	}
+
+	if err := s.storage.Customer().UpdateLicence(ctx, req); err != nil {
+		s.logger.Error("failed to update customer licence", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s customerService) ReviewVerification(ctx context.Context, req models.ReviewCustomerVerification) error {
+	switch req.Status {
+	case config.VERIFICATION_VERIFIED:
+	case config.VERIFICATION_REJECTED:
+		if strings.TrimSpace(req.Note) == "" {
+			return fmt.Errorf("%w: a note is required when rejecting", ErrInvalidReview)
+		}
+	default:
+		return fmt.Errorf("%w: status must be %s or %s", ErrInvalidReview, config.VERIFICATION_VERIFIED, config.VERIFICATION_REJECTED)
+	}
+
+	if err := s.storage.Customer().ReviewVerification(ctx, req); err != nil {
+		s.logger.Error("failed to review customer verification", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s customerService) GetVerification(ctx context.Context, id string) (models.CustomerVerification, error) {
+	verification, err := s.storage.Customer().GetVerification(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get customer verification", logger.Error(err))
+		return models.CustomerVerification{}, err
+	}
+	return verification, nil
+}
+
+// prepareLicence validates licence details given at sign up and queues them
+// for review. Customers may sign up without a licence and add it later.
+func prepareLicence(customer *models.CreateCustomer) error {
+	if customer.LicenceNumber == "" && customer.LicenceCountry == "" &&
+		customer.LicenceExpiry == "" && customer.DateOfBirth == "" {
+		customer.VerificationStatus = config.VERIFICATION_UNVERIFIED
+		return nil
+	}
+
+	licence := models.UpdateCustomerLicence{
+		LicenceNumber:  customer.LicenceNumber,
+		LicenceCountry: customer.LicenceCountry,
+		LicenceExpiry:  customer.LicenceExpiry,
+		DateOfBirth:    customer.DateOfBirth,
+	}
+	if err := normalizeLicence(&licence); err != nil {
+		return err
+	}
+
+	customer.LicenceNumber = licence.LicenceNumber
+	customer.LicenceCountry = licence.LicenceCountry
+	customer.LicenceExpiry = licence.LicenceExpiry
+	customer.DateOfBirth = licence.DateOfBirth
+	customer.VerificationStatus = config.VERIFICATION_PENDING
+
+	return nil
+}
+
+// normalizeLicence checks that every licence field is present and rewrites
+// the dates as plain dates.
+func normalizeLicence(licence *models.UpdateCustomerLicence) error {
+	licence.LicenceNumber = strings.ToUpper(strings.TrimSpace(licence.LicenceNumber))
+	licence.LicenceCountry = strings.TrimSpace(licence.LicenceCountry)
+
+	if licence.LicenceNumber == "" {
+		return fmt.Errorf("%w: licence_number is required", ErrInvalidLicence)
+	}
+	if licence.LicenceCountry == "" {
+		return fmt.Errorf("%w: licence_country is required", ErrInvalidLicence)
+	}
+
+	expiry, err := pkg.ParseDate(licence.LicenceExpiry)
+	if err != nil {
+		return fmt.Errorf("%w: licence_expiry: %v", ErrInvalidLicence, err)
+	}
+	if expiry.Before(time.Now()) {
+		return fmt.Errorf("%w: licence has expired", ErrInvalidLicence)
+	}
+
+	birth, err := pkg.ParseDate(licence.DateOfBirth)
+	if err != nil {
+		return fmt.Errorf("%w: date_of_birth: %v", ErrInvalidLicence, err)
+	}
+	if !birth.Before(time.Now()) {
+		return fmt.Errorf("%w: date_of_birth must be in the past", ErrInvalidLicence)
+	}
+
+	licence.LicenceExpiry = expiry.Format(time.DateOnly)
+	licence.DateOfBirth = birth.Format(time.DateOnly)
+
+	return nil
+}
+
+// checkDriverEligible returns an error if the customer may not drive the car
+// for the whole rental period.
+func checkDriverEligible(ctx context.Context, store storage.IStorage, customerID, fromDate, toDate string) error {
+	verification, err := store.Customer().GetVerification(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	return driverEligibility(verification, fromDate, toDate)
+}
+
+func driverEligibility(verification models.CustomerVerification, fromDate, toDate string) error {
+	if verification.Status != config.VERIFICATION_VERIFIED {
+		return fmt.Errorf("%w: verification is %s", ErrCustomerNotVerified, verification.Status)
+	}
+
+	from, err := pkg.ParseDate(fromDate)
+	if err != nil {
+		return fmt.Errorf("%w: from_date: %v", ErrInvalidDate, err)
+	}
+	to, err := pkg.ParseDate(toDate)
+	if err != nil {
+		return fmt.Errorf("%w: to_date: %v", ErrInvalidDate, err)
+	}
+
+	birth, err := pkg.ParseDate(verification.DateOfBirth)
+	if err != nil {
+		return fmt.Errorf("%w: date_of_birth: %v", ErrCustomerNotVerified, err)
+	}
+	if birth.AddDate(config.MIN_DRIVER_AGE, 0, 0).After(from) {
+		return fmt.Errorf("%w: drivers must be at least %d", ErrDriverUnderage, config.MIN_DRIVER_AGE)
+	}
+
+	// the licence is valid through the whole of its expiry day
+	expiry, err := pkg.ParseDate(verification.LicenceExpiry)
+	if err != nil {
+		return fmt.Errorf("%w: licence_expiry: %v", ErrCustomerNotVerified, err)
+	}
+	if !to.Before(expiry.AddDate(0, 0, 1)) {
+		return fmt.Errorf("%w: licence expires on %s", ErrLicenceExpiring, verification.LicenceExpiry)
+	}
+
+	return nil
+}
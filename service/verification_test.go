@@ -0,0 +1,48 @@
+package service
+
+import (
+	"rent-car/api/models"
+	"rent-car/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriverEligibility(t *testing.T) {
+	verified := models.CustomerVerification{
+		Status:        config.VERIFICATION_VERIFIED,
+		DateOfBirth:   "2000-06-05",
+		LicenceExpiry: "2024-06-10",
+	}
+
+	testCases := []struct {
+		name     string
+		status   string
+		from     string
+		to       string
+		expected error
+	}{
+		{"Eligible", config.VERIFICATION_VERIFIED, "2024-06-05", "2024-06-08", nil},
+		{"Licence valid on its expiry day", config.VERIFICATION_VERIFIED, "2024-06-05", "2024-06-10T18:00:00Z", nil},
+		{"Pending verification", config.VERIFICATION_PENDING, "2024-06-05", "2024-06-08", ErrCustomerNotVerified},
+		{"Rejected verification", config.VERIFICATION_REJECTED, "2024-06-05", "2024-06-08", ErrCustomerNotVerified},
+		{"Under age at pickup", config.VERIFICATION_VERIFIED, "2021-06-04", "2021-06-08", ErrDriverUnderage},
+		{"Licence expires during rental", config.VERIFICATION_VERIFIED, "2024-06-05", "2024-06-11", ErrLicenceExpiring},
+		{"Unparsable pickup date", config.VERIFICATION_VERIFIED, "next friday", "2024-06-08", ErrInvalidDate},
+		{"Unparsable return date", config.VERIFICATION_VERIFIED, "2024-06-05", "08/06/2024", ErrInvalidDate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			verification := verified
+			verification.Status = tc.status
+
+			err := driverEligibility(verification, tc.from, tc.to)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package storage
+
+import "rent-car/pkg/apperr"
+
+var (
+	ErrCarAlreadyBooked     = apperr.New(apperr.Conflict, "car is already booked for this period")
+	ErrOrderStatusChanged   = apperr.New(apperr.Conflict, "order status was changed by another request")
+	ErrOrderNotFound        = apperr.New(apperr.NotFound, "order not found")
+	ErrCancellationNotFound = apperr.New(apperr.NotFound, "order was not cancelled")
+	ErrCustomerNotFound     = apperr.New(apperr.NotFound, "customer not found")
+	ErrCarNotFound          = apperr.New(apperr.NotFound, "car not found")
+	ErrAdminNotFound        = apperr.New(apperr.NotFound, "admin not found")
+	ErrRefundExceedsPaid    = apperr.New(apperr.Conflict, "refund exceeds the amount paid")
+	ErrPasswordMismatch     = apperr.New(apperr.Validation, "old password does not match")
+
+	ErrVerificationNotPending = apperr.New(apperr.Conflict, "customer has no licence awaiting review")
+
+	ErrDocumentNotFound      = apperr.New(apperr.NotFound, "document not found")
+	ErrDocumentOwnerNotFound = apperr.New(apperr.NotFound, "customer or car for the document was not found")
+	ErrFileNotFound          = apperr.New(apperr.NotFound, "file not found")
+	ErrInvalidFileKey        = apperr.New(apperr.Validation, "invalid file key")
+
+	ErrPhotoNotFound      = apperr.New(apperr.NotFound, "photo not found")
+	ErrPhotoOrderMismatch = apperr.New(apperr.Validation, "photo order must list every photo of the car once")
+
+	ErrMaintenanceNotFound  = apperr.New(apperr.NotFound, "maintenance not found")
+	ErrMaintenanceCompleted = apperr.New(apperr.Conflict, "maintenance was already completed")
+	ErrMaintenanceConflict  = apperr.New(apperr.Conflict, "car is booked during the maintenance window")
+	ErrCarInMaintenance     = apperr.New(apperr.Conflict, "car is in maintenance for this period")
+	ErrIntervalNotFound     = apperr.New(apperr.NotFound, "maintenance interval not found")
+
+	ErrInspectionExists     = apperr.New(apperr.Conflict, "order already has this inspection")
+	ErrInspectionNotAllowed = apperr.New(apperr.Conflict, "order is not in a status for this inspection")
+	ErrOdometerRollback     = apperr.New(apperr.Validation, "odometer reading is below the car's recorded mileage")
+
+	ErrDamageNotFound      = apperr.New(apperr.NotFound, "damage report not found")
+	ErrDamagePhotoNotFound = apperr.New(apperr.NotFound, "damage photo not found")
+	ErrReturnNotInspected  = apperr.New(apperr.Conflict, "order has no return inspection")
+
+	ErrPromoNotFound      = apperr.New(apperr.NotFound, "promo code not found")
+	ErrPromoCodeExists    = apperr.New(apperr.Conflict, "promo code already exists")
+	ErrPromoNotValid      = apperr.New(apperr.Validation, "promo code is not valid at this time")
+	ErrPromoExhausted     = apperr.New(apperr.Conflict, "promo code usage limit reached")
+	ErrPromoNotApplicable = apperr.New(apperr.Validation, "promo code does not apply to this order")
+
+	ErrDepositNotFound         = apperr.New(apperr.NotFound, "order has no deposit on hold")
+	ErrDepositAlreadyReleased  = apperr.New(apperr.Conflict, "deposit was already released")
+	ErrDeductionExceedsDeposit = apperr.New(apperr.Conflict, "deductions exceed the deposit amount")
+)
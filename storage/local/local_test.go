@@ -0,0 +1,46 @@
+package local
+
+import (
+	"context"
+	"io"
+	"rent-car/storage"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	files, err := New(t.TempDir())
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	size, err := files.Put(ctx, "customers/1/licence", strings.NewReader("licence scan"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), size)
+
+	file, err := files.Open(ctx, "customers/1/licence")
+	assert.NoError(t, err)
+
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "licence scan", string(content))
+	assert.NoError(t, file.Close())
+
+	assert.NoError(t, files.Delete(ctx, "customers/1/licence"))
+	assert.NoError(t, files.Delete(ctx, "customers/1/licence"))
+
+	_, err = files.Open(ctx, "customers/1/licence")
+	assert.ErrorIs(t, err, storage.ErrFileNotFound)
+}
+
+func TestStoreRejectsEscapingKeys(t *testing.T) {
+	files, err := New(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "cars/../../outside", "cars\\1"} {
+		_, err := files.Put(context.Background(), key, strings.NewReader("x"))
+		assert.ErrorIs(t, err, storage.ErrInvalidFileKey, key)
+	}
+}
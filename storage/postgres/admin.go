@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AdminRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewAdminRepo(db *pgxpool.Pool, log logger.ILogger) AdminRepo {
+	return AdminRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (a *AdminRepo) Create(ctx context.Context, admin models.CreateAdmin) (string, error) {
+	id := uuid.New().String()
+
+	query := `INSERT INTO admins (
+		id,
+		first_name,
+		last_name,
+		login,
+		password,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err := a.db.Exec(ctx, query,
+		id,
+		admin.FirstName,
+		admin.LastName,
+		admin.Login,
+		admin.Password,
+	)
+
+	if err != nil {
+		a.logger.Error("failed to create admin in database", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (a *AdminRepo) GetByLogin(ctx context.Context, login string) (models.Admin, error) {
+	var (
+		admin     models.Admin
+		firstname sql.NullString
+		lastname  sql.NullString
+		createdat sql.NullString
+		updatedat sql.NullString
+	)
+
+	query := `SELECT
+		id,
+		first_name,
+		last_name,
+		login,
+		password,
+		created_at,
+		updated_at
+	FROM admins WHERE login = $1 AND deleted_at = 0`
+
+	err := a.db.QueryRow(ctx, query, login).Scan(
+		&admin.ID,
+		&firstname,
+		&lastname,
+		&admin.Login,
+		&admin.Password,
+		&createdat,
+		&updatedat,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Admin{}, storage.ErrAdminNotFound
+		}
+		a.logger.Error("failed to scan admin by LOGIN from database", logger.Error(err))
+		return models.Admin{}, err
+	}
+
+	admin.FirstName = firstname.String
+	admin.LastName = lastname.String
+	admin.CreatedAt = createdat.String
+	admin.UpdatedAt = updatedat.String
+
+	return admin, nil
+}
+
+func (a *AdminRepo) Count(ctx context.Context) (int64, error) {
+	var count int64
+
+	query := `SELECT COUNT(id) FROM admins WHERE deleted_at = 0`
+
+	err := a.db.QueryRow(ctx, query).Scan(&count)
+	if err != nil {
+		a.logger.Error("failed to get admins count from database", logger.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
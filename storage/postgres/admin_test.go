@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAdmin(t *testing.T) {
+	adminRepo := NewAdminRepo(db, log)
+
+	admin := models.CreateAdmin{
+		FirstName: faker.FirstName(),
+		LastName:  faker.LastName(),
+		Login:     faker.Username(),
+		Password:  faker.Password(),
+	}
+
+	id, err := adminRepo.Create(context.Background(), admin)
+	assert.NoError(t, err)
+
+	got, err := adminRepo.GetByLogin(context.Background(), admin.Login)
+	assert.NoError(t, err)
+	assert.Equal(t, id, got.ID)
+	assert.Equal(t, admin.Password, got.Password)
+
+	count, err := adminRepo.Count(context.Background())
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, count, int64(1))
+
+	_, err = db.Exec(context.Background(), `DELETE FROM admins WHERE id = $1`, id)
+	assert.NoError(t, err)
+}
@@ -3,14 +3,68 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"errors"
 	"rent-car/api/models"
+	"rent-car/config"
 	"rent-car/pkg/logger"
+	"rent-car/pkg/sqlbuilder"
+	"rent-car/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// carSortColumns are the columns car lists can be sorted by.
+var carSortColumns = sqlbuilder.Columns{
+	"created_at":  "c.created_at",
+	"name":        "c.name",
+	"year":        "c.year",
+	"price":       "c.price",
+	"horse_power": "c.horse_power",
+	"mileage":     "c.mileage",
+}
+
+// carFilters are the filters car lists accept.
+var carFilters = sqlbuilder.Filters{
+	"search":      {Kind: sqlbuilder.FilterILike, Columns: []string{"c.name", "c.brand", "c.model"}},
+	"brand":       {Kind: sqlbuilder.FilterILikePattern, Columns: []string{"c.brand"}},
+	"colour":      {Kind: sqlbuilder.FilterILikePattern, Columns: []string{"c.colour"}},
+	"year":        {Kind: sqlbuilder.FilterRange, Columns: []string{"c.year"}},
+	"horse_power": {Kind: sqlbuilder.FilterRange, Columns: []string{"c.horse_power"}},
+	"price":       {Kind: sqlbuilder.FilterRange, Columns: []string{"c.price"}},
+}
+
+// carFields are the columns of car lists under the field names of models.Car.
+var carFields = sqlbuilder.Projection{
+	{Name: "id", Column: "c.id"},
+	{Name: "name", Column: "c.name"},
+	{Name: "year", Column: "c.year"},
+	{Name: "brand", Column: "c.brand"},
+	{Name: "model", Column: "c.model"},
+	{Name: "horse_power", Column: "c.horse_power"},
+	{Name: "colour", Column: "c.colour"},
+	{Name: "engine_cap", Column: "c.engine_cap"},
+	{Name: "price", Column: "c.price"},
+	{Name: "deposit", Column: "c.deposit"},
+	{Name: "mileage", Column: "c.mileage"},
+	{Name: "created_at", Column: "c.created_at"},
+	{Name: "updated_at", Column: "c.updated_at"},
+	{Name: "thumbnail_url", Column: "(SELECT p.id::text FROM car_photos p WHERE p.car_id = c.id AND p.is_primary)"},
+}
+
+// availablePeriod is the rental period searched for as a half open range,
+// $1 and $2 are its dates. An empty period means "today", a missing or same
+// day end date means a one day rental.
+const availablePeriod = `daterange(
+	COALESCE(NULLIF($1, '')::date, CURRENT_DATE),
+	GREATEST(
+		COALESCE(NULLIF($2, '')::date, NULLIF($1, '')::date, CURRENT_DATE),
+		COALESCE(NULLIF($1, '')::date, CURRENT_DATE) + 1
+	),
+	'[)'
+)`
+
 type CarRepo struct {
 	db     *pgxpool.Pool
 	logger logger.ILogger
@@ -35,9 +89,12 @@ func (c *CarRepo) Create(ctx context.Context, car models.CreateCarRequest) (stri
 		horse_power,
 		colour,
 		engine_cap,
+		price,
+		deposit,
+		mileage,
 		created_at,
 		updated_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
 	_, err := c.db.Exec(ctx, query,
 		id,
@@ -48,6 +105,9 @@ func (c *CarRepo) Create(ctx context.Context, car models.CreateCarRequest) (stri
 		car.HorsePower,
 		car.Colour,
 		car.EngineCap,
+		car.Price,
+		car.Deposit,
+		car.Mileage,
 	)
 
 	if err != nil {
@@ -67,10 +127,13 @@ func (c *CarRepo) Update(ctx context.Context, car models.UpdateCarRequest) (stri
 		horse_power = $5,
 		colour = $6,
 		engine_cap = $7,
+		price = $8,
+		deposit = $9,
+		mileage = GREATEST(mileage, $10),
 		updated_at = CURRENT_TIMESTAMP
-	WHERE id = $8`
+	WHERE id = $11 AND deleted_at = 0`
 
-	_, err := c.db.Exec(ctx, query,
+	tag, err := c.db.Exec(ctx, query,
 		car.Name,
 		car.Year,
 		car.Brand,
@@ -78,6 +141,9 @@ func (c *CarRepo) Update(ctx context.Context, car models.UpdateCarRequest) (stri
 		car.HorsePower,
 		car.Colour,
 		car.EngineCap,
+		car.Price,
+		car.Deposit,
+		car.Mileage,
 		car.ID,
 	)
 
@@ -86,6 +152,10 @@ func (c *CarRepo) Update(ctx context.Context, car models.UpdateCarRequest) (stri
 		return "", err
 	}
 
+	if tag.RowsAffected() == 0 {
+		return "", storage.ErrCarNotFound
+	}
+
 	return car.ID, nil
 }
 
@@ -99,6 +169,9 @@ func (c *CarRepo) GetByID(ctx context.Context, id string) (models.GetCarByIDResp
 		horsepower sql.NullInt64
 		colour     sql.NullString
 		enginecap  sql.NullFloat64
+		price      sql.NullFloat64
+		deposit    sql.NullFloat64
+		mileage    sql.NullInt64
 		createdat  sql.NullString
 		updatedat  sql.NullString
 	)
@@ -112,6 +185,9 @@ func (c *CarRepo) GetByID(ctx context.Context, id string) (models.GetCarByIDResp
 		horse_power,
 		colour,
 		engine_cap,
+		price,
+		deposit,
+		mileage,
 		created_at,
 		updated_at
 	FROM cars
@@ -128,11 +204,17 @@ func (c *CarRepo) GetByID(ctx context.Context, id string) (models.GetCarByIDResp
 		&horsepower,
 		&colour,
 		&enginecap,
+		&price,
+		&deposit,
+		&mileage,
 		&createdat,
 		&updatedat,
 	)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.GetCarByIDResponse{}, storage.ErrCarNotFound
+		}
 		c.logger.Error("failed to get car by ID from database", logger.Error(err))
 		return models.GetCarByIDResponse{}, err
 	}
@@ -144,6 +226,9 @@ func (c *CarRepo) GetByID(ctx context.Context, id string) (models.GetCarByIDResp
 	car.HorsePower = horsepower.Int64
 	car.Colour = colour.String
 	car.EngineCap = float32(enginecap.Float64)
+	car.Price = price.Float64
+	car.Deposit = deposit.Float64
+	car.Mileage = mileage.Int64
 	car.CreatedAt = createdat.String
 	car.UpdatedAt = updatedat.String
 
@@ -160,33 +245,38 @@ func (c *CarRepo) GetAll(ctx context.Context, req models.GetAllCarsRequest) (mod
 		horsepower sql.NullInt64
 		colour     sql.NullString
 		enginecap  sql.NullFloat64
+		price      sql.NullFloat64
+		deposit    sql.NullFloat64
+		mileage    sql.NullInt64
 		createdat  sql.NullString
 		updatedat  sql.NullString
-		filter     string
+		photoid    sql.NullString
 	)
 
-	offset := (req.Page - 1) * req.Limit
+	b := sqlbuilder.New().
+		Where("c.deleted_at = 0").
+		Page(req.Page, req.Limit)
 
-	if req.Search != "" {
-		filter = fmt.Sprintf(` AND (name ILIKE '%%%v%%' OR brand ILIKE '%%%v%%' OR model ILIKE '%%%v%%')`, req.Search, req.Search, req.Search)
+	if err := b.Filter(carFilters, sqlbuilder.Values{"search": req.Search}); err != nil {
+		return resp, err
 	}
 
-	filter += fmt.Sprintf(" OFFSET %v LIMIT %v", offset, req.Limit)
-
-	query := `SELECT 
-		id, 
-		name, 
-		year, 
-		brand, 
-		model, 
-		horse_power, 
-		colour, 
-		engine_cap, 
-		created_at, 
-		updated_at
-	FROM cars WHERE deleted_at = 0` + filter
+	sort := req.Sort
+	if len(sort) == 0 {
+		sort = []string{"created_at"}
+	}
+	if err := b.OrderBy(carSortColumns, "c.id", sort...); err != nil {
+		return resp, err
+	}
+
+	columns, err := carFields.Columns(req.Fields, "id")
+	if err != nil {
+		return resp, err
+	}
+
+	query, args := b.Select(`SELECT ` + columns + ` FROM cars c`)
 
-	rows, err := c.db.Query(ctx, query)
+	rows, err := c.db.Query(ctx, query, args...)
 	if err != nil {
 		c.logger.Error("failed to get all cars from database", logger.Error(err))
 		return resp, err
@@ -205,8 +295,12 @@ func (c *CarRepo) GetAll(ctx context.Context, req models.GetAllCarsRequest) (mod
 			&horsepower,
 			&colour,
 			&enginecap,
+			&price,
+			&deposit,
+			&mileage,
 			&createdat,
 			&updatedat,
+			&photoid,
 		)
 
 		if err != nil {
@@ -215,21 +309,30 @@ func (c *CarRepo) GetAll(ctx context.Context, req models.GetAllCarsRequest) (mod
 		}
 
 		resp.Cars = append(resp.Cars, models.Car{
-			ID:         car.ID,
-			Name:       name.String,
-			Year:       year.Int64,
-			Brand:      brand.String,
-			Model:      model.String,
-			HorsePower: horsepower.Int64,
-			Colour:     colour.String,
-			EngineCap:  float32(enginecap.Float64),
-			CreatedAt:  createdat.String,
-			UpdatedAt:  updatedat.String,
+			ID:             car.ID,
+			Name:           name.String,
+			Year:           year.Int64,
+			Brand:          brand.String,
+			Model:          model.String,
+			HorsePower:     horsepower.Int64,
+			Colour:         colour.String,
+			EngineCap:      float32(enginecap.Float64),
+			Price:          price.Float64,
+			Deposit:        deposit.Float64,
+			Mileage:        mileage.Int64,
+			CreatedAt:      createdat.String,
+			UpdatedAt:      updatedat.String,
+			PrimaryPhotoId: photoid.String,
 		})
 	}
 
-	countQuery := `SELECT COUNT(id) FROM cars`
-	err = c.db.QueryRow(ctx, countQuery).Scan(&resp.Count)
+	if err = rows.Err(); err != nil {
+		c.logger.Error("failed to get all cars from database", logger.Error(err))
+		return models.GetAllCarsResponse{}, err
+	}
+
+	countQuery, countArgs := b.Count(`SELECT COUNT(*) FROM cars c`)
+	err = c.db.QueryRow(ctx, countQuery, countArgs...).Scan(&resp.Count)
 	if err != nil {
 		c.logger.Error("failed to get cars count from database", logger.Error(err))
 		return resp, err
@@ -242,7 +345,6 @@ func (c *CarRepo) GetAvailable(ctx context.Context, req models.GetAvailableCarsR
 	var (
 		cars       models.GetAvailableCarsResponse
 		count      uint64
-		filter     string
 		name       sql.NullString
 		year       sql.NullInt64
 		brand      sql.NullString
@@ -250,37 +352,67 @@ func (c *CarRepo) GetAvailable(ctx context.Context, req models.GetAvailableCarsR
 		horsepower sql.NullInt64
 		colour     sql.NullString
 		enginecap  sql.NullFloat64
+		price      sql.NullFloat64
+		deposit    sql.NullFloat64
+		mileage    sql.NullInt64
 		createdat  sql.NullString
 		updatedat  sql.NullString
+		photoid    sql.NullString
 	)
-	offset := (req.Page - 1) * req.Limit
 
-	if req.Search != "" {
-		filter = fmt.Sprintf(` AND (name ILIKE '%%%v%%' OR brand ILIKE '%%%v%%' OR model ILIKE '%%%v%%')`, req.Search, req.Search, req.Search)
+	b := sqlbuilder.New(req.From, req.To, nonBlockingStatuses, config.MAINTENANCE_SCHEDULED).
+		Where("c.deleted_at = 0").
+		Where(`NOT EXISTS (
+			SELECT 1
+			FROM orders o
+			WHERE o.car_id = c.id
+				AND o.deleted_at = 0
+				AND o.status <> ALL($3)
+				AND daterange(o.from_date, GREATEST(o.to_date, o.from_date + 1), '[)') && `+availablePeriod+`
+		)`).
+		Where(`NOT EXISTS (
+			SELECT 1
+			FROM maintenance m
+			WHERE m.car_id = c.id
+				AND m.status = $4
+				AND daterange(m.start_date, m.end_date + 1, '[)') && `+availablePeriod+`
+		)`).
+		Page(req.Page, req.Limit)
+
+	err := b.Filter(carFilters, sqlbuilder.Values{
+		"search":      req.Search,
+		"brand":       req.Brand,
+		"colour":      req.Colour,
+		"year":        sqlbuilder.Bounds{From: req.YearFrom, To: req.YearTo},
+		"horse_power": sqlbuilder.Bounds{From: req.HorsePowerFrom, To: req.HorsePowerTo},
+		"price":       sqlbuilder.Bounds{To: req.MaxPrice},
+	})
+	if err != nil {
+		return models.GetAvailableCarsResponse{}, err
 	}
 
-	filter += fmt.Sprintf(" OFFSET %v LIMIT %v", offset, req.Limit)
-
-	query := `SELECT
-			id,
-			name,
-			year,
-			brand,
-			model,
-			horse_power,
-			colour,
-			engine_cap,
-			created_at,
-			updated_at
-		FROM cars
-		WHERE id NOT IN (
-			SELECT DISTINCT car_id
-			FROM orders
-			WHERE from_date <= NOW() AND to_date >= NOW()
-		)
-	` + filter
+	if err := b.OrderBy(carSortColumns, "c.id", "created_at"); err != nil {
+		return models.GetAvailableCarsResponse{}, err
+	}
 
-	rows, err := c.db.Query(ctx, query)
+	query, args := b.Select(`SELECT
+			c.id,
+			c.name,
+			c.year,
+			c.brand,
+			c.model,
+			c.horse_power,
+			c.colour,
+			c.engine_cap,
+			c.price,
+			c.deposit,
+			c.mileage,
+			c.created_at,
+			c.updated_at,
+			(SELECT p.id::text FROM car_photos p WHERE p.car_id = c.id AND p.is_primary)
+		FROM cars c`)
+
+	rows, err := c.db.Query(ctx, query, args...)
 	if err != nil {
 		c.logger.Error("failed to get available cars from database", logger.Error(err))
 		return models.GetAvailableCarsResponse{}, err
@@ -298,8 +430,12 @@ func (c *CarRepo) GetAvailable(ctx context.Context, req models.GetAvailableCarsR
 			&horsepower,
 			&colour,
 			&enginecap,
+			&price,
+			&deposit,
+			&mileage,
 			&createdat,
 			&updatedat,
+			&photoid,
 		)
 
 		if err != nil {
@@ -308,21 +444,30 @@ func (c *CarRepo) GetAvailable(ctx context.Context, req models.GetAvailableCarsR
 		}
 
 		cars.Cars = append(cars.Cars, models.Car{
-			ID:         car.ID,
-			Name:       name.String,
-			Year:       year.Int64,
-			Brand:      brand.String,
-			Model:      model.String,
-			HorsePower: horsepower.Int64,
-			Colour:     colour.String,
-			EngineCap:  float32(enginecap.Float64),
-			CreatedAt:  createdat.String,
-			UpdatedAt:  updatedat.String,
+			ID:             car.ID,
+			Name:           name.String,
+			Year:           year.Int64,
+			Brand:          brand.String,
+			Model:          model.String,
+			HorsePower:     horsepower.Int64,
+			Colour:         colour.String,
+			EngineCap:      float32(enginecap.Float64),
+			Price:          price.Float64,
+			Deposit:        deposit.Float64,
+			Mileage:        mileage.Int64,
+			CreatedAt:      createdat.String,
+			UpdatedAt:      updatedat.String,
+			PrimaryPhotoId: photoid.String,
 		})
 	}
 
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS subquery", query)
-	err = c.db.QueryRow(ctx, countQuery).Scan(&count)
+	if err = rows.Err(); err != nil {
+		c.logger.Error("failed to get available cars from database", logger.Error(err))
+		return models.GetAvailableCarsResponse{}, err
+	}
+
+	countQuery, countArgs := b.Count(`SELECT COUNT(*) FROM cars c`)
+	err = c.db.QueryRow(ctx, countQuery, countArgs...).Scan(&count)
 	cars.Count = count
 	if err != nil {
 		c.logger.Error("failed to get count of available cars", logger.Error(err))
@@ -335,12 +480,16 @@ func (c *CarRepo) GetAvailable(ctx context.Context, req models.GetAvailableCarsR
 func (c *CarRepo) Delete(ctx context.Context, id string) error {
 	query := `UPDATE cars SET deleted_at = date_part('epoch', CURRENT_TIMESTAMP)::int WHERE id = $1 AND deleted_at = 0`
 
-	_, err := c.db.Exec(ctx, query, id)
+	tag, err := c.db.Exec(ctx, query, id)
 	if err != nil {
 		c.logger.Error("failed to delete car", logger.Error(err), logger.String("car_id", id))
 		return err
 	}
 
+	if tag.RowsAffected() == 0 {
+		return storage.ErrCarNotFound
+	}
+
 	return nil
 }
 
@@ -0,0 +1,362 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CarPhotoRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewCarPhotoRepo(db *pgxpool.Pool, log logger.ILogger) CarPhotoRepo {
+	return CarPhotoRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Create appends the photo to the end of the car's gallery, the first photo
+// of a car becomes its primary one.
+func (p *CarPhotoRepo) Create(ctx context.Context, photo models.CreateCarPhoto) (string, error) {
+	id := uuid.New().String()
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.logger.Error("failed to begin car photo transaction", logger.Error(err))
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	// locking the car serialises uploads to the same gallery
+	if err = lockCar(ctx, tx, photo.CarId); err != nil {
+		return "", err
+	}
+
+	query := `INSERT INTO car_photos (
+		id,
+		car_id,
+		position,
+		is_primary,
+		content_type,
+		size,
+		width,
+		height,
+		checksum,
+		storage_key,
+		thumbnail_key,
+		created_at
+	) SELECT $1::uuid, $2::uuid,
+		COALESCE(MAX(position) + 1, 0),
+		COUNT(*) FILTER (WHERE is_primary) = 0,
+		$3::text, $4::bigint, $5::int, $6::int, $7::text, $8::text, $9::text, CURRENT_TIMESTAMP
+	FROM car_photos
+	WHERE car_id = $2::uuid`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		photo.CarId,
+		photo.ContentType,
+		photo.Size,
+		photo.Width,
+		photo.Height,
+		photo.Checksum,
+		photo.StorageKey,
+		photo.ThumbnailKey,
+	)
+
+	if err != nil {
+		p.logger.Error("failed to create car photo in database", logger.Error(err))
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.logger.Error("failed to commit car photo transaction", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (p *CarPhotoRepo) GetByID(ctx context.Context, id string) (models.CarPhoto, error) {
+	query := `SELECT
+		id,
+		car_id,
+		position,
+		is_primary,
+		content_type,
+		size,
+		width,
+		height,
+		checksum,
+		storage_key,
+		thumbnail_key,
+		created_at
+	FROM car_photos
+	WHERE id = $1`
+
+	photo, err := scanCarPhoto(p.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.CarPhoto{}, storage.ErrPhotoNotFound
+		}
+		p.logger.Error("failed to get car photo from database", logger.Error(err))
+		return models.CarPhoto{}, err
+	}
+
+	return photo, nil
+}
+
+func (p *CarPhotoRepo) GetByCarID(ctx context.Context, carID string) ([]models.CarPhoto, error) {
+	photos := []models.CarPhoto{}
+
+	query := `SELECT
+		id,
+		car_id,
+		position,
+		is_primary,
+		content_type,
+		size,
+		width,
+		height,
+		checksum,
+		storage_key,
+		thumbnail_key,
+		created_at
+	FROM car_photos
+	WHERE car_id = $1
+	ORDER BY position`
+
+	rows, err := p.db.Query(ctx, query, carID)
+	if err != nil {
+		p.logger.Error("failed to get car photos from database", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		photo, err := scanCarPhoto(rows)
+		if err != nil {
+			p.logger.Error("failed to scan car photos from database", logger.Error(err))
+			return nil, err
+		}
+
+		photos = append(photos, photo)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logger.Error("failed to get car photos from database", logger.Error(err))
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+func (p *CarPhotoRepo) SetPrimary(ctx context.Context, carID, photoID string) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.logger.Error("failed to begin car photo transaction", logger.Error(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCar(ctx, tx, carID); err != nil {
+		return err
+	}
+
+	// the old primary is cleared first so the one-primary index holds at
+	// every statement
+	query := `UPDATE car_photos SET is_primary = FALSE WHERE car_id = $1 AND is_primary AND id <> $2`
+
+	if _, err = tx.Exec(ctx, query, carID, photoID); err != nil {
+		p.logger.Error("failed to clear primary car photo in database", logger.Error(err))
+		return err
+	}
+
+	query = `UPDATE car_photos SET is_primary = TRUE WHERE car_id = $1 AND id = $2`
+
+	tag, err := tx.Exec(ctx, query, carID, photoID)
+	if err != nil {
+		p.logger.Error("failed to set primary car photo in database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrPhotoNotFound
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.logger.Error("failed to commit car photo transaction", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Reorder sets the gallery order, photoIDs must list every photo of the car
+// exactly once.
+func (p *CarPhotoRepo) Reorder(ctx context.Context, req models.ReorderCarPhotos) error {
+	var current []string
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.logger.Error("failed to begin car photo transaction", logger.Error(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCar(ctx, tx, req.CarId); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id FROM car_photos WHERE car_id = $1`, req.CarId)
+	if err != nil {
+		p.logger.Error("failed to get car photos from database", logger.Error(err))
+		return err
+	}
+
+	current, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		p.logger.Error("failed to scan car photos from database", logger.Error(err))
+		return err
+	}
+
+	requested := slices.Clone(req.PhotoIds)
+	slices.Sort(current)
+	slices.Sort(requested)
+	if !slices.Equal(current, requested) {
+		return storage.ErrPhotoOrderMismatch
+	}
+
+	query := `UPDATE car_photos p SET position = o.position - 1
+	FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, position)
+	WHERE p.id = o.id AND p.car_id = $1`
+
+	if _, err = tx.Exec(ctx, query, req.CarId, req.PhotoIds); err != nil {
+		p.logger.Error("failed to reorder car photos in database", logger.Error(err))
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.logger.Error("failed to commit car photo transaction", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the photo and closes the gap it leaves in the order. If it
+// was the primary photo the next one in the gallery takes its place. The
+// deleted photo is returned so its files can be removed.
+func (p *CarPhotoRepo) Delete(ctx context.Context, carID, photoID string) (models.CarPhoto, error) {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.logger.Error("failed to begin car photo transaction", logger.Error(err))
+		return models.CarPhoto{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCar(ctx, tx, carID); err != nil {
+		return models.CarPhoto{}, err
+	}
+
+	query := `DELETE FROM car_photos
+	WHERE car_id = $1 AND id = $2
+	RETURNING
+		id,
+		car_id,
+		position,
+		is_primary,
+		content_type,
+		size,
+		width,
+		height,
+		checksum,
+		storage_key,
+		thumbnail_key,
+		created_at`
+
+	photo, err := scanCarPhoto(tx.QueryRow(ctx, query, carID, photoID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.CarPhoto{}, storage.ErrPhotoNotFound
+		}
+		p.logger.Error("failed to delete car photo from database", logger.Error(err))
+		return models.CarPhoto{}, err
+	}
+
+	query = `UPDATE car_photos SET position = position - 1 WHERE car_id = $1 AND position > $2`
+
+	if _, err = tx.Exec(ctx, query, carID, photo.Position); err != nil {
+		p.logger.Error("failed to reorder car photos in database", logger.Error(err))
+		return models.CarPhoto{}, err
+	}
+
+	if photo.IsPrimary {
+		query = `UPDATE car_photos SET is_primary = TRUE
+		WHERE id = (SELECT id FROM car_photos WHERE car_id = $1 ORDER BY position LIMIT 1)`
+
+		if _, err = tx.Exec(ctx, query, carID); err != nil {
+			p.logger.Error("failed to promote primary car photo in database", logger.Error(err))
+			return models.CarPhoto{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.logger.Error("failed to commit car photo transaction", logger.Error(err))
+		return models.CarPhoto{}, err
+	}
+
+	return photo, nil
+}
+
+func lockCar(ctx context.Context, tx pgx.Tx, carID string) error {
+	var id string
+
+	err := tx.QueryRow(ctx, `SELECT id FROM cars WHERE id = $1 AND deleted_at = 0 FOR UPDATE`, carID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrCarNotFound
+	}
+
+	return err
+}
+
+func scanCarPhoto(row pgx.Row) (models.CarPhoto, error) {
+	var (
+		photo     models.CarPhoto
+		createdAt sql.NullString
+	)
+
+	err := row.Scan(
+		&photo.Id,
+		&photo.CarId,
+		&photo.Position,
+		&photo.IsPrimary,
+		&photo.ContentType,
+		&photo.Size,
+		&photo.Width,
+		&photo.Height,
+		&photo.Checksum,
+		&photo.StorageKey,
+		&photo.ThumbnailKey,
+		&createdAt,
+	)
+
+	if err != nil {
+		return models.CarPhoto{}, err
+	}
+
+	photo.CreatedAt = createdAt.String
+
+	return photo, nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/storage"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarPhotos(t *testing.T) {
+	photoRepo := NewCarPhotoRepo(db, log)
+	carRepo := NewCarRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "Gallery",
+		Price: 80,
+	})
+
+	var photoIDs []string
+	for i := 0; i < 3; i++ {
+		key := "cars/" + carID + "/photos/" + uuid.New().String()
+
+		id, err := photoRepo.Create(context.Background(), models.CreateCarPhoto{
+			CarId:        carID,
+			ContentType:  "image/jpeg",
+			Size:         4096,
+			Width:        1600,
+			Height:       900,
+			Checksum:     "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			StorageKey:   key,
+			ThumbnailKey: key + "-thumbnail",
+		})
+		assert.NoError(t, err)
+		photoIDs = append(photoIDs, id)
+	}
+
+	photos, err := photoRepo.GetByCarID(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(photos))
+	assert.True(t, photos[0].IsPrimary)
+	assert.Equal(t, 2, photos[2].Position)
+
+	err = photoRepo.Reorder(context.Background(), models.ReorderCarPhotos{
+		CarId:    carID,
+		PhotoIds: []string{photoIDs[2], photoIDs[0]},
+	})
+	assert.ErrorIs(t, err, storage.ErrPhotoOrderMismatch)
+
+	err = photoRepo.Reorder(context.Background(), models.ReorderCarPhotos{
+		CarId:    carID,
+		PhotoIds: []string{photoIDs[2], photoIDs[0], photoIDs[1]},
+	})
+	assert.NoError(t, err)
+
+	err = photoRepo.SetPrimary(context.Background(), carID, photoIDs[2])
+	assert.NoError(t, err)
+
+	cars, err := carRepo.GetAll(context.Background(), models.GetAllCarsRequest{Search: "Gallery", Page: 1, Limit: 100})
+	assert.NoError(t, err)
+	for _, car := range cars.Cars {
+		if car.ID == carID {
+			assert.Equal(t, photoIDs[2], car.PrimaryPhotoId)
+		}
+	}
+
+	_, err = photoRepo.Delete(context.Background(), carID, photoIDs[2])
+	assert.NoError(t, err)
+
+	photos, err = photoRepo.GetByCarID(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(photos))
+	assert.Equal(t, photoIDs[0], photos[0].Id)
+	assert.Equal(t, 0, photos[0].Position)
+	assert.True(t, photos[0].IsPrimary)
+
+	_, err = photoRepo.Create(context.Background(), models.CreateCarPhoto{
+		CarId:        uuid.New().String(),
+		ContentType:  "image/png",
+		Size:         1,
+		StorageKey:   uuid.New().String(),
+		ThumbnailKey: uuid.New().String(),
+	})
+	assert.ErrorIs(t, err, storage.ErrCarNotFound)
+
+}
@@ -3,7 +3,10 @@ package postgres
 import (
 	"context"
 	"rent-car/api/models"
+	"rent-car/pkg/sqlbuilder"
+	"rent-car/storage"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/go-faker/faker/v4"
@@ -21,6 +24,7 @@ func TestCreateCar(t *testing.T) {
 		HorsePower: 200,
 		Colour:     "Blue",
 		EngineCap:  2.0,
+		Price:      45.5,
 	}
 
 	id, err := carRepo.Create(context.Background(), reqCar)
@@ -36,6 +40,7 @@ func TestCreateCar(t *testing.T) {
 	assert.Equal(t, reqCar.HorsePower, createdCar.HorsePower)
 	assert.Equal(t, reqCar.Colour, createdCar.Colour)
 	assert.Equal(t, reqCar.EngineCap, createdCar.EngineCap)
+	assert.Equal(t, reqCar.Price, createdCar.Price)
 }
 
 func TestUpdateCar(t *testing.T) {
@@ -130,6 +135,7 @@ func TestGetAllCar(t *testing.T) {
 		{"Get 1st page with limit 3", models.GetAllCarsRequest{Search: "", Page: 1, Limit: 2}, 2},
 		{"Get 2nd page with limit 2", models.GetAllCarsRequest{Search: "", Page: 2, Limit: 1}, 1},
 		{"Search for 'LucidAir' cars", models.GetAllCarsRequest{Search: "LucidAir", Page: 1, Limit: 3}, 3},
+		{"Search is bound, not spliced", models.GetAllCarsRequest{Search: "' OR '1'='1", Page: 1, Limit: 3}, 0},
 	}
 
 	for _, tc := range testCases {
@@ -143,6 +149,45 @@ func TestGetAllCar(t *testing.T) {
 	}
 }
 
+func TestGetAllCarSortAndFields(t *testing.T) {
+	carRepo := NewCarRepo(db, log)
+
+	for i := 0; i < 3; i++ {
+		reqCar := models.CreateCarRequest{
+			Name:       "SortedAir",
+			Year:       2015 + int64(i),
+			Brand:      faker.Word(),
+			Model:      faker.Word(),
+			HorsePower: 200,
+			Colour:     "Blue",
+			EngineCap:  2.0,
+		}
+		createTestCar(t, reqCar)
+	}
+
+	cars, err := carRepo.GetAll(context.Background(), models.GetAllCarsRequest{
+		Search: "SortedAir",
+		Page:   1,
+		Limit:  3,
+		Sort:   []string{"-year"},
+		Fields: []string{"name", "year"},
+	})
+	assert.NoError(t, err)
+
+	if assert.Len(t, cars.Cars, 3) {
+		assert.Equal(t, int64(2017), cars.Cars[0].Year)
+		assert.Equal(t, int64(2015), cars.Cars[2].Year)
+		assert.NotEmpty(t, cars.Cars[0].ID)
+		assert.Empty(t, cars.Cars[0].Colour)
+	}
+
+	_, err = carRepo.GetAll(context.Background(), models.GetAllCarsRequest{Page: 1, Limit: 3, Sort: []string{"colour"}})
+	assert.ErrorIs(t, err, sqlbuilder.ErrUnknownSort)
+
+	_, err = carRepo.GetAll(context.Background(), models.GetAllCarsRequest{Page: 1, Limit: 3, Fields: []string{"vin"}})
+	assert.ErrorIs(t, err, sqlbuilder.ErrUnknownField)
+}
+
 func TestGetAvailableCar(t *testing.T) {
 	carRepo := NewCarRepo(db, log)
 
@@ -179,6 +224,62 @@ func TestGetAvailableCar(t *testing.T) {
 		})
 	}
 }
+func TestGetAvailableCarByPeriod(t *testing.T) {
+	carRepo := NewCarRepo(db, log)
+	orderRepo := NewOrderRepo(db, log)
+
+	brand := faker.Word()
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "Reserved",
+		Brand: brand,
+	})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().AddDate(0, 1, 3).Format(time.DateOnly),
+		ToDate:     time.Now().AddDate(0, 1, 10).Format(time.DateOnly),
+		Status:     "active",
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		req      models.GetAvailableCarsRequest
+		expected int
+	}{
+		{"Booked inside the period", models.GetAvailableCarsRequest{
+			Brand: brand,
+			From:  time.Now().AddDate(0, 1, 5).Format(time.DateOnly),
+			To:    time.Now().AddDate(0, 1, 6).Format(time.DateOnly),
+			Page:  1, Limit: 5,
+		}, 0},
+		{"Free before the period", models.GetAvailableCarsRequest{
+			Brand: brand,
+			From:  time.Now().AddDate(0, 0, 1).Format(time.DateOnly),
+			To:    time.Now().AddDate(0, 0, 7).Format(time.DateOnly),
+			Page:  1, Limit: 5,
+		}, 1},
+		{"Filtered out by horse power", models.GetAvailableCarsRequest{
+			Brand:          brand,
+			HorsePowerFrom: 400,
+			Page:           1, Limit: 5,
+		}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cars, err := carRepo.GetAvailable(context.Background(), tc.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, len(cars.Cars))
+			assert.Equal(t, uint64(tc.expected), cars.Count)
+		})
+	}
+
+	err = orderRepo.DeleteHard(context.Background(), orderID)
+	assert.NoError(t, err)
+}
+
 func TestDeleteCar(t *testing.T) {
 	carRepo := NewCarRepo(db, log)
 
@@ -197,6 +298,9 @@ func TestDeleteCar(t *testing.T) {
 	err = carRepo.Delete(context.Background(), id)
 	assert.NoError(t, err)
 
+	err = carRepo.Delete(context.Background(), id)
+	assert.ErrorIs(t, err, storage.ErrCarNotFound)
+
 	_, err = carRepo.GetByID(context.Background(), id)
 	assert.Error(t, err)
 }
@@ -9,14 +9,52 @@ import (
 	"rent-car/api/models"
 	"rent-car/pkg"
 	"rent-car/pkg/logger"
+	"rent-car/pkg/sqlbuilder"
 	"rent-car/storage"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// customerSortColumns are the columns customer lists can be sorted by.
+var customerSortColumns = sqlbuilder.Columns{
+	"created_at": "created_at",
+	"first_name": "first_name",
+	"last_name":  "last_name",
+}
+
+// customerFilters are the filters customer lists accept.
+var customerFilters = sqlbuilder.Filters{
+	"search":              {Kind: sqlbuilder.FilterILike, Columns: []string{"first_name", "last_name", "phone"}},
+	"verification_status": {Kind: sqlbuilder.FilterEquals, Columns: []string{"verification_status"}},
+}
+
+// customerFields are the columns of customer lists under the field names of
+// models.Customer, orders, orders_count and unique_cars_count are loaded per
+// customer only when asked for.
+var customerFields = sqlbuilder.Projection{
+	{Name: "id", Column: "id"},
+	{Name: "first_name", Column: "first_name"},
+	{Name: "last_name", Column: "last_name"},
+	{Name: "email", Column: "email"},
+	{Name: "phone", Column: "phone"},
+	{Name: "address", Column: "address"},
+	{Name: "licence_number", Column: "licence_number"},
+	{Name: "licence_country", Column: "licence_country"},
+	{Name: "licence_expiry", Column: "licence_expiry::text"},
+	{Name: "date_of_birth", Column: "date_of_birth::text"},
+	{Name: "verification_status", Column: "verification_status"},
+	{Name: "verification_note", Column: "verification_note"},
+	{Name: "created_at", Column: "created_at"},
+	{Name: "updated_at", Column: "updated_at"},
+	{Name: "orders"},
+	{Name: "orders_count"},
+	{Name: "unique_cars_count"},
+}
+
 type CustomerRepo struct {
 	db     *pgxpool.Pool
 	logger logger.ILogger
@@ -43,9 +81,15 @@ func (c *CustomerRepo) Create(ctx context.Context, customer models.CreateCustome
 		login,
 		password,
         address,
+		licence_number,
+		licence_country,
+		licence_expiry,
+		date_of_birth,
+		verification_status,
         created_at,
         updated_at
-    ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+    ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), NULLIF($11, '')::date, NULLIF($12, '')::date,
+		COALESCE(NULLIF($13, ''), 'unverified'), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
 	_, err := c.db.Exec(ctx, query,
 		id,
@@ -56,6 +100,11 @@ func (c *CustomerRepo) Create(ctx context.Context, customer models.CreateCustome
 		customer.Login,
 		customer.Password,
 		customer.Address,
+		customer.LicenceNumber,
+		customer.LicenceCountry,
+		customer.LicenceExpiry,
+		customer.DateOfBirth,
+		customer.VerificationStatus,
 	)
 
 	if err != nil {
@@ -87,6 +136,27 @@ func (c *CustomerRepo) CheckEmailExists(ctx context.Context, email string) (bool
 	return exists, nil
 }
 
+func (c *CustomerRepo) ResetPassword(ctx context.Context, email string, password string) (string, error) {
+	var id string
+
+	query := `UPDATE customers SET
+		password = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE email = $1 AND deleted_at = 0
+	RETURNING id`
+
+	err := c.db.QueryRow(ctx, query, email, password).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrCustomerNotFound
+		}
+		c.logger.Error("failed to reset customer password in database", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
 func (c *CustomerRepo) Update(ctx context.Context, customer models.UpdateCustomer, id string) (string, error) {
 	query := `UPDATE customers SET
         first_name = $1,
@@ -95,9 +165,9 @@ func (c *CustomerRepo) Update(ctx context.Context, customer models.UpdateCustome
         phone = $4,
         address = $5,
         updated_at = $6
-    WHERE id = $7`
+    WHERE id = $7 AND deleted_at = 0`
 
-	_, err := c.db.Exec(ctx, query,
+	tag, err := c.db.Exec(ctx, query,
 		customer.FirstName,
 		customer.LastName,
 		customer.Email,
@@ -112,6 +182,10 @@ func (c *CustomerRepo) Update(ctx context.Context, customer models.UpdateCustome
 		return "", err
 	}
 
+	if tag.RowsAffected() == 0 {
+		return "", storage.ErrCustomerNotFound
+	}
+
 	err = c.redis.Del(ctx, "customer_id:"+id)
 	if err != nil {
 		c.logger.Error("failed to delete customer data from Redis", logger.Error(err))
@@ -124,15 +198,20 @@ func (c *CustomerRepo) GetByID(ctx context.Context, id string) (models.Customer,
 	var customer models.Customer
 
 	var (
-		firstname       sql.NullString
-		lastname        sql.NullString
-		phone           sql.NullString
-		email           sql.NullString
-		address         sql.NullString
-		createdat       sql.NullString
-		updatedat       sql.NullString
-		uniquecarscount sql.NullInt64
-		orderscount     sql.NullInt64
+		firstname        sql.NullString
+		lastname         sql.NullString
+		phone            sql.NullString
+		email            sql.NullString
+		address          sql.NullString
+		licencenumber    sql.NullString
+		licencecountry   sql.NullString
+		licenceexpiry    sql.NullString
+		dateofbirth      sql.NullString
+		verificationnote sql.NullString
+		createdat        sql.NullString
+		updatedat        sql.NullString
+		uniquecarscount  sql.NullInt64
+		orderscount      sql.NullInt64
 	)
 
 	query := `SELECT 
@@ -142,6 +221,12 @@ func (c *CustomerRepo) GetByID(ctx context.Context, id string) (models.Customer,
 		phone,
 		email,
 		address,
+		licence_number,
+		licence_country,
+		licence_expiry::text,
+		date_of_birth::text,
+		verification_status,
+		verification_note,
 		created_at, 
 		updated_at
 		FROM customers WHERE id = $1 AND deleted_at = 0`
@@ -159,11 +244,20 @@ func (c *CustomerRepo) GetByID(ctx context.Context, id string) (models.Customer,
 		&phone,
 		&email,
 		&address,
+		&licencenumber,
+		&licencecountry,
+		&licenceexpiry,
+		&dateofbirth,
+		&customer.VerificationStatus,
+		&verificationnote,
 		&createdat,
 		&updatedat,
 	)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Customer{}, storage.ErrCustomerNotFound
+		}
 		c.logger.Error("failed to scan customer by ID from database", logger.Error(err))
 		return models.Customer{}, err
 	}
@@ -173,18 +267,25 @@ func (c *CustomerRepo) GetByID(ctx context.Context, id string) (models.Customer,
 	customer.Phone = phone.String
 	customer.Email = email.String
 	customer.Address = address.String
+	customer.LicenceNumber = licencenumber.String
+	customer.LicenceCountry = licencecountry.String
+	customer.LicenceExpiry = licenceexpiry.String
+	customer.DateOfBirth = dateofbirth.String
+	customer.VerificationNote = verificationnote.String
 	customer.CreatedAt = createdat.String
 	customer.UpdatedAt = updatedat.String
 
 	orderQuery := `SELECT
-		id,
-		from_date,
-		to_date,
-		status,
-		payment_status,
-		created_at,
-		updated_at
-		FROM orders WHERE customer_id = $1`
+		o.id,
+		o.from_date,
+		o.to_date,
+		o.status,
+		b.payment_status,
+		o.created_at,
+		o.updated_at
+		FROM orders o
+		JOIN order_balances b ON b.order_id = o.id
+		WHERE o.customer_id = $1`
 
 	rows, err := c.db.Query(ctx, orderQuery, id)
 
@@ -303,6 +404,9 @@ func (c *CustomerRepo) GetByLogin(ctx context.Context, login string) (models.Cus
 	)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Customer{}, storage.ErrCustomerNotFound
+		}
 		c.logger.Error("failed to scan customer by LOGIN from database", logger.Error(err))
 		return models.Customer{}, err
 	}
@@ -328,12 +432,11 @@ func (c *CustomerRepo) GetPassword(ctx context.Context, phone string) (string, e
 	err := c.db.QueryRow(ctx, query, phone).Scan(&hashedPass)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", errors.New("incorrect phone")
-		} else {
-			c.logger.Error("failed to get customer password from database", logger.Error(err))
-			return "", err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrCustomerNotFound
 		}
+		c.logger.Error("failed to get customer password from database", logger.Error(err))
+		return "", err
 	}
 
 	return hashedPass, nil
@@ -351,8 +454,8 @@ func (c *CustomerRepo) ChangePassword(ctx context.Context, pass models.ChangePas
 	).Scan(&hashedPass)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", errors.New("incorrect login")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrCustomerNotFound
 		}
 		c.logger.Error("failed to get customer password from database", logger.Error(err))
 		return "", err
@@ -360,7 +463,7 @@ func (c *CustomerRepo) ChangePassword(ctx context.Context, pass models.ChangePas
 
 	err = bcrypt.CompareHashAndPassword([]byte(hashedPass), []byte(pass.OldPassword))
 	if err != nil {
-		return "", errors.New("password mismatch")
+		return "", storage.ErrPasswordMismatch
 	}
 
 	newHashedPassword, err := bcrypt.GenerateFromPassword([]byte(pass.NewPassword), bcrypt.DefaultCost)
@@ -385,37 +488,56 @@ func (c *CustomerRepo) ChangePassword(ctx context.Context, pass models.ChangePas
 
 func (c *CustomerRepo) GetAll(ctx context.Context, req models.GetAllCustomersRequest) (models.GetAllCustomersResponse, error) {
 	var (
-		resp      = models.GetAllCustomersResponse{}
-		filter    string
-		firstname sql.NullString
-		lastname  sql.NullString
-		phone     sql.NullString
-		email     sql.NullString
-		address   sql.NullString
-		createdat sql.NullString
-		updatedat sql.NullString
-		count     sql.NullInt64
+		resp               = models.GetAllCustomersResponse{}
+		firstname          sql.NullString
+		lastname           sql.NullString
+		phone              sql.NullString
+		email              sql.NullString
+		address            sql.NullString
+		licencenumber      sql.NullString
+		licencecountry     sql.NullString
+		licenceexpiry      sql.NullString
+		dateofbirth        sql.NullString
+		verificationstatus sql.NullString
+		verificationnote   sql.NullString
+		createdat          sql.NullString
+		updatedat          sql.NullString
+		count              sql.NullInt64
 	)
-	offset := (req.Page - 1) * req.Limit
+	b := sqlbuilder.New().
+		Where("deleted_at = 0").
+		Page(req.Page, req.Limit)
+
+	err := b.Filter(customerFilters, sqlbuilder.Values{
+		"search":              req.Search,
+		"verification_status": req.VerificationStatus,
+	})
+	if err != nil {
+		return resp, err
+	}
 
-	if req.Search != "" {
-		filter = fmt.Sprintf(` AND (first_name ILIKE '%%%v%%' OR last_name ILIKE '%%%v%%' OR phone ILIKE '%%%v%%')`, req.Search, req.Search, req.Search)
+	// a cursor only continues the default order, an explicit sort pages by offset
+	switch {
+	case len(req.Sort) > 0 && req.Cursor != "":
+		return resp, fmt.Errorf("%w: cursor can not be combined with sort", sqlbuilder.ErrInvalidCursor)
+	case len(req.Sort) > 0:
+		err = b.OrderBy(customerSortColumns, "id", req.Sort...)
+	default:
+		err = b.Keyset(req.Cursor, "created_at", "id")
+	}
+	if err != nil {
+		return resp, err
 	}
 
-	filter += fmt.Sprintf(" OFFSET %v LIMIT %v", offset, req.Limit)
+	// the cursor is made from id and created_at, so they are always selected
+	columns, err := customerFields.Columns(req.Fields, "id", "created_at")
+	if err != nil {
+		return resp, err
+	}
 
-	query := `SELECT 
-        id, 
-        first_name, 
-        last_name, 
-        email,
-        phone,
-        address,
-        created_at, 
-        updated_at
-        FROM customers WHERE deleted_at = 0` + filter
+	query, args := b.Select(`SELECT ` + columns + ` FROM customers`)
 
-	rows, err := c.db.Query(ctx, query)
+	rows, err := c.db.Query(ctx, query, args...)
 	if err != nil {
 		c.logger.Error("failed to get all customers from database", logger.Error(err))
 		return resp, err
@@ -432,6 +554,12 @@ func (c *CustomerRepo) GetAll(ctx context.Context, req models.GetAllCustomersReq
 			&email,
 			&phone,
 			&address,
+			&licencenumber,
+			&licencecountry,
+			&licenceexpiry,
+			&dateofbirth,
+			&verificationstatus,
+			&verificationnote,
 			&createdat,
 			&updatedat,
 		)
@@ -447,71 +575,92 @@ func (c *CustomerRepo) GetAll(ctx context.Context, req models.GetAllCustomersReq
 		customer.Email = email.String
 		customer.Phone = phone.String
 		customer.Address = address.String
+		customer.LicenceNumber = licencenumber.String
+		customer.LicenceCountry = licencecountry.String
+		customer.LicenceExpiry = licenceexpiry.String
+		customer.DateOfBirth = dateofbirth.String
+		customer.VerificationStatus = verificationstatus.String
+		customer.VerificationNote = verificationnote.String
 		customer.CreatedAt = createdat.String
 		customer.UpdatedAt = updatedat.String
 
-		customer.Orders = make([]models.Order, 0)
-
-		orderQuery := `SELECT
-            id,
-            from_date,
-            to_date,
-            status,
-            payment_status,
-            created_at,
-            updated_at
-            FROM orders WHERE customer_id = $1`
-
-		orderRows, err := c.db.Query(ctx, orderQuery, id)
-
-		if err != nil {
-			c.logger.Error("failed to get  customers orders from database", logger.Error(err))
-			return models.GetAllCustomersResponse{}, err
-		}
-		defer orderRows.Close()
-
 		var (
-			orders          []models.Order
-			fromdate        sql.NullString
-			todate          sql.NullString
-			status          sql.NullString
-			paid            sql.NullBool
-			createdAt       sql.NullString
-			updatedAt       sql.NullString
 			orderscount     sql.NullInt64
 			uniquecarscount sql.NullInt64
 		)
 
-		for orderRows.Next() {
-			var order models.Order
-			err := orderRows.Scan(
-				&order.Id,
-				&fromdate,
-				&todate,
-				&status,
-				&paid,
-				&createdAt,
-				&updatedAt,
-			)
+		if sqlbuilder.Picked(req.Fields, "orders") {
+			customer.Orders = make([]models.Order, 0)
+
+			orderQuery := `SELECT
+            o.id,
+            o.from_date,
+            o.to_date,
+            o.status,
+            b.payment_status,
+            o.created_at,
+            o.updated_at
+            FROM orders o
+            JOIN order_balances b ON b.order_id = o.id
+            WHERE o.customer_id = $1`
 
-			order.FromDate = fromdate.String
-			order.ToDate = todate.String
-			order.Status = status.String
-			order.Paid = paid.Bool
-			order.CreatedAt = createdAt.String
-			order.UpdatedAt = updatedAt.String
+			orderRows, err := c.db.Query(ctx, orderQuery, id)
 
 			if err != nil {
-				c.logger.Error("failed to scan customers orders from database", logger.Error(err))
+				c.logger.Error("failed to get  customers orders from database", logger.Error(err))
 				return models.GetAllCustomersResponse{}, err
 			}
 
-			orders = append(orders, order)
-		}
+			var (
+				orders    []models.Order
+				fromdate  sql.NullString
+				todate    sql.NullString
+				status    sql.NullString
+				paid      sql.NullBool
+				createdAt sql.NullString
+				updatedAt sql.NullString
+			)
 
-		customer.Orders = orders
+			for orderRows.Next() {
+				var order models.Order
+				err := orderRows.Scan(
+					&order.Id,
+					&fromdate,
+					&todate,
+					&status,
+					&paid,
+					&createdAt,
+					&updatedAt,
+				)
+
+				order.FromDate = fromdate.String
+				order.ToDate = todate.String
+				order.Status = status.String
+				order.Paid = paid.Bool
+				order.CreatedAt = createdAt.String
+				order.UpdatedAt = updatedAt.String
+
+				if err != nil {
+					orderRows.Close()
+					c.logger.Error("failed to scan customers orders from database", logger.Error(err))
+					return models.GetAllCustomersResponse{}, err
+				}
+
+				orders = append(orders, order)
+			}
 
-		if customer.Orders != nil {
+			// closed here, not deferred, so each customer's rows are released
+			// before the next customer's are queried
+			orderRows.Close()
+			if err = orderRows.Err(); err != nil {
+				c.logger.Error("failed to get customers orders from database", logger.Error(err))
+				return models.GetAllCustomersResponse{}, err
+			}
+
+			customer.Orders = orders
+		}
+
+		if sqlbuilder.Picked(req.Fields, "orders_count") {
 			ordersCountQuery := `SELECT COUNT(o.car_id) FROM orders o WHERE o.customer_id = $1`
 			err = c.db.QueryRow(ctx, ordersCountQuery, id).Scan(&orderscount)
 			customer.OrdersCount = orderscount.Int64
@@ -521,19 +670,30 @@ func (c *CustomerRepo) GetAll(ctx context.Context, req models.GetAllCustomersReq
 			}
 		}
 
-		uniqueCarsCountQuery := `SELECT COUNT(DISTINCT o.car_id) FROM orders o WHERE o.customer_id = $1`
-		err = c.db.QueryRow(ctx, uniqueCarsCountQuery, id).Scan(&uniquecarscount)
-		customer.UniqueCarsCount = uniquecarscount.Int64
-		if err != nil {
-			c.logger.Error("failed to get customers car unique cars count from database", logger.Error(err))
-			return resp, err
+		if sqlbuilder.Picked(req.Fields, "unique_cars_count") {
+			uniqueCarsCountQuery := `SELECT COUNT(DISTINCT o.car_id) FROM orders o WHERE o.customer_id = $1`
+			err = c.db.QueryRow(ctx, uniqueCarsCountQuery, id).Scan(&uniquecarscount)
+			customer.UniqueCarsCount = uniquecarscount.Int64
+			if err != nil {
+				c.logger.Error("failed to get customers car unique cars count from database", logger.Error(err))
+				return resp, err
+			}
 		}
 
 		resp.Customers = append(resp.Customers, customer)
 	}
 
-	countQuery := `SELECT COUNT(id) FROM customers`
-	err = c.db.QueryRow(ctx, countQuery).Scan(&count)
+	if err = rows.Err(); err != nil {
+		c.logger.Error("failed to get all customers from database", logger.Error(err))
+		return models.GetAllCustomersResponse{}, err
+	}
+
+	if n := len(resp.Customers); len(req.Sort) == 0 && req.Limit > 0 && uint64(n) == req.Limit {
+		resp.NextCursor = sqlbuilder.Cursor(resp.Customers[n-1].CreatedAt, resp.Customers[n-1].ID)
+	}
+
+	countQuery, countArgs := b.Count(`SELECT COUNT(*) FROM customers`)
+	err = c.db.QueryRow(ctx, countQuery, countArgs...).Scan(&count)
 	resp.Count = count.Int64
 	if err != nil {
 		c.logger.Error("failed to get customers count from database", logger.Error(err))
@@ -654,12 +814,16 @@ func (c *CustomerRepo) GetCustomerCars(ctx context.Context, name string, id stri
 func (c *CustomerRepo) Delete(ctx context.Context, id string) error {
 	query := `UPDATE customers SET deleted_at = date_part('epoch', CURRENT_TIMESTAMP)::int WHERE id = $1 AND deleted_at = 0`
 
-	_, err := c.db.Exec(ctx, query, id)
+	tag, err := c.db.Exec(ctx, query, id)
 	if err != nil {
 		c.logger.Error("failed to delete customer from database", logger.Error(err))
 		return err
 	}
 
+	if tag.RowsAffected() == 0 {
+		return storage.ErrCustomerNotFound
+	}
+
 	return nil
 }
 
@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (c *CustomerRepo) Block(ctx context.Context, req models.BlockCustomer) error {
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		c.logger.Error("failed to begin customer block transaction", logger.Error(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE customers SET
+		is_blocked = TRUE,
+		block_reason = $2,
+		blocked_until = NULLIF($3, '')::timestamptz,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND deleted_at = 0`
+
+	tag, err := tx.Exec(ctx, query, req.CustomerId, req.Reason, req.Until)
+	if err != nil {
+		c.logger.Error("failed to block customer in database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrCustomerNotFound
+	}
+
+	if err = c.insertBlockAudit(ctx, tx, req.CustomerId, config.BLOCK_ACTION, req.Reason, req.Until, req.AdminId); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		c.logger.Error("failed to commit customer block transaction", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (c *CustomerRepo) Unblock(ctx context.Context, req models.UnblockCustomer) error {
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		c.logger.Error("failed to begin customer unblock transaction", logger.Error(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE customers SET
+		is_blocked = FALSE,
+		block_reason = NULL,
+		blocked_until = NULL,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND deleted_at = 0`
+
+	tag, err := tx.Exec(ctx, query, req.CustomerId)
+	if err != nil {
+		c.logger.Error("failed to unblock customer in database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrCustomerNotFound
+	}
+
+	if err = c.insertBlockAudit(ctx, tx, req.CustomerId, config.UNBLOCK_ACTION, req.Reason, "", req.AdminId); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		c.logger.Error("failed to commit customer unblock transaction", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (c *CustomerRepo) insertBlockAudit(ctx context.Context, tx pgx.Tx, customerID, action, reason, until, adminID string) error {
+	query := `INSERT INTO customer_blocks (
+		id,
+		customer_id,
+		action,
+		reason,
+		blocked_until,
+		admin_id,
+		created_at
+	) VALUES ($1, $2, $3, $4, NULLIF($5, '')::timestamptz, NULLIF($6, '')::uuid, CURRENT_TIMESTAMP)`
+
+	_, err := tx.Exec(ctx, query,
+		uuid.New().String(),
+		customerID,
+		action,
+		reason,
+		until,
+		adminID,
+	)
+
+	if err != nil {
+		c.logger.Error("failed to insert customer block audit in database", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetBlockStatus reports whether the customer is blocked right now, a block
+// whose expiry has passed no longer counts.
+func (c *CustomerRepo) GetBlockStatus(ctx context.Context, id string) (models.CustomerBlockStatus, error) {
+	var (
+		status       models.CustomerBlockStatus
+		reason       sql.NullString
+		blockedUntil sql.NullString
+	)
+
+	query := `SELECT
+		is_blocked AND (blocked_until IS NULL OR blocked_until > CURRENT_TIMESTAMP),
+		block_reason,
+		blocked_until
+	FROM customers
+	WHERE id = $1 AND deleted_at = 0`
+
+	err := c.db.QueryRow(ctx, query, id).Scan(
+		&status.IsBlocked,
+		&reason,
+		&blockedUntil,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.CustomerBlockStatus{}, storage.ErrCustomerNotFound
+		}
+		c.logger.Error("failed to get customer block status from database", logger.Error(err))
+		return models.CustomerBlockStatus{}, err
+	}
+
+	if status.IsBlocked {
+		status.Reason = reason.String
+		status.BlockedUntil = blockedUntil.String
+	}
+
+	return status, nil
+}
+
+func (c *CustomerRepo) GetBlocks(ctx context.Context, id string) (models.GetCustomerBlocksResponse, error) {
+	var (
+		resp = models.GetCustomerBlocksResponse{
+			Blocks: []models.CustomerBlock{},
+		}
+		err error
+	)
+
+	resp.Status, err = c.GetBlockStatus(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	query := `SELECT
+		id,
+		customer_id,
+		action,
+		reason,
+		blocked_until,
+		admin_id,
+		created_at
+	FROM customer_blocks
+	WHERE customer_id = $1
+	ORDER BY created_at`
+
+	rows, err := c.db.Query(ctx, query, id)
+	if err != nil {
+		c.logger.Error("failed to get customer blocks from database", logger.Error(err))
+		return resp, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			block        models.CustomerBlock
+			blockedUntil sql.NullString
+			adminID      sql.NullString
+			createdAt    sql.NullString
+		)
+
+		err := rows.Scan(
+			&block.Id,
+			&block.CustomerId,
+			&block.Action,
+			&block.Reason,
+			&blockedUntil,
+			&adminID,
+			&createdAt,
+		)
+
+		if err != nil {
+			c.logger.Error("failed to scan customer blocks from database", logger.Error(err))
+			return resp, err
+		}
+
+		block.BlockedUntil = blockedUntil.String
+		block.AdminId = adminID.String
+		block.CreatedAt = createdAt.String
+
+		resp.Blocks = append(resp.Blocks, block)
+	}
+
+	if err = rows.Err(); err != nil {
+		c.logger.Error("failed to get customer blocks from database", logger.Error(err))
+		return resp, err
+	}
+
+	resp.Count = len(resp.Blocks)
+
+	return resp, nil
+}
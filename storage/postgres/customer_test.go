@@ -3,16 +3,19 @@ package postgres
 import (
 	"context"
 	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/storage"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreateCustomer(t *testing.T) {
-	customerRepo := NewCustomerRepo(db, log)
+	customerRepo := NewCustomerRepo(db, log, cache)
 
 	reqCustomer := models.CreateCustomer{
 		FirstName: faker.FirstName(),
@@ -36,8 +39,34 @@ func TestCreateCustomer(t *testing.T) {
 	assert.Equal(t, reqCustomer.Address, createdCustomer.Address)
 }
 
+func TestResetCustomerPassword(t *testing.T) {
+	customerRepo := NewCustomerRepo(db, log, cache)
+
+	email := faker.Email()
+
+	customerID, err := customerRepo.Create(context.Background(), models.CreateCustomer{
+		FirstName: faker.FirstName(),
+		LastName:  faker.LastName(),
+		Email:     email,
+		Phone:     faker.Phonenumber(),
+		Password:  faker.Password(),
+		Address:   gofakeit.RandomString([]string{"Tashkent", "Samarkand", "Fergana"}),
+	})
+	assert.NoError(t, err)
+
+	resetID, err := customerRepo.ResetPassword(context.Background(), email, "new-hashed-password")
+	assert.NoError(t, err)
+	assert.Equal(t, customerID, resetID)
+
+	_, err = customerRepo.ResetPassword(context.Background(), faker.Email(), "new-hashed-password")
+	assert.Error(t, err)
+
+	err = customerRepo.DeleteHard(context.Background(), customerID)
+	assert.NoError(t, err)
+}
+
 func TestUpdateCustomer(t *testing.T) {
-	customerRepo := NewCustomerRepo(db, log)
+	customerRepo := NewCustomerRepo(db, log, cache)
 
 	customerID, err := customerRepo.Create(context.Background(), models.CreateCustomer{
 		FirstName: faker.FirstName(),
@@ -71,7 +100,7 @@ func TestUpdateCustomer(t *testing.T) {
 }
 
 func TestGetByIDCustomer(t *testing.T) {
-	customerRepo := NewCustomerRepo(db, log)
+	customerRepo := NewCustomerRepo(db, log, cache)
 
 	expectedCustomer := models.CreateCustomer{
 		FirstName: faker.FirstName(),
@@ -95,7 +124,7 @@ func TestGetByIDCustomer(t *testing.T) {
 }
 
 func TestGetAllCustomer(t *testing.T) {
-	customerRepo := NewCustomerRepo(db, log)
+	customerRepo := NewCustomerRepo(db, log, cache)
 
 	for i := 0; i < 3; i++ {
 		reqCustomer := models.CreateCustomer{
@@ -133,7 +162,7 @@ func TestGetAllCustomer(t *testing.T) {
 func TestGetCustomerCars(t *testing.T) {
 	orderRepo := NewOrderRepo(db, log)
 	carRepo := NewCarRepo(db, log)
-	customerRepo := NewCustomerRepo(db, log)
+	customerRepo := NewCustomerRepo(db, log, cache)
 
 	reqCustomer := models.CreateCustomer{
 		FirstName: "Bilmasam",
@@ -165,7 +194,6 @@ func TestGetCustomerCars(t *testing.T) {
 		FromDate:   time.Now().Format(time.RFC3339),
 		ToDate:     time.Now().AddDate(0, 0, 5).Format(time.RFC3339),
 		Status:     "active",
-		Paid:       true,
 	}
 
 	orderId, err := orderRepo.Create(context.Background(), reqOrder)
@@ -213,7 +241,7 @@ func TestGetCustomerCars(t *testing.T) {
 }
 
 func TestDeleteCustomer(t *testing.T) {
-	customerRepo := NewCustomerRepo(db, log)
+	customerRepo := NewCustomerRepo(db, log, cache)
 
 	customerID, err := customerRepo.Create(context.Background(), models.CreateCustomer{
 		FirstName: faker.FirstName(),
@@ -227,6 +255,101 @@ func TestDeleteCustomer(t *testing.T) {
 	err = customerRepo.Delete(context.Background(), customerID)
 	assert.NoError(t, err)
 
+	err = customerRepo.Delete(context.Background(), customerID)
+	assert.ErrorIs(t, err, storage.ErrCustomerNotFound)
+
 	_, err = customerRepo.GetByID(context.Background(), customerID)
 	assert.Error(t, err)
 }
+
+func TestBlockCustomer(t *testing.T) {
+	customerRepo := NewCustomerRepo(db, log, cache)
+
+	customerID, err := customerRepo.Create(context.Background(), models.CreateCustomer{
+		FirstName: faker.FirstName(),
+		LastName:  faker.LastName(),
+		Email:     faker.Email(),
+		Phone:     faker.Phonenumber(),
+		Address:   gofakeit.RandomString([]string{"Tashkent", "Samarkand", "Fergana"}),
+	})
+	assert.NoError(t, err)
+
+	err = customerRepo.Block(context.Background(), models.BlockCustomer{
+		CustomerId: customerID,
+		Reason:     "unpaid damages",
+	})
+	assert.NoError(t, err)
+
+	status, err := customerRepo.GetBlockStatus(context.Background(), customerID)
+	assert.NoError(t, err)
+	assert.True(t, status.IsBlocked)
+	assert.Equal(t, "unpaid damages", status.Reason)
+
+	err = customerRepo.Unblock(context.Background(), models.UnblockCustomer{
+		CustomerId: customerID,
+		Reason:     "damages paid",
+	})
+	assert.NoError(t, err)
+
+	blocks, err := customerRepo.GetBlocks(context.Background(), customerID)
+	assert.NoError(t, err)
+	assert.False(t, blocks.Status.IsBlocked)
+	assert.Equal(t, 2, len(blocks.Blocks))
+
+	err = customerRepo.Block(context.Background(), models.BlockCustomer{
+		CustomerId: uuid.New().String(),
+		Reason:     "unknown customer",
+	})
+	assert.ErrorIs(t, err, storage.ErrCustomerNotFound)
+
+	err = customerRepo.DeleteHard(context.Background(), customerID)
+	assert.NoError(t, err)
+}
+
+func TestCustomerVerification(t *testing.T) {
+	customerRepo := NewCustomerRepo(db, log, cache)
+
+	customerID, err := customerRepo.Create(context.Background(), models.CreateCustomer{
+		FirstName: faker.FirstName(),
+		LastName:  faker.LastName(),
+		Email:     faker.Email(),
+		Phone:     faker.Phonenumber(),
+		Address:   gofakeit.RandomString([]string{"Tashkent", "Samarkand", "Fergana"}),
+	})
+	assert.NoError(t, err)
+
+	verification, err := customerRepo.GetVerification(context.Background(), customerID)
+	assert.NoError(t, err)
+	assert.Equal(t, config.VERIFICATION_UNVERIFIED, verification.Status)
+
+	err = customerRepo.ReviewVerification(context.Background(), models.ReviewCustomerVerification{
+		CustomerId: customerID,
+		Status:     config.VERIFICATION_VERIFIED,
+	})
+	assert.ErrorIs(t, err, storage.ErrVerificationNotPending)
+
+	err = customerRepo.UpdateLicence(context.Background(), models.UpdateCustomerLicence{
+		CustomerId:     customerID,
+		LicenceNumber:  "AB1234567",
+		LicenceCountry: "Uzbekistan",
+		LicenceExpiry:  time.Now().AddDate(2, 0, 0).Format(time.DateOnly),
+		DateOfBirth:    "1995-04-12",
+	})
+	assert.NoError(t, err)
+
+	err = customerRepo.ReviewVerification(context.Background(), models.ReviewCustomerVerification{
+		CustomerId: customerID,
+		Status:     config.VERIFICATION_VERIFIED,
+	})
+	assert.NoError(t, err)
+
+	verification, err = customerRepo.GetVerification(context.Background(), customerID)
+	assert.NoError(t, err)
+	assert.Equal(t, config.VERIFICATION_VERIFIED, verification.Status)
+	assert.Equal(t, "AB1234567", verification.LicenceNumber)
+	assert.Equal(t, "1995-04-12", verification.DateOfBirth)
+	assert.NotEmpty(t, verification.VerifiedAt)
+
+	err = customerRepo.DeleteHard(context.Background(), customerID)
+	assert.NoError(t, err)
+}
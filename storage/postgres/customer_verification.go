@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// UpdateLicence stores new licence details and puts the customer back in the
+// review queue, an earlier review no longer applies to them.
+func (c *CustomerRepo) UpdateLicence(ctx context.Context, req models.UpdateCustomerLicence) error {
+	query := `UPDATE customers SET
+		licence_number = $2,
+		licence_country = $3,
+		licence_expiry = $4::date,
+		date_of_birth = $5::date,
+		verification_status = $6,
+		verification_note = NULL,
+		verified_by = NULL,
+		verified_at = NULL,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND deleted_at = 0`
+
+	tag, err := c.db.Exec(ctx, query,
+		req.CustomerId,
+		req.LicenceNumber,
+		req.LicenceCountry,
+		req.LicenceExpiry,
+		req.DateOfBirth,
+		config.VERIFICATION_PENDING,
+	)
+
+	if err != nil {
+		c.logger.Error("failed to update customer licence in database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrCustomerNotFound
+	}
+
+	if err = c.redis.Del(ctx, "customer_id:"+req.CustomerId); err != nil {
+		c.logger.Error("failed to delete customer data from Redis", logger.Error(err))
+	}
+
+	return nil
+}
+
+// ReviewVerification records an admin's decision on a pending licence.
+func (c *CustomerRepo) ReviewVerification(ctx context.Context, req models.ReviewCustomerVerification) error {
+	query := `UPDATE customers SET
+		verification_status = $2,
+		verification_note = NULLIF($3, ''),
+		verified_by = NULLIF($4, '')::uuid,
+		verified_at = CURRENT_TIMESTAMP,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND deleted_at = 0 AND verification_status = $5`
+
+	tag, err := c.db.Exec(ctx, query,
+		req.CustomerId,
+		req.Status,
+		req.Note,
+		req.AdminId,
+		config.VERIFICATION_PENDING,
+	)
+
+	if err != nil {
+		c.logger.Error("failed to review customer verification in database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		if _, err = c.GetVerification(ctx, req.CustomerId); err != nil {
+			return err
+		}
+		return storage.ErrVerificationNotPending
+	}
+
+	if err = c.redis.Del(ctx, "customer_id:"+req.CustomerId); err != nil {
+		c.logger.Error("failed to delete customer data from Redis", logger.Error(err))
+	}
+
+	return nil
+}
+
+func (c *CustomerRepo) GetVerification(ctx context.Context, id string) (models.CustomerVerification, error) {
+	var (
+		verification   models.CustomerVerification
+		licenceNumber  sql.NullString
+		licenceCountry sql.NullString
+		licenceExpiry  sql.NullString
+		dateOfBirth    sql.NullString
+		note           sql.NullString
+		verifiedBy     sql.NullString
+		verifiedAt     sql.NullString
+	)
+
+	query := `SELECT
+		id,
+		licence_number,
+		licence_country,
+		licence_expiry::text,
+		date_of_birth::text,
+		verification_status,
+		verification_note,
+		verified_by::text,
+		verified_at
+	FROM customers
+	WHERE id = $1 AND deleted_at = 0`
+
+	err := c.db.QueryRow(ctx, query, id).Scan(
+		&verification.CustomerId,
+		&licenceNumber,
+		&licenceCountry,
+		&licenceExpiry,
+		&dateOfBirth,
+		&verification.Status,
+		&note,
+		&verifiedBy,
+		&verifiedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.CustomerVerification{}, storage.ErrCustomerNotFound
+		}
+		c.logger.Error("failed to get customer verification from database", logger.Error(err))
+		return models.CustomerVerification{}, err
+	}
+
+	verification.LicenceNumber = licenceNumber.String
+	verification.LicenceCountry = licenceCountry.String
+	verification.LicenceExpiry = licenceExpiry.String
+	verification.DateOfBirth = dateOfBirth.String
+	verification.Note = note.String
+	verification.VerifiedBy = verifiedBy.String
+	verification.VerifiedAt = verifiedAt.String
+
+	return verification, nil
+}
@@ -0,0 +1,383 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DamageRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewDamageRepo(db *pgxpool.Pool, log logger.ILogger) DamageRepo {
+	return DamageRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+const damageColumns = `
+		id,
+		order_id,
+		car_id,
+		location,
+		severity,
+		description,
+		estimated_cost,
+		actual_cost,
+		charged_to_customer,
+		reported_by::text,
+		created_at,
+		updated_at`
+
+// Create logs damage against the order and the car it was rented with.
+// Damage is found when the car comes back, so the order needs its return
+// inspection first.
+func (d *DamageRepo) Create(ctx context.Context, damage models.CreateDamageReport) (string, error) {
+	var (
+		id        = uuid.New().String()
+		carID     string
+		inspected bool
+	)
+
+	query := `SELECT
+		o.car_id,
+		EXISTS (SELECT 1 FROM order_inspections i WHERE i.order_id = o.id AND i.kind = $2)
+	FROM orders o
+	WHERE o.id = $1 AND o.deleted_at = 0`
+
+	err := d.db.QueryRow(ctx, query, damage.OrderId, config.INSPECTION_RETURN).Scan(&carID, &inspected)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrOrderNotFound
+		}
+		d.logger.Error("failed to get order for damage report", logger.Error(err))
+		return "", err
+	}
+
+	if !inspected {
+		return "", storage.ErrReturnNotInspected
+	}
+
+	query = `INSERT INTO damage_reports (
+		id,
+		order_id,
+		car_id,
+		location,
+		severity,
+		description,
+		estimated_cost,
+		reported_by,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, NULLIF($8, '')::uuid, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err = d.db.Exec(ctx, query,
+		id,
+		damage.OrderId,
+		carID,
+		damage.Location,
+		damage.Severity,
+		damage.Description,
+		damage.EstimatedCost,
+		damage.ReportedBy,
+	)
+
+	if err != nil {
+		d.logger.Error("failed to create damage report in database", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (d *DamageRepo) GetByID(ctx context.Context, orderID, id string) (models.DamageReport, error) {
+	query := `SELECT` + damageColumns + `
+	FROM damage_reports
+	WHERE id = $1 AND order_id = $2`
+
+	damage, err := scanDamage(d.db.QueryRow(ctx, query, id, orderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.DamageReport{}, storage.ErrDamageNotFound
+		}
+		d.logger.Error("failed to get damage report from database", logger.Error(err))
+		return models.DamageReport{}, err
+	}
+
+	photos, err := d.getPhotos(ctx, []string{id})
+	if err != nil {
+		return models.DamageReport{}, err
+	}
+	damage.Photos = photos[id]
+
+	return damage, nil
+}
+
+func (d *DamageRepo) GetByOrderID(ctx context.Context, orderID string) ([]models.DamageReport, error) {
+	return d.getAll(ctx, `order_id = $1`, orderID)
+}
+
+// GetByCarID returns the car's damage history across all its rentals,
+// newest first.
+func (d *DamageRepo) GetByCarID(ctx context.Context, carID string) ([]models.DamageReport, error) {
+	return d.getAll(ctx, `car_id = $1`, carID)
+}
+
+func (d *DamageRepo) Update(ctx context.Context, damage models.UpdateDamageReport) error {
+	query := `UPDATE damage_reports SET
+		location = $3,
+		severity = $4,
+		description = NULLIF($5, ''),
+		estimated_cost = $6,
+		actual_cost = $7,
+		charged_to_customer = $8,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND order_id = $2`
+
+	tag, err := d.db.Exec(ctx, query,
+		damage.Id,
+		damage.OrderId,
+		damage.Location,
+		damage.Severity,
+		damage.Description,
+		damage.EstimatedCost,
+		damage.ActualCost,
+		damage.ChargedToCustomer,
+	)
+
+	if err != nil {
+		d.logger.Error("failed to update damage report in database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrDamageNotFound
+	}
+
+	return nil
+}
+
+func (d *DamageRepo) CreatePhoto(ctx context.Context, photo models.CreateDamagePhoto) (string, error) {
+	id := uuid.New().String()
+
+	query := `INSERT INTO damage_photos (
+		id,
+		damage_id,
+		content_type,
+		size,
+		checksum,
+		storage_key,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)`
+
+	_, err := d.db.Exec(ctx, query,
+		id,
+		photo.DamageId,
+		photo.ContentType,
+		photo.Size,
+		photo.Checksum,
+		photo.StorageKey,
+	)
+
+	if err != nil {
+		if isMissingReference(err) {
+			return "", storage.ErrDamageNotFound
+		}
+		d.logger.Error("failed to create damage photo in database", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (d *DamageRepo) GetPhoto(ctx context.Context, damageID, id string) (models.DamagePhoto, error) {
+	query := `SELECT
+		id,
+		damage_id,
+		content_type,
+		size,
+		checksum,
+		storage_key,
+		created_at
+	FROM damage_photos
+	WHERE id = $1 AND damage_id = $2`
+
+	photo, err := scanDamagePhoto(d.db.QueryRow(ctx, query, id, damageID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.DamagePhoto{}, storage.ErrDamagePhotoNotFound
+		}
+		d.logger.Error("failed to get damage photo from database", logger.Error(err))
+		return models.DamagePhoto{}, err
+	}
+
+	return photo, nil
+}
+
+func (d *DamageRepo) getAll(ctx context.Context, filter string, arg string) ([]models.DamageReport, error) {
+	var (
+		damages   = []models.DamageReport{}
+		damageIDs []string
+	)
+
+	query := `SELECT` + damageColumns + `
+	FROM damage_reports
+	WHERE ` + filter + `
+	ORDER BY created_at DESC`
+
+	rows, err := d.db.Query(ctx, query, arg)
+	if err != nil {
+		d.logger.Error("failed to get damage reports from database", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		damage, err := scanDamage(rows)
+		if err != nil {
+			d.logger.Error("failed to scan damage reports from database", logger.Error(err))
+			return nil, err
+		}
+
+		damages = append(damages, damage)
+		damageIDs = append(damageIDs, damage.Id)
+	}
+
+	if err = rows.Err(); err != nil {
+		d.logger.Error("failed to get damage reports from database", logger.Error(err))
+		return nil, err
+	}
+
+	photos, err := d.getPhotos(ctx, damageIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range damages {
+		damages[i].Photos = photos[damages[i].Id]
+	}
+
+	return damages, nil
+}
+
+func (d *DamageRepo) getPhotos(ctx context.Context, damageIDs []string) (map[string][]models.DamagePhoto, error) {
+	photos := make(map[string][]models.DamagePhoto, len(damageIDs))
+	for _, id := range damageIDs {
+		photos[id] = []models.DamagePhoto{}
+	}
+
+	if len(damageIDs) == 0 {
+		return photos, nil
+	}
+
+	query := `SELECT
+		id,
+		damage_id,
+		content_type,
+		size,
+		checksum,
+		storage_key,
+		created_at
+	FROM damage_photos
+	WHERE damage_id = ANY($1)
+	ORDER BY created_at`
+
+	rows, err := d.db.Query(ctx, query, damageIDs)
+	if err != nil {
+		d.logger.Error("failed to get damage photos from database", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		photo, err := scanDamagePhoto(rows)
+		if err != nil {
+			d.logger.Error("failed to scan damage photos from database", logger.Error(err))
+			return nil, err
+		}
+
+		photos[photo.DamageId] = append(photos[photo.DamageId], photo)
+	}
+
+	if err = rows.Err(); err != nil {
+		d.logger.Error("failed to get damage photos from database", logger.Error(err))
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+func scanDamage(row pgx.Row) (models.DamageReport, error) {
+	var (
+		damage      models.DamageReport
+		description sql.NullString
+		actualCost  sql.NullFloat64
+		reportedBy  sql.NullString
+		createdAt   sql.NullString
+		updatedAt   sql.NullString
+	)
+
+	err := row.Scan(
+		&damage.Id,
+		&damage.OrderId,
+		&damage.CarId,
+		&damage.Location,
+		&damage.Severity,
+		&description,
+		&damage.EstimatedCost,
+		&actualCost,
+		&damage.ChargedToCustomer,
+		&reportedBy,
+		&createdAt,
+		&updatedAt,
+	)
+
+	if err != nil {
+		return models.DamageReport{}, err
+	}
+
+	damage.Description = description.String
+	if actualCost.Valid {
+		damage.ActualCost = &actualCost.Float64
+	}
+	damage.ReportedBy = reportedBy.String
+	damage.CreatedAt = createdAt.String
+	damage.UpdatedAt = updatedAt.String
+
+	return damage, nil
+}
+
+func scanDamagePhoto(row pgx.Row) (models.DamagePhoto, error) {
+	var (
+		photo     models.DamagePhoto
+		createdAt sql.NullString
+	)
+
+	err := row.Scan(
+		&photo.Id,
+		&photo.DamageId,
+		&photo.ContentType,
+		&photo.Size,
+		&photo.Checksum,
+		&photo.StorageKey,
+		&createdAt,
+	)
+
+	if err != nil {
+		return models.DamagePhoto{}, err
+	}
+
+	photo.CreatedAt = createdAt.String
+
+	return photo, nil
+}
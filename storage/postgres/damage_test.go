@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/storage"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDamageReports(t *testing.T) {
+	damageRepo := NewDamageRepo(db, log)
+	inspectionRepo := NewInspectionRepo(db, log)
+	orderRepo := NewOrderRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{Name: "Dented"})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().Format(time.DateOnly),
+		ToDate:     time.Now().AddDate(0, 0, 1).Format(time.DateOnly),
+		Status:     config.STATUS_PICKED_UP,
+		TotalPrice: 60,
+	})
+	assert.NoError(t, err)
+
+	report := models.CreateDamageReport{
+		OrderId:       orderID,
+		Location:      "rear bumper",
+		Severity:      config.DAMAGE_MODERATE,
+		EstimatedCost: 250,
+	}
+
+	_, err = damageRepo.Create(context.Background(), report)
+	assert.ErrorIs(t, err, storage.ErrReturnNotInspected)
+
+	_, err = inspectionRepo.Create(context.Background(), models.CreateOrderInspection{
+		OrderId:   orderID,
+		Kind:      config.INSPECTION_RETURN,
+		Odometer:  400,
+		FuelLevel: 90,
+	})
+	assert.NoError(t, err)
+
+	id, err := damageRepo.Create(context.Background(), report)
+	assert.NoError(t, err)
+
+	_, err = damageRepo.CreatePhoto(context.Background(), models.CreateDamagePhoto{
+		DamageId:    id,
+		ContentType: "image/jpeg",
+		Size:        2048,
+		Checksum:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		StorageKey:  "orders/" + orderID + "/damages/" + id + "/" + uuid.New().String(),
+	})
+	assert.NoError(t, err)
+
+	actualCost := 212.5
+	update := models.UpdateDamageReport{
+		Id:                id,
+		OrderId:           orderID,
+		Location:          report.Location,
+		Severity:          report.Severity,
+		EstimatedCost:     report.EstimatedCost,
+		ActualCost:        &actualCost,
+		ChargedToCustomer: true,
+	}
+
+	err = damageRepo.Update(context.Background(), update)
+	assert.NoError(t, err)
+
+	damages, err := damageRepo.GetByCarID(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(damages))
+	assert.Equal(t, orderID, damages[0].OrderId)
+	if assert.NotNil(t, damages[0].ActualCost) {
+		assert.Equal(t, 212.5, *damages[0].ActualCost)
+	}
+	assert.True(t, damages[0].ChargedToCustomer)
+	assert.Equal(t, 1, len(damages[0].Photos))
+
+	// a repair done for free is still a known cost
+	actualCost = 0
+	err = damageRepo.Update(context.Background(), update)
+	assert.NoError(t, err)
+
+	damage, err := damageRepo.GetByID(context.Background(), orderID, id)
+	assert.NoError(t, err)
+	if assert.NotNil(t, damage.ActualCost) {
+		assert.Equal(t, 0.0, *damage.ActualCost)
+	}
+
+	_, err = damageRepo.GetByID(context.Background(), OrderiD, id)
+	assert.ErrorIs(t, err, storage.ErrDamageNotFound)
+}
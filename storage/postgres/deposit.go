@@ -0,0 +1,376 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/pkg/sqlbuilder"
+	"rent-car/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// depositSortColumns are the columns deposit lists can be sorted by.
+var depositSortColumns = sqlbuilder.Columns{
+	"captured_at": "captured_at",
+	"amount":      "amount",
+}
+
+// depositFilters are the filters deposit lists accept.
+var depositFilters = sqlbuilder.Filters{
+	"status": {Kind: sqlbuilder.FilterEquals, Columns: []string{"status"}},
+}
+
+type DepositRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewDepositRepo(db *pgxpool.Pool, log logger.ILogger) DepositRepo {
+	return DepositRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (d *DepositRepo) Release(ctx context.Context, req models.ReleaseDeposit) (models.OrderDeposit, error) {
+	var (
+		amount   float64
+		status   string
+		withheld float64
+	)
+
+	tx, err := d.db.Begin(ctx)
+	if err != nil {
+		d.logger.Error("failed to begin deposit release transaction", logger.Error(err))
+		return models.OrderDeposit{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `SELECT amount, status FROM order_deposits WHERE order_id = $1 FOR UPDATE`
+
+	err = tx.QueryRow(ctx, query, req.OrderId).Scan(&amount, &status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OrderDeposit{}, storage.ErrDepositNotFound
+		}
+		d.logger.Error("failed to lock order deposit", logger.Error(err))
+		return models.OrderDeposit{}, err
+	}
+
+	if status != config.DEPOSIT_HELD {
+		return models.OrderDeposit{}, storage.ErrDepositAlreadyReleased
+	}
+
+	for _, deduction := range req.Deductions {
+		withheld += deduction.Amount
+	}
+	withheld = math.Round(withheld*100) / 100
+
+	if withheld > amount {
+		return models.OrderDeposit{}, storage.ErrDeductionExceedsDeposit
+	}
+
+	query = `INSERT INTO deposit_deductions (
+		id,
+		order_id,
+		reason,
+		amount,
+		note,
+		created_at
+	) VALUES ($1, $2, $3, $4, NULLIF($5, ''), CURRENT_TIMESTAMP)`
+
+	for _, deduction := range req.Deductions {
+		_, err = tx.Exec(ctx, query,
+			uuid.New().String(),
+			req.OrderId,
+			deduction.Reason,
+			deduction.Amount,
+			deduction.Note,
+		)
+
+		if err != nil {
+			d.logger.Error("failed to create deposit deduction in database", logger.Error(err))
+			return models.OrderDeposit{}, err
+		}
+	}
+
+	switch {
+	case withheld == 0:
+		status = config.DEPOSIT_RELEASED
+	case withheld == amount:
+		status = config.DEPOSIT_WITHHELD
+	default:
+		status = config.DEPOSIT_PARTIALLY_RELEASED
+	}
+
+	query = `UPDATE order_deposits SET
+		status = $2,
+		withheld_amount = $3,
+		released_at = CURRENT_TIMESTAMP
+	WHERE order_id = $1`
+
+	_, err = tx.Exec(ctx, query, req.OrderId, status, withheld)
+	if err != nil {
+		d.logger.Error("failed to release order deposit in database", logger.Error(err))
+		return models.OrderDeposit{}, err
+	}
+
+	query = `INSERT INTO payments (
+		order_id,
+		kind,
+		amount
+	) VALUES ($1, $2, $3)`
+
+	movements := []struct {
+		kind   string
+		amount float64
+	}{
+		{config.PAYMENT_DEPOSIT_WITHHOLD, withheld},
+		{config.PAYMENT_DEPOSIT_RELEASE, math.Round((amount-withheld)*100) / 100},
+	}
+
+	for _, movement := range movements {
+		if movement.amount <= 0 {
+			continue
+		}
+
+		_, err = tx.Exec(ctx, query, req.OrderId, movement.kind, movement.amount)
+		if err != nil {
+			d.logger.Error("failed to record deposit movement in payments", logger.Error(err))
+			return models.OrderDeposit{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		d.logger.Error("failed to commit deposit release transaction", logger.Error(err))
+		return models.OrderDeposit{}, err
+	}
+
+	return d.GetByOrderID(ctx, req.OrderId)
+}
+
+func (d *DepositRepo) GetByOrderID(ctx context.Context, orderID string) (models.OrderDeposit, error) {
+	var (
+		deposit    models.OrderDeposit
+		capturedAt sql.NullString
+		releasedAt sql.NullString
+	)
+
+	query := `SELECT
+		order_id,
+		amount,
+		status,
+		withheld_amount,
+		captured_at,
+		released_at
+	FROM order_deposits
+	WHERE order_id = $1`
+
+	err := d.db.QueryRow(ctx, query, orderID).Scan(
+		&deposit.OrderId,
+		&deposit.Amount,
+		&deposit.Status,
+		&deposit.WithheldAmount,
+		&capturedAt,
+		&releasedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OrderDeposit{}, storage.ErrDepositNotFound
+		}
+		d.logger.Error("failed to get order deposit from database", logger.Error(err))
+		return models.OrderDeposit{}, err
+	}
+
+	deposit.CapturedAt = capturedAt.String
+	deposit.ReleasedAt = releasedAt.String
+	if releasedAt.Valid {
+		deposit.ReleasedAmount = math.Round((deposit.Amount-deposit.WithheldAmount)*100) / 100
+	}
+
+	deductions, err := d.getDeductions(ctx, []string{orderID})
+	if err != nil {
+		return models.OrderDeposit{}, err
+	}
+	deposit.Deductions = deductions[orderID]
+
+	return deposit, nil
+}
+
+func (d *DepositRepo) GetAll(ctx context.Context, req models.GetAllDepositsRequest) (models.GetAllDepositsResponse, error) {
+	var (
+		resp     = models.GetAllDepositsResponse{Deposits: []models.OrderDeposit{}}
+		orderIDs []string
+	)
+
+	b := sqlbuilder.New().
+		Page(req.Page, req.Limit)
+
+	if err := b.Filter(depositFilters, sqlbuilder.Values{"status": req.Status}); err != nil {
+		return resp, err
+	}
+
+	// captured_at is a timestamp, the bounds are whole days
+	if req.From != "" {
+		b.Where("captured_at >= ?::date", req.From)
+	}
+	if req.To != "" {
+		b.Where("captured_at < ?::date + 1", req.To)
+	}
+
+	if err := b.OrderBy(depositSortColumns, "order_id", "captured_at"); err != nil {
+		return resp, err
+	}
+
+	query, args := b.Select(`SELECT
+		order_id,
+		amount,
+		status,
+		withheld_amount,
+		captured_at,
+		released_at
+	FROM order_deposits`)
+
+	rows, err := d.db.Query(ctx, query, args...)
+	if err != nil {
+		d.logger.Error("failed to get all deposits from database", logger.Error(err))
+		return resp, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			deposit    models.OrderDeposit
+			capturedAt sql.NullString
+			releasedAt sql.NullString
+		)
+
+		err := rows.Scan(
+			&deposit.OrderId,
+			&deposit.Amount,
+			&deposit.Status,
+			&deposit.WithheldAmount,
+			&capturedAt,
+			&releasedAt,
+		)
+
+		if err != nil {
+			d.logger.Error("failed to scan all deposits from database", logger.Error(err))
+			return resp, err
+		}
+
+		deposit.CapturedAt = capturedAt.String
+		deposit.ReleasedAt = releasedAt.String
+		if releasedAt.Valid {
+			deposit.ReleasedAmount = math.Round((deposit.Amount-deposit.WithheldAmount)*100) / 100
+		}
+
+		resp.Deposits = append(resp.Deposits, deposit)
+		orderIDs = append(orderIDs, deposit.OrderId)
+	}
+
+	if err = rows.Err(); err != nil {
+		d.logger.Error("failed to get all deposits from database", logger.Error(err))
+		return resp, err
+	}
+
+	deductions, err := d.getDeductions(ctx, orderIDs)
+	if err != nil {
+		return resp, err
+	}
+	for i := range resp.Deposits {
+		resp.Deposits[i].Deductions = deductions[resp.Deposits[i].OrderId]
+	}
+
+	// totals cover the whole filtered set, not just the current page
+	countQuery, countArgs := b.Count(`SELECT
+		COUNT(*),
+		COALESCE(SUM(amount) FILTER (WHERE status = '` + config.DEPOSIT_HELD + `'), 0),
+		COALESCE(SUM(withheld_amount), 0)
+	FROM order_deposits`)
+
+	err = d.db.QueryRow(ctx, countQuery, countArgs...).Scan(
+		&resp.Count,
+		&resp.HeldAmount,
+		&resp.WithheldAmount,
+	)
+
+	if err != nil {
+		d.logger.Error("failed to get deposits count from database", logger.Error(err))
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func (d *DepositRepo) getDeductions(ctx context.Context, orderIDs []string) (map[string][]models.DepositDeduction, error) {
+	deductions := make(map[string][]models.DepositDeduction, len(orderIDs))
+	for _, id := range orderIDs {
+		deductions[id] = []models.DepositDeduction{}
+	}
+
+	if len(orderIDs) == 0 {
+		return deductions, nil
+	}
+
+	query := `SELECT
+		id,
+		order_id,
+		reason,
+		amount,
+		note,
+		created_at
+	FROM deposit_deductions
+	WHERE order_id = ANY($1)
+	ORDER BY created_at`
+
+	rows, err := d.db.Query(ctx, query, orderIDs)
+	if err != nil {
+		d.logger.Error("failed to get deposit deductions from database", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			deduction models.DepositDeduction
+			orderID   string
+			note      sql.NullString
+			createdAt sql.NullString
+		)
+
+		err := rows.Scan(
+			&deduction.Id,
+			&orderID,
+			&deduction.Reason,
+			&deduction.Amount,
+			&note,
+			&createdAt,
+		)
+
+		if err != nil {
+			d.logger.Error("failed to scan deposit deductions from database", logger.Error(err))
+			return nil, err
+		}
+
+		deduction.Note = note.String
+		deduction.CreatedAt = createdAt.String
+
+		deductions[orderID] = append(deductions[orderID], deduction)
+	}
+
+	if err = rows.Err(); err != nil {
+		d.logger.Error("failed to get deposit deductions from database", logger.Error(err))
+		return nil, err
+	}
+
+	return deductions, nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/storage"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseDeposit(t *testing.T) {
+	depositRepo := NewDepositRepo(db, log)
+	orderRepo := NewOrderRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:    "Deposit",
+		Price:   60,
+		Deposit: 300,
+	})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().Format(time.RFC3339),
+		ToDate:     time.Now().AddDate(0, 0, 2).Format(time.RFC3339),
+		Status:     config.STATUS_NEW,
+		TotalPrice: 120,
+	})
+	assert.NoError(t, err)
+
+	_, err = depositRepo.GetByOrderID(context.Background(), orderID)
+	assert.ErrorIs(t, err, storage.ErrDepositNotFound)
+
+	for _, step := range []models.UpdateOrderStatus{
+		{Id: orderID, FromStatus: config.STATUS_NEW, Status: config.STATUS_CONFIRMED},
+		{Id: orderID, FromStatus: config.STATUS_CONFIRMED, Status: config.STATUS_PICKED_UP, CaptureDeposit: true},
+		{Id: orderID, FromStatus: config.STATUS_PICKED_UP, Status: config.STATUS_RETURNED},
+	} {
+		_, err = orderRepo.UpdateStatus(context.Background(), step)
+		assert.NoError(t, err)
+	}
+
+	deposit, err := depositRepo.GetByOrderID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 300.0, deposit.Amount)
+	assert.Equal(t, config.DEPOSIT_HELD, deposit.Status)
+
+	paymentRepo := NewPaymentRepo(db, log)
+	balance, err := paymentRepo.GetBalance(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 300.0, balance.DepositHeld)
+	assert.Equal(t, 0.0, balance.PaidAmount)
+
+	_, err = depositRepo.Release(context.Background(), models.ReleaseDeposit{
+		OrderId: orderID,
+		Deductions: []models.CreateDepositDeduction{
+			{Reason: config.DEDUCTION_DAMAGE, Amount: 250},
+			{Reason: config.DEDUCTION_FUEL, Amount: 100},
+		},
+	})
+	assert.ErrorIs(t, err, storage.ErrDeductionExceedsDeposit)
+
+	deposit, err = depositRepo.Release(context.Background(), models.ReleaseDeposit{
+		OrderId: orderID,
+		Deductions: []models.CreateDepositDeduction{
+			{Reason: config.DEDUCTION_FUEL, Amount: 40, Note: "tank a quarter full"},
+			{Reason: config.DEDUCTION_LATE_RETURN, Amount: 60},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, config.DEPOSIT_PARTIALLY_RELEASED, deposit.Status)
+	assert.Equal(t, 100.0, deposit.WithheldAmount)
+	assert.Equal(t, 200.0, deposit.ReleasedAmount)
+	assert.Len(t, deposit.Deductions, 2)
+
+	balance, err = paymentRepo.GetBalance(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, balance.DepositHeld)
+	assert.Equal(t, 100.0, balance.DepositWithheld)
+
+	_, err = depositRepo.Release(context.Background(), models.ReleaseDeposit{OrderId: orderID})
+	assert.ErrorIs(t, err, storage.ErrDepositAlreadyReleased)
+
+	err = orderRepo.DeleteHard(context.Background(), orderID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/pkg/logger"
+	"rent-car/pkg/sqlbuilder"
+	"rent-car/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// documentSortColumns are the columns document lists can be sorted by.
+var documentSortColumns = sqlbuilder.Columns{
+	"created_at": "created_at",
+}
+
+// documentFilters are the filters document lists accept.
+var documentFilters = sqlbuilder.Filters{
+	"customer_id": {Kind: sqlbuilder.FilterEquals, Columns: []string{"customer_id"}},
+	"car_id":      {Kind: sqlbuilder.FilterEquals, Columns: []string{"car_id"}},
+	"type":        {Kind: sqlbuilder.FilterEquals, Columns: []string{"type"}},
+}
+
+type DocumentRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewDocumentRepo(db *pgxpool.Pool, log logger.ILogger) DocumentRepo {
+	return DocumentRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (d *DocumentRepo) Create(ctx context.Context, document models.CreateDocument) (string, error) {
+	id := uuid.New().String()
+
+	query := `INSERT INTO documents (
+		id,
+		customer_id,
+		car_id,
+		type,
+		file_name,
+		content_type,
+		size,
+		checksum,
+		storage_key,
+		uploaded_by,
+		created_at
+	) VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, $9, NULLIF($10, '')::uuid, CURRENT_TIMESTAMP)`
+
+	_, err := d.db.Exec(ctx, query,
+		id,
+		document.CustomerId,
+		document.CarId,
+		document.Type,
+		document.FileName,
+		document.ContentType,
+		document.Size,
+		document.Checksum,
+		document.StorageKey,
+		document.UploadedBy,
+	)
+
+	if err != nil {
+		if isMissingReference(err) {
+			return "", storage.ErrDocumentOwnerNotFound
+		}
+		d.logger.Error("failed to create document in database", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (d *DocumentRepo) GetByID(ctx context.Context, id string) (models.Document, error) {
+	query := `SELECT
+		id,
+		customer_id::text,
+		car_id::text,
+		type,
+		file_name,
+		content_type,
+		size,
+		checksum,
+		storage_key,
+		uploaded_by::text,
+		created_at
+	FROM documents
+	WHERE id = $1`
+
+	document, err := scanDocument(d.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Document{}, storage.ErrDocumentNotFound
+		}
+		d.logger.Error("failed to get document from database", logger.Error(err))
+		return models.Document{}, err
+	}
+
+	return document, nil
+}
+
+func (d *DocumentRepo) GetAll(ctx context.Context, req models.GetAllDocumentsRequest) (models.GetAllDocumentsResponse, error) {
+	resp := models.GetAllDocumentsResponse{Documents: []models.Document{}}
+
+	b := sqlbuilder.New()
+
+	err := b.Filter(documentFilters, sqlbuilder.Values{
+		"customer_id": req.CustomerId,
+		"car_id":      req.CarId,
+		"type":        req.Type,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if err := b.OrderBy(documentSortColumns, "id", "created_at"); err != nil {
+		return resp, err
+	}
+
+	query, args := b.Select(`SELECT
+		id,
+		customer_id::text,
+		car_id::text,
+		type,
+		file_name,
+		content_type,
+		size,
+		checksum,
+		storage_key,
+		uploaded_by::text,
+		created_at
+	FROM documents`)
+
+	rows, err := d.db.Query(ctx, query, args...)
+	if err != nil {
+		d.logger.Error("failed to get all documents from database", logger.Error(err))
+		return resp, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		document, err := scanDocument(rows)
+		if err != nil {
+			d.logger.Error("failed to scan documents from database", logger.Error(err))
+			return resp, err
+		}
+
+		resp.Documents = append(resp.Documents, document)
+	}
+
+	if err = rows.Err(); err != nil {
+		d.logger.Error("failed to get all documents from database", logger.Error(err))
+		return resp, err
+	}
+
+	resp.Count = int64(len(resp.Documents))
+
+	return resp, nil
+}
+
+func (d *DocumentRepo) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM documents WHERE id = $1`
+
+	tag, err := d.db.Exec(ctx, query, id)
+	if err != nil {
+		d.logger.Error("failed to delete document from database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrDocumentNotFound
+	}
+
+	return nil
+}
+
+func scanDocument(row pgx.Row) (models.Document, error) {
+	var (
+		document   models.Document
+		customerID sql.NullString
+		carID      sql.NullString
+		uploadedBy sql.NullString
+		createdAt  sql.NullString
+	)
+
+	err := row.Scan(
+		&document.Id,
+		&customerID,
+		&carID,
+		&document.Type,
+		&document.FileName,
+		&document.ContentType,
+		&document.Size,
+		&document.Checksum,
+		&document.StorageKey,
+		&uploadedBy,
+		&createdAt,
+	)
+
+	if err != nil {
+		return models.Document{}, err
+	}
+
+	document.CustomerId = customerID.String
+	document.CarId = carID.String
+	document.UploadedBy = uploadedBy.String
+	document.CreatedAt = createdAt.String
+
+	return document, nil
+}
+
+func isMissingReference(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/storage"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument(t *testing.T) {
+	documentRepo := NewDocumentRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "Documents",
+		Price: 50,
+	})
+
+	documentID, err := documentRepo.Create(context.Background(), models.CreateDocument{
+		CarId:       carID,
+		Type:        config.DOCUMENT_INSURANCE,
+		FileName:    "insurance.pdf",
+		ContentType: "application/pdf",
+		Size:        2048,
+		Checksum:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		StorageKey:  "cars/" + carID + "/" + uuid.New().String(),
+	})
+	assert.NoError(t, err)
+
+	document, err := documentRepo.GetByID(context.Background(), documentID)
+	assert.NoError(t, err)
+	assert.Equal(t, carID, document.CarId)
+	assert.Empty(t, document.CustomerId)
+	assert.Equal(t, int64(2048), document.Size)
+
+	documents, err := documentRepo.GetAll(context.Background(), models.GetAllDocumentsRequest{CarId: carID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), documents.Count)
+
+	_, err = documentRepo.Create(context.Background(), models.CreateDocument{
+		CarId:       uuid.New().String(),
+		Type:        config.DOCUMENT_PHOTO,
+		FileName:    "front.jpg",
+		ContentType: "image/jpeg",
+		Size:        1024,
+		Checksum:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		StorageKey:  "cars/missing/" + uuid.New().String(),
+	})
+	assert.ErrorIs(t, err, storage.ErrDocumentOwnerNotFound)
+
+	err = documentRepo.Delete(context.Background(), documentID)
+	assert.NoError(t, err)
+
+	_, err = documentRepo.GetByID(context.Background(), documentID)
+	assert.ErrorIs(t, err, storage.ErrDocumentNotFound)
+
+}
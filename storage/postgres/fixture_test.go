@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestCar creates a car for a single test, fields left empty get
+// throwaway values. The car and every order booked on it are hard deleted
+// once the test ends, so reruns start from the same rows.
+func createTestCar(t *testing.T, car models.CreateCarRequest) string {
+	t.Helper()
+
+	if car.Brand == "" {
+		car.Brand = faker.Word()
+	}
+	if car.Model == "" {
+		car.Model = faker.Word()
+	}
+	if car.Year == 0 {
+		car.Year = 2020
+	}
+	if car.HorsePower == 0 {
+		car.HorsePower = 150
+	}
+	if car.Colour == "" {
+		car.Colour = "White"
+	}
+	if car.EngineCap == 0 {
+		car.EngineCap = 1.6
+	}
+
+	carRepo := NewCarRepo(db, log)
+
+	id, err := carRepo.Create(context.Background(), car)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	t.Cleanup(func() {
+		_, err := db.Exec(context.Background(), `DELETE FROM orders WHERE car_id = $1`, id)
+		assert.NoError(t, err)
+		assert.NoError(t, carRepo.DeleteHard(context.Background(), id))
+	})
+
+	return id
+}
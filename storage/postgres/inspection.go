@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// inspectionStatuses is the order status each inspection is taken in.
+var inspectionStatuses = map[string]string{
+	config.INSPECTION_PICKUP: config.STATUS_CONFIRMED,
+	config.INSPECTION_RETURN: config.STATUS_PICKED_UP,
+}
+
+type InspectionRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewInspectionRepo(db *pgxpool.Pool, log logger.ILogger) InspectionRepo {
+	return InspectionRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Create records an inspection and moves the car's odometer up to its
+// reading. Inspections can not be changed once recorded since the return
+// charges are worked out from them.
+func (i *InspectionRepo) Create(ctx context.Context, inspection models.CreateOrderInspection) (string, error) {
+	var (
+		id      = uuid.New().String()
+		status  string
+		carID   string
+		mileage int64
+	)
+
+	tx, err := i.db.Begin(ctx)
+	if err != nil {
+		i.logger.Error("failed to begin inspection transaction", logger.Error(err))
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `SELECT o.status, c.id, c.mileage
+	FROM orders o
+	JOIN cars c ON c.id = o.car_id
+	WHERE o.id = $1 AND o.deleted_at = 0
+	FOR UPDATE`
+
+	err = tx.QueryRow(ctx, query, inspection.OrderId).Scan(&status, &carID, &mileage)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrOrderNotFound
+		}
+		i.logger.Error("failed to lock order for inspection", logger.Error(err))
+		return "", err
+	}
+
+	if status != inspectionStatuses[inspection.Kind] {
+		return "", storage.ErrInspectionNotAllowed
+	}
+
+	if inspection.Odometer < mileage {
+		return "", storage.ErrOdometerRollback
+	}
+
+	query = `INSERT INTO order_inspections (
+		id,
+		order_id,
+		kind,
+		odometer,
+		fuel_level,
+		inspected_by,
+		notes,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, NULLIF($7, ''), CURRENT_TIMESTAMP)`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		inspection.OrderId,
+		inspection.Kind,
+		inspection.Odometer,
+		inspection.FuelLevel,
+		inspection.InspectedBy,
+		inspection.Notes,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return "", storage.ErrInspectionExists
+		}
+		i.logger.Error("failed to create inspection in database", logger.Error(err))
+		return "", err
+	}
+
+	query = `UPDATE cars SET mileage = $2 WHERE id = $1`
+
+	if _, err = tx.Exec(ctx, query, carID, inspection.Odometer); err != nil {
+		i.logger.Error("failed to update car mileage in database", logger.Error(err))
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		i.logger.Error("failed to commit inspection transaction", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (i *InspectionRepo) GetByOrderID(ctx context.Context, orderID string) ([]models.OrderInspection, error) {
+	inspections := []models.OrderInspection{}
+
+	query := `SELECT
+		id,
+		order_id,
+		kind,
+		odometer,
+		fuel_level,
+		inspected_by::text,
+		notes,
+		created_at
+	FROM order_inspections
+	WHERE order_id = $1
+	ORDER BY created_at`
+
+	rows, err := i.db.Query(ctx, query, orderID)
+	if err != nil {
+		i.logger.Error("failed to get inspections from database", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			inspection  models.OrderInspection
+			inspectedBy sql.NullString
+			notes       sql.NullString
+			createdAt   sql.NullString
+		)
+
+		err := rows.Scan(
+			&inspection.Id,
+			&inspection.OrderId,
+			&inspection.Kind,
+			&inspection.Odometer,
+			&inspection.FuelLevel,
+			&inspectedBy,
+			&notes,
+			&createdAt,
+		)
+
+		if err != nil {
+			i.logger.Error("failed to scan inspections from database", logger.Error(err))
+			return nil, err
+		}
+
+		inspection.InspectedBy = inspectedBy.String
+		inspection.Notes = notes.String
+		inspection.CreatedAt = createdAt.String
+
+		inspections = append(inspections, inspection)
+	}
+
+	if err = rows.Err(); err != nil {
+		i.logger.Error("failed to get inspections from database", logger.Error(err))
+		return nil, err
+	}
+
+	return inspections, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
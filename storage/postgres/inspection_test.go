@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/storage"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderInspections(t *testing.T) {
+	inspectionRepo := NewInspectionRepo(db, log)
+	orderRepo := NewOrderRepo(db, log)
+	carRepo := NewCarRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:    "Inspected",
+		Mileage: 1000,
+	})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().Format(time.DateOnly),
+		ToDate:     time.Now().AddDate(0, 0, 2).Format(time.DateOnly),
+		Status:     config.STATUS_CONFIRMED,
+		TotalPrice: 100,
+	})
+	assert.NoError(t, err)
+
+	_, err = inspectionRepo.Create(context.Background(), models.CreateOrderInspection{
+		OrderId:   orderID,
+		Kind:      config.INSPECTION_RETURN,
+		Odometer:  1200,
+		FuelLevel: 100,
+	})
+	assert.ErrorIs(t, err, storage.ErrInspectionNotAllowed)
+
+	pickup := models.CreateOrderInspection{
+		OrderId:   orderID,
+		Kind:      config.INSPECTION_PICKUP,
+		Odometer:  900,
+		FuelLevel: 100,
+	}
+
+	_, err = inspectionRepo.Create(context.Background(), pickup)
+	assert.ErrorIs(t, err, storage.ErrOdometerRollback)
+
+	pickup.Odometer = 1200
+	_, err = inspectionRepo.Create(context.Background(), pickup)
+	assert.NoError(t, err)
+
+	_, err = inspectionRepo.Create(context.Background(), pickup)
+	assert.ErrorIs(t, err, storage.ErrInspectionExists)
+
+	car, err := carRepo.GetByID(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1200), car.Mileage)
+
+	_, err = orderRepo.UpdateStatus(context.Background(), models.UpdateOrderStatus{
+		Id:         orderID,
+		Status:     config.STATUS_PICKED_UP,
+		FromStatus: config.STATUS_CONFIRMED,
+	})
+	assert.NoError(t, err)
+
+	_, err = inspectionRepo.Create(context.Background(), models.CreateOrderInspection{
+		OrderId:   orderID,
+		Kind:      config.INSPECTION_RETURN,
+		Odometer:  2000,
+		FuelLevel: 60,
+	})
+	assert.NoError(t, err)
+
+	inspections, err := inspectionRepo.GetByOrderID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(inspections))
+
+	_, err = orderRepo.UpdateStatus(context.Background(), models.UpdateOrderStatus{
+		Id:            orderID,
+		Status:        config.STATUS_RETURNED,
+		FromStatus:    config.STATUS_PICKED_UP,
+		ReturnCharges: &models.ReturnCharges{ExcessMileageCharge: 50, FuelCharge: 36},
+	})
+	assert.NoError(t, err)
+
+	order, err := orderRepo.GetByID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 186.0, order.TotalPrice)
+	assert.Equal(t, 36.0, order.FuelCharge)
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"rent-car/config"
 	"rent-car/pkg/logger"
+	"rent-car/storage"
+	"rent-car/storage/redis"
 
 	"testing"
 
@@ -13,8 +15,9 @@ import (
 )
 
 var (
-	db *pgxpool.Pool
-	log logger.ILogger
+	db    *pgxpool.Pool
+	log   logger.ILogger
+	cache storage.IRedisStorage
 )
 
 var (
@@ -25,6 +28,9 @@ var (
 
 func TestMain(m *testing.M) {
 	cfg := config.Load()
+	log = logger.New(cfg.ServiceName)
+	cache = redis.New(cfg)
+
 	conf, err := pgxpool.ParseConfig(fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=disable",
 		cfg.PostgresUser,
@@ -32,7 +38,6 @@ func TestMain(m *testing.M) {
 		cfg.PostgresHost,
 		cfg.PostgresPort,
 		cfg.PostgresDatabase,
-		cfg.ServiceName,
 	))
 	if err != nil {
 		panic(err)
@@ -44,7 +49,7 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		panic(err)
 	}
-	
+
 	conf.MaxConns = 10
 
 	db, err = pgxpool.NewWithConfig(context.Background(), conf)
@@ -0,0 +1,653 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MaintenanceRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewMaintenanceRepo(db *pgxpool.Pool, log logger.ILogger) MaintenanceRepo {
+	return MaintenanceRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+const maintenanceColumns = `
+		id,
+		car_id,
+		interval_id::text,
+		title,
+		status,
+		start_date::text,
+		end_date::text,
+		mileage,
+		cost,
+		notes,
+		completed_at,
+		created_at,
+		updated_at`
+
+const intervalColumns = `
+		i.id,
+		i.car_id,
+		i.name,
+		i.every_km,
+		i.every_days,
+		i.last_service_date::text,
+		i.last_service_mileage,
+		(i.last_service_date + i.every_days)::text,
+		i.last_service_mileage + i.every_km,
+		i.created_at`
+
+// Create schedules a maintenance window, the car must not be booked for any
+// day of it.
+func (m *MaintenanceRepo) Create(ctx context.Context, maintenance models.CreateMaintenance) (string, error) {
+	id := uuid.New().String()
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		m.logger.Error("failed to begin maintenance transaction", logger.Error(err))
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCar(ctx, tx, maintenance.CarId); err != nil {
+		return "", err
+	}
+
+	if maintenance.IntervalId != "" {
+		var exists bool
+
+		query := `SELECT EXISTS (SELECT 1 FROM maintenance_intervals WHERE id = $1 AND car_id = $2)`
+
+		if err = tx.QueryRow(ctx, query, maintenance.IntervalId, maintenance.CarId).Scan(&exists); err != nil {
+			m.logger.Error("failed to check maintenance interval in database", logger.Error(err))
+			return "", err
+		}
+		if !exists {
+			return "", storage.ErrIntervalNotFound
+		}
+	}
+
+	if err = m.checkBooked(ctx, tx, maintenance.CarId, maintenance.StartDate, maintenance.EndDate); err != nil {
+		return "", err
+	}
+
+	query := `INSERT INTO maintenance (
+		id,
+		car_id,
+		interval_id,
+		title,
+		status,
+		start_date,
+		end_date,
+		notes,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, NULLIF($8, ''), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		maintenance.CarId,
+		maintenance.IntervalId,
+		maintenance.Title,
+		config.MAINTENANCE_SCHEDULED,
+		maintenance.StartDate,
+		maintenance.EndDate,
+		maintenance.Notes,
+	)
+
+	if err != nil {
+		m.logger.Error("failed to create maintenance in database", logger.Error(err))
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		m.logger.Error("failed to commit maintenance transaction", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (m *MaintenanceRepo) GetByID(ctx context.Context, carID, id string) (models.Maintenance, error) {
+	query := `SELECT` + maintenanceColumns + `
+	FROM maintenance
+	WHERE id = $1 AND car_id = $2`
+
+	maintenance, err := scanMaintenance(m.db.QueryRow(ctx, query, id, carID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Maintenance{}, storage.ErrMaintenanceNotFound
+		}
+		m.logger.Error("failed to get maintenance from database", logger.Error(err))
+		return models.Maintenance{}, err
+	}
+
+	return maintenance, nil
+}
+
+func (m *MaintenanceRepo) GetAll(ctx context.Context, carID string) (models.GetAllMaintenanceResponse, error) {
+	resp := models.GetAllMaintenanceResponse{Maintenance: []models.Maintenance{}}
+
+	query := `SELECT` + maintenanceColumns + `
+	FROM maintenance
+	WHERE car_id = $1
+	ORDER BY start_date DESC`
+
+	rows, err := m.db.Query(ctx, query, carID)
+	if err != nil {
+		m.logger.Error("failed to get all maintenance from database", logger.Error(err))
+		return resp, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		maintenance, err := scanMaintenance(rows)
+		if err != nil {
+			m.logger.Error("failed to scan maintenance from database", logger.Error(err))
+			return resp, err
+		}
+
+		resp.Maintenance = append(resp.Maintenance, maintenance)
+		resp.TotalCost += maintenance.Cost
+	}
+
+	if err = rows.Err(); err != nil {
+		m.logger.Error("failed to get all maintenance from database", logger.Error(err))
+		return resp, err
+	}
+
+	resp.Count = int64(len(resp.Maintenance))
+
+	return resp, nil
+}
+
+func (m *MaintenanceRepo) Update(ctx context.Context, maintenance models.UpdateMaintenance) error {
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		m.logger.Error("failed to begin maintenance transaction", logger.Error(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCar(ctx, tx, maintenance.CarId); err != nil {
+		return err
+	}
+
+	if _, err = m.lockScheduled(ctx, tx, maintenance.CarId, maintenance.Id); err != nil {
+		return err
+	}
+
+	if err = m.checkBooked(ctx, tx, maintenance.CarId, maintenance.StartDate, maintenance.EndDate); err != nil {
+		return err
+	}
+
+	query := `UPDATE maintenance SET
+		title = $2,
+		start_date = $3,
+		end_date = $4,
+		notes = NULLIF($5, ''),
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	_, err = tx.Exec(ctx, query,
+		maintenance.Id,
+		maintenance.Title,
+		maintenance.StartDate,
+		maintenance.EndDate,
+		maintenance.Notes,
+	)
+
+	if err != nil {
+		m.logger.Error("failed to update maintenance in database", logger.Error(err))
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		m.logger.Error("failed to commit maintenance transaction", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Complete records the service and frees the rest of the window. The car's
+// odometer moves up to the reported mileage, and the interval the work was
+// for, if any, restarts from today.
+func (m *MaintenanceRepo) Complete(ctx context.Context, req models.CompleteMaintenance) error {
+	var mileage int64
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		m.logger.Error("failed to begin maintenance transaction", logger.Error(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCar(ctx, tx, req.CarId); err != nil {
+		return err
+	}
+
+	intervalID, err := m.lockScheduled(ctx, tx, req.CarId, req.Id)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE cars SET mileage = GREATEST(mileage, $2) WHERE id = $1 RETURNING mileage`
+
+	if err = tx.QueryRow(ctx, query, req.CarId, req.Mileage).Scan(&mileage); err != nil {
+		m.logger.Error("failed to update car mileage in database", logger.Error(err))
+		return err
+	}
+
+	query = `UPDATE maintenance SET
+		status = $2,
+		mileage = $3,
+		cost = $4,
+		notes = COALESCE(NULLIF($5, ''), notes),
+		completed_at = CURRENT_TIMESTAMP,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	_, err = tx.Exec(ctx, query, req.Id, config.MAINTENANCE_COMPLETED, mileage, req.Cost, req.Notes)
+	if err != nil {
+		m.logger.Error("failed to complete maintenance in database", logger.Error(err))
+		return err
+	}
+
+	if intervalID.Valid {
+		query = `UPDATE maintenance_intervals SET
+			last_service_date = CURRENT_DATE,
+			last_service_mileage = $2
+		WHERE id = $1`
+
+		if _, err = tx.Exec(ctx, query, intervalID.String, mileage); err != nil {
+			m.logger.Error("failed to reset maintenance interval in database", logger.Error(err))
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		m.logger.Error("failed to commit maintenance transaction", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes a scheduled window, completed maintenance is kept as the
+// car's service record.
+func (m *MaintenanceRepo) Delete(ctx context.Context, carID, id string) error {
+	query := `DELETE FROM maintenance WHERE id = $1 AND car_id = $2 AND status = $3`
+
+	tag, err := m.db.Exec(ctx, query, id, carID, config.MAINTENANCE_SCHEDULED)
+	if err != nil {
+		m.logger.Error("failed to delete maintenance from database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		if _, err = m.GetByID(ctx, carID, id); err != nil {
+			return err
+		}
+		return storage.ErrMaintenanceCompleted
+	}
+
+	return nil
+}
+
+func (m *MaintenanceRepo) CheckOverlap(ctx context.Context, carID, fromDate, toDate string) (bool, error) {
+	var exists bool
+
+	err := m.db.QueryRow(ctx, maintenanceOverlapQuery, carID, fromDate, toDate, config.MAINTENANCE_SCHEDULED).Scan(&exists)
+	if err != nil {
+		m.logger.Error("failed to check maintenance overlap in database", logger.Error(err))
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CreateInterval starts counting from the car's current mileage unless the
+// last service is given.
+func (m *MaintenanceRepo) CreateInterval(ctx context.Context, interval models.CreateMaintenanceInterval) (string, error) {
+	id := uuid.New().String()
+
+	query := `INSERT INTO maintenance_intervals (
+		id,
+		car_id,
+		name,
+		every_km,
+		every_days,
+		last_service_date,
+		last_service_mileage,
+		created_at
+	) SELECT $1::uuid, c.id, $3::varchar, NULLIF($4, 0), NULLIF($5, 0),
+		COALESCE(NULLIF($6, '')::date, CURRENT_DATE),
+		COALESCE(NULLIF($7, 0), c.mileage),
+		CURRENT_TIMESTAMP
+	FROM cars c
+	WHERE c.id = $2 AND c.deleted_at = 0`
+
+	tag, err := m.db.Exec(ctx, query,
+		id,
+		interval.CarId,
+		interval.Name,
+		interval.EveryKm,
+		interval.EveryDays,
+		interval.LastServiceDate,
+		interval.LastServiceMileage,
+	)
+
+	if err != nil {
+		m.logger.Error("failed to create maintenance interval in database", logger.Error(err))
+		return "", err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return "", storage.ErrCarNotFound
+	}
+
+	return id, nil
+}
+
+func (m *MaintenanceRepo) GetIntervals(ctx context.Context, carID string) ([]models.MaintenanceInterval, error) {
+	intervals := []models.MaintenanceInterval{}
+
+	query := `SELECT` + intervalColumns + `
+	FROM maintenance_intervals i
+	WHERE i.car_id = $1
+	ORDER BY i.created_at`
+
+	rows, err := m.db.Query(ctx, query, carID)
+	if err != nil {
+		m.logger.Error("failed to get maintenance intervals from database", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		interval, err := scanInterval(rows)
+		if err != nil {
+			m.logger.Error("failed to scan maintenance intervals from database", logger.Error(err))
+			return nil, err
+		}
+
+		intervals = append(intervals, interval)
+	}
+
+	if err = rows.Err(); err != nil {
+		m.logger.Error("failed to get maintenance intervals from database", logger.Error(err))
+		return nil, err
+	}
+
+	return intervals, nil
+}
+
+func (m *MaintenanceRepo) DeleteInterval(ctx context.Context, carID, id string) error {
+	query := `DELETE FROM maintenance_intervals WHERE id = $1 AND car_id = $2`
+
+	tag, err := m.db.Exec(ctx, query, id, carID)
+	if err != nil {
+		m.logger.Error("failed to delete maintenance interval from database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrIntervalNotFound
+	}
+
+	return nil
+}
+
+// GetDue lists intervals that fall due within the given number of days or
+// kilometres, overdue ones first.
+func (m *MaintenanceRepo) GetDue(ctx context.Context, req models.GetDueMaintenanceRequest) (models.GetDueMaintenanceResponse, error) {
+	resp := models.GetDueMaintenanceResponse{Due: []models.DueMaintenance{}}
+
+	query := `SELECT` + intervalColumns + `,
+		c.name,
+		c.mileage,
+		COALESCE(i.last_service_date + i.every_days <= CURRENT_DATE, FALSE)
+			OR COALESCE(c.mileage >= i.last_service_mileage + i.every_km, FALSE) AS overdue,
+		(
+			SELECT m.id::text FROM maintenance m
+			WHERE m.interval_id = i.id AND m.status = $3
+			ORDER BY m.start_date
+			LIMIT 1
+		)
+	FROM maintenance_intervals i
+	JOIN cars c ON c.id = i.car_id AND c.deleted_at = 0
+	WHERE i.last_service_date + i.every_days <= CURRENT_DATE + $1::int
+		OR c.mileage + $2::int >= i.last_service_mileage + i.every_km
+	ORDER BY overdue DESC, i.last_service_date + i.every_days NULLS LAST, c.name`
+
+	rows, err := m.db.Query(ctx, query, req.Days, req.Km, config.MAINTENANCE_SCHEDULED)
+	if err != nil {
+		m.logger.Error("failed to get due maintenance from database", logger.Error(err))
+		return resp, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			due         models.DueMaintenance
+			createdAt   sql.NullString
+			everyKm     sql.NullInt64
+			everyDays   sql.NullInt64
+			dueDate     sql.NullString
+			dueMileage  sql.NullInt64
+			scheduledID sql.NullString
+		)
+
+		err := rows.Scan(
+			&due.Interval.Id,
+			&due.Interval.CarId,
+			&due.Interval.Name,
+			&everyKm,
+			&everyDays,
+			&due.Interval.LastServiceDate,
+			&due.Interval.LastServiceMileage,
+			&dueDate,
+			&dueMileage,
+			&createdAt,
+			&due.CarName,
+			&due.CarMileage,
+			&due.Overdue,
+			&scheduledID,
+		)
+
+		if err != nil {
+			m.logger.Error("failed to scan due maintenance from database", logger.Error(err))
+			return resp, err
+		}
+
+		due.Interval.EveryKm = everyKm.Int64
+		due.Interval.EveryDays = everyDays.Int64
+		due.Interval.DueDate = dueDate.String
+		due.Interval.DueMileage = dueMileage.Int64
+		due.Interval.CreatedAt = createdAt.String
+		due.ScheduledMaintenanceId = scheduledID.String
+
+		resp.Due = append(resp.Due, due)
+	}
+
+	if err = rows.Err(); err != nil {
+		m.logger.Error("failed to get due maintenance from database", logger.Error(err))
+		return resp, err
+	}
+
+	resp.Count = int64(len(resp.Due))
+
+	return resp, nil
+}
+
+// lockScheduled locks a maintenance row that can still be changed and returns
+// the interval it belongs to.
+func (m *MaintenanceRepo) lockScheduled(ctx context.Context, tx pgx.Tx, carID, id string) (sql.NullString, error) {
+	var (
+		status     string
+		intervalID sql.NullString
+	)
+
+	query := `SELECT status, interval_id::text FROM maintenance WHERE id = $1 AND car_id = $2 FOR UPDATE`
+
+	err := tx.QueryRow(ctx, query, id, carID).Scan(&status, &intervalID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sql.NullString{}, storage.ErrMaintenanceNotFound
+		}
+		m.logger.Error("failed to lock maintenance in database", logger.Error(err))
+		return sql.NullString{}, err
+	}
+
+	if status != config.MAINTENANCE_SCHEDULED {
+		return sql.NullString{}, storage.ErrMaintenanceCompleted
+	}
+
+	return intervalID, nil
+}
+
+// maintenanceOverlapQuery tells whether scheduled maintenance keeps the car
+// out on any day of a rental.
+const maintenanceOverlapQuery = `SELECT EXISTS (
+	SELECT 1 FROM maintenance
+	WHERE car_id = $1
+		AND status = $4
+		AND daterange(start_date, end_date + 1, '[)') && daterange($2::date, GREATEST($3::date, $2::date + 1), '[)')
+)`
+
+// checkNotInMaintenance is the order side of checkBooked, run with the car
+// locked so maintenance can not be scheduled over the rental meanwhile.
+func checkNotInMaintenance(ctx context.Context, tx pgx.Tx, carID, fromDate, toDate string) error {
+	var busy bool
+
+	err := tx.QueryRow(ctx, maintenanceOverlapQuery, carID, fromDate, toDate, config.MAINTENANCE_SCHEDULED).Scan(&busy)
+	if err != nil {
+		return err
+	}
+
+	if busy {
+		return storage.ErrCarInMaintenance
+	}
+
+	return nil
+}
+
+func (m *MaintenanceRepo) checkBooked(ctx context.Context, tx pgx.Tx, carID, startDate, endDate string) error {
+	var booked bool
+
+	query := `SELECT EXISTS (
+		SELECT 1 FROM orders
+		WHERE car_id = $1
+			AND deleted_at = 0
+			AND status <> ALL($4)
+			AND daterange(from_date, GREATEST(to_date, from_date + 1), '[)') && daterange($2::date, $3::date + 1, '[)')
+	)`
+
+	err := tx.QueryRow(ctx, query, carID, startDate, endDate, nonBlockingStatuses).Scan(&booked)
+	if err != nil {
+		m.logger.Error("failed to check car bookings in database", logger.Error(err))
+		return err
+	}
+
+	if booked {
+		return storage.ErrMaintenanceConflict
+	}
+
+	return nil
+}
+
+func scanMaintenance(row pgx.Row) (models.Maintenance, error) {
+	var (
+		maintenance models.Maintenance
+		intervalID  sql.NullString
+		mileage     sql.NullInt64
+		cost        sql.NullFloat64
+		notes       sql.NullString
+		completedAt sql.NullString
+		createdAt   sql.NullString
+		updatedAt   sql.NullString
+	)
+
+	err := row.Scan(
+		&maintenance.Id,
+		&maintenance.CarId,
+		&intervalID,
+		&maintenance.Title,
+		&maintenance.Status,
+		&maintenance.StartDate,
+		&maintenance.EndDate,
+		&mileage,
+		&cost,
+		&notes,
+		&completedAt,
+		&createdAt,
+		&updatedAt,
+	)
+
+	if err != nil {
+		return models.Maintenance{}, err
+	}
+
+	maintenance.IntervalId = intervalID.String
+	maintenance.Mileage = mileage.Int64
+	maintenance.Cost = cost.Float64
+	maintenance.Notes = notes.String
+	maintenance.CompletedAt = completedAt.String
+	maintenance.CreatedAt = createdAt.String
+	maintenance.UpdatedAt = updatedAt.String
+
+	return maintenance, nil
+}
+
+func scanInterval(row pgx.Row) (models.MaintenanceInterval, error) {
+	var (
+		interval   models.MaintenanceInterval
+		everyKm    sql.NullInt64
+		everyDays  sql.NullInt64
+		dueDate    sql.NullString
+		dueMileage sql.NullInt64
+		createdAt  sql.NullString
+	)
+
+	err := row.Scan(
+		&interval.Id,
+		&interval.CarId,
+		&interval.Name,
+		&everyKm,
+		&everyDays,
+		&interval.LastServiceDate,
+		&interval.LastServiceMileage,
+		&dueDate,
+		&dueMileage,
+		&createdAt,
+	)
+
+	if err != nil {
+		return models.MaintenanceInterval{}, err
+	}
+
+	interval.EveryKm = everyKm.Int64
+	interval.EveryDays = everyDays.Int64
+	interval.DueDate = dueDate.String
+	interval.DueMileage = dueMileage.Int64
+	interval.CreatedAt = createdAt.String
+
+	return interval, nil
+}
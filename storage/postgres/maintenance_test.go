@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/storage"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenance(t *testing.T) {
+	maintenanceRepo := NewMaintenanceRepo(db, log)
+	carRepo := NewCarRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:    "Workshop",
+		Price:   50,
+		Mileage: 9500,
+	})
+
+	intervalID, err := maintenanceRepo.CreateInterval(context.Background(), models.CreateMaintenanceInterval{
+		CarId:   carID,
+		Name:    "Oil change",
+		EveryKm: 10000,
+	})
+	assert.NoError(t, err)
+
+	intervals, err := maintenanceRepo.GetIntervals(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(intervals))
+	assert.Equal(t, int64(19500), intervals[0].DueMileage)
+
+	start := time.Now().AddDate(0, 0, 30).Format(time.DateOnly)
+	end := time.Now().AddDate(0, 0, 31).Format(time.DateOnly)
+
+	id, err := maintenanceRepo.Create(context.Background(), models.CreateMaintenance{
+		CarId:      carID,
+		IntervalId: intervalID,
+		Title:      "Oil change",
+		StartDate:  start,
+		EndDate:    end,
+	})
+	assert.NoError(t, err)
+
+	busy, err := maintenanceRepo.CheckOverlap(context.Background(), carID, end, end)
+	assert.NoError(t, err)
+	assert.True(t, busy)
+
+	orderRepo := NewOrderRepo(db, log)
+	_, err = orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   end,
+		ToDate:     end,
+		Status:     config.STATUS_NEW,
+		TotalPrice: 50,
+	})
+	assert.ErrorIs(t, err, storage.ErrCarInMaintenance)
+
+	available, err := carRepo.GetAvailable(context.Background(), models.GetAvailableCarsRequest{
+		Search: "Workshop",
+		From:   start,
+		To:     end,
+		Page:   1,
+		Limit:  100,
+	})
+	assert.NoError(t, err)
+	for _, car := range available.Cars {
+		assert.NotEqual(t, carID, car.ID)
+	}
+
+	err = maintenanceRepo.Complete(context.Background(), models.CompleteMaintenance{
+		Id:      id,
+		CarId:   carID,
+		Mileage: 10200,
+		Cost:    120,
+	})
+	assert.NoError(t, err)
+
+	maintenance, err := maintenanceRepo.GetByID(context.Background(), carID, id)
+	assert.NoError(t, err)
+	assert.Equal(t, config.MAINTENANCE_COMPLETED, maintenance.Status)
+	assert.Equal(t, int64(10200), maintenance.Mileage)
+
+	intervals, err = maintenanceRepo.GetIntervals(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20200), intervals[0].DueMileage)
+
+	busy, err = maintenanceRepo.CheckOverlap(context.Background(), carID, start, end)
+	assert.NoError(t, err)
+	assert.False(t, busy)
+
+	err = maintenanceRepo.Delete(context.Background(), carID, id)
+	assert.ErrorIs(t, err, storage.ErrMaintenanceCompleted)
+
+	err = maintenanceRepo.DeleteInterval(context.Background(), carID, intervalID)
+	assert.NoError(t, err)
+
+	intervals, err = maintenanceRepo.GetIntervals(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.Empty(t, intervals)
+
+	// the service history outlives its interval
+	maintenance, err = maintenanceRepo.GetByID(context.Background(), carID, id)
+	assert.NoError(t, err)
+	assert.Empty(t, maintenance.IntervalId)
+}
@@ -3,15 +3,62 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"rent-car/api/models"
+	"rent-car/config"
 	"rent-car/pkg"
 	"rent-car/pkg/logger"
+	"rent-car/pkg/sqlbuilder"
+	"rent-car/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// orders in these statuses do not hold the car, must match orders_car_id_period_excl.
+// An order holds the car from from_date up to but not including to_date, so the
+// car can be handed over again on the day it comes back.
+var nonBlockingStatuses = []string{config.STATUS_RETURNED, config.STATUS_CLOSED, config.STATUS_CANCELLED, config.STATUS_NO_SHOW}
+
+// orderSortColumns are the columns order lists can be sorted by.
+var orderSortColumns = sqlbuilder.Columns{
+	"created_at":  "o.created_at",
+	"from_date":   "o.from_date",
+	"total_price": "o.total_price",
+}
+
+// orderFilters are the filters order lists accept.
+var orderFilters = sqlbuilder.Filters{
+	"search":      {Kind: sqlbuilder.FilterILike, Columns: []string{"c.name", "cu.first_name", "cu.last_name"}},
+	"customer_id": {Kind: sqlbuilder.FilterEquals, Columns: []string{"o.customer_id"}},
+	"status":      {Kind: sqlbuilder.FilterIn, Columns: []string{"o.status"}},
+}
+
+// orderFields are the columns of order lists under the field names of
+// models.GetOrderResponse.
+var orderFields = sqlbuilder.Projection{
+	{Name: "id", Column: "o.id"},
+	{Name: "car", Column: "c.id"},
+	{Name: "car", Column: "c.name"},
+	{Name: "car", Column: "c.brand"},
+	{Name: "customer", Column: "cu.id"},
+	{Name: "customer", Column: "cu.first_name"},
+	{Name: "customer", Column: "cu.last_name"},
+	{Name: "customer", Column: "cu.email"},
+	{Name: "customer", Column: "cu.phone"},
+	{Name: "customer", Column: "cu.address"},
+	{Name: "from_date", Column: "o.from_date"},
+	{Name: "to_date", Column: "o.to_date"},
+	{Name: "status", Column: "o.status"},
+	{Name: "payment_status", Column: "b.payment_status"},
+	{Name: "total_price", Column: "o.total_price"},
+	{Name: "created_at", Column: "o.created_at"},
+	{Name: "updated_at", Column: "o.updated_at"},
+}
+
 type OrderRepo struct {
 	db     *pgxpool.Pool
 	logger logger.ILogger
@@ -36,6 +83,36 @@ func (o *OrderRepo) Create(ctx context.Context, order models.CreateOrder) (strin
 	}
 	orderNumber := pkg.GetSerialId(&max)
 
+	tx, err := o.db.Begin(ctx)
+	if err != nil {
+		o.logger.Error("failed to begin order transaction", logger.Error(err))
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCar(ctx, tx, order.CarId); err != nil {
+		return "", err
+	}
+
+	if err = checkNotInMaintenance(ctx, tx, order.CarId, order.FromDate, order.ToDate); err != nil {
+		if !errors.Is(err, storage.ErrCarInMaintenance) {
+			o.logger.Error("failed to check car maintenance in database", logger.Error(err))
+		}
+		return "", err
+	}
+
+	// the code stays locked until the order is in, so concurrent orders can
+	// not redeem it past its limits
+	var (
+		promo    models.PromoCode
+		discount float64
+	)
+	if order.PromoCode != "" {
+		if promo, discount, err = lockPromo(ctx, tx, order); err != nil {
+			return "", err
+		}
+	}
+
 	query := `INSERT INTO orders (
 		id,
 		order_number,
@@ -44,12 +121,13 @@ func (o *OrderRepo) Create(ctx context.Context, order models.CreateOrder) (strin
 		from_date,
 		to_date,
 		status,
-		payment_status,
+		total_price,
+		discount,
 		created_at,
 		updated_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
-	_, err = o.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		id,
 		orderNumber,
 		order.CarId,
@@ -57,46 +135,156 @@ func (o *OrderRepo) Create(ctx context.Context, order models.CreateOrder) (strin
 		order.FromDate,
 		order.ToDate,
 		order.Status,
-		order.Paid,
+		order.TotalPrice-discount,
+		discount,
 	)
 
 	if err != nil {
+		if isBookingConflict(err) {
+			return "", storage.ErrCarAlreadyBooked
+		}
 		o.logger.Error("failed to create order in database", logger.Error(err))
 		return "", err
 	}
 
+	if promo.Id != "" {
+		if err = redeemPromo(ctx, tx, promo.Id, id, order.CustomerId, discount); err != nil {
+			o.logger.Error("failed to redeem promo code in database", logger.Error(err))
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		o.logger.Error("failed to commit order transaction", logger.Error(err))
+		return "", err
+	}
+
 	return id, nil
 }
 
 func (o *OrderRepo) Update(ctx context.Context, order models.UpdateOrder) (string, error) {
+	tx, err := o.db.Begin(ctx)
+	if err != nil {
+		o.logger.Error("failed to begin order update transaction", logger.Error(err))
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCar(ctx, tx, order.CarId); err != nil {
+		return "", err
+	}
+
+	if err = checkNotInMaintenance(ctx, tx, order.CarId, order.FromDate, order.ToDate); err != nil {
+		if !errors.Is(err, storage.ErrCarInMaintenance) {
+			o.logger.Error("failed to check car maintenance in database", logger.Error(err))
+		}
+		return "", err
+	}
+
+	// a promo code is applied again to the edited order as if it was new, it
+	// has to still be valid for it and its discount follows the new total
+	code, err := releasePromo(ctx, tx, order.Id)
+	if err != nil {
+		o.logger.Error("failed to release promo code in database", logger.Error(err))
+		return "", err
+	}
+
+	var (
+		promo    models.PromoCode
+		discount float64
+	)
+	if code != "" {
+		promo, discount, err = lockPromo(ctx, tx, models.CreateOrder{
+			CarId:      order.CarId,
+			CustomerId: order.CustomerId,
+			TotalPrice: order.TotalPrice,
+			PromoCode:  code,
+			RentalDays: order.RentalDays,
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
 	query := `UPDATE orders SET
 		car_id = $1,
 		customer_id = $2,
 		from_date = $3,
 		to_date = $4,
-		status = $5,
-		payment_status = $6,
+		total_price = $5,
+		discount = $8,
 		updated_at = CURRENT_TIMESTAMP
-	WHERE id = $7 AND deleted_at = 0`
+	WHERE id = $6 AND deleted_at = 0 AND status = ANY($7)`
 
-	_, err := o.db.Exec(ctx, query,
+	tag, err := tx.Exec(ctx, query,
 		order.CarId,
 		order.CustomerId,
 		order.FromDate,
 		order.ToDate,
-		order.Status,
-		order.Paid,
+		order.TotalPrice-discount,
 		order.Id,
+		order.EditableStatuses,
+		discount,
 	)
 
 	if err != nil {
+		if isBookingConflict(err) {
+			return "", storage.ErrCarAlreadyBooked
+		}
 		o.logger.Error("failed to update order in database", logger.Error(err))
 		return "", err
 	}
 
+	// the order is gone, or its status moved on after it was checked
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		query = `SELECT EXISTS (SELECT 1 FROM orders WHERE id = $1 AND deleted_at = 0)`
+		if err = tx.QueryRow(ctx, query, order.Id).Scan(&exists); err != nil {
+			o.logger.Error("failed to check order in database", logger.Error(err))
+			return "", err
+		}
+		if !exists {
+			return "", storage.ErrOrderNotFound
+		}
+		return "", storage.ErrOrderStatusChanged
+	}
+
+	if promo.Id != "" {
+		if err = redeemPromo(ctx, tx, promo.Id, order.Id, order.CustomerId, discount); err != nil {
+			o.logger.Error("failed to redeem promo code in database", logger.Error(err))
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		o.logger.Error("failed to commit order update transaction", logger.Error(err))
+		return "", err
+	}
+
 	return order.Id, nil
 }
 
+func (o *OrderRepo) CheckOverlap(ctx context.Context, carID, fromDate, toDate, excludeID string) (bool, error) {
+	var exists bool
+
+	query := `SELECT EXISTS (
+		SELECT 1 FROM orders
+		WHERE car_id = $1
+			AND deleted_at = 0
+			AND status <> ALL($4)
+			AND daterange(from_date, GREATEST(to_date, from_date + 1), '[)') && daterange($2::date, GREATEST($3::date, $2::date + 1), '[)')
+			AND id::text <> $5
+	)`
+
+	err := o.db.QueryRow(ctx, query, carID, fromDate, toDate, nonBlockingStatuses, excludeID).Scan(&exists)
+	if err != nil {
+		o.logger.Error("failed to check order overlap in database", logger.Error(err))
+		return false, err
+	}
+
+	return exists, nil
+}
+
 func (o *OrderRepo) UpdateStatus(ctx context.Context, status models.UpdateOrderStatus) (models.UpdateStatus, error) {
 	var (
 		updatedOrder   models.UpdateStatus
@@ -112,52 +300,208 @@ func (o *OrderRepo) UpdateStatus(ctx context.Context, status models.UpdateOrderS
 		paid           sql.NullBool
 	)
 
-	query := `SELECT status
-		FROM orders
-		WHERE id = $1`
-
-	err := o.db.QueryRow(ctx, query, status.Id).Scan(
-		&fromStatus,
-	)
-
+	tx, err := o.db.Begin(ctx)
 	if err != nil {
-		o.logger.Error("failed to get order current status in database", logger.Error(err))
+		o.logger.Error("failed to begin order status transaction", logger.Error(err))
 		return models.UpdateStatus{}, err
 	}
+	defer tx.Rollback(ctx)
 
-	query = `UPDATE orders SET
+	query := `UPDATE orders SET
 		status = $2,
 		updated_at = CURRENT_TIMESTAMP
-	WHERE id = $1`
+	WHERE id = $1 AND status = $3 AND deleted_at = 0`
 
-	_, err = o.db.Exec(ctx, query,
+	tag, err := tx.Exec(ctx, query,
 		status.Id,
 		status.Status,
+		status.FromStatus,
 	)
 
 	if err != nil {
+		if isBookingConflict(err) {
+			return models.UpdateStatus{}, storage.ErrCarAlreadyBooked
+		}
 		o.logger.Error("failed to update order STATUS in database", logger.Error(err))
 		return models.UpdateStatus{}, err
 	}
 
+	if tag.RowsAffected() == 0 {
+		return models.UpdateStatus{}, storage.ErrOrderStatusChanged
+	}
+
+	query = `INSERT INTO order_status_history (
+		id,
+		order_id,
+		from_status,
+		to_status,
+		changed_by,
+		changed_by_role,
+		note,
+		created_at
+	) VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, ''), NULLIF($7, ''), CURRENT_TIMESTAMP)
+	RETURNING from_status, to_status`
+
+	err = tx.QueryRow(ctx, query,
+		uuid.New().String(),
+		status.Id,
+		status.FromStatus,
+		status.Status,
+		status.ChangedBy,
+		status.ChangedByRole,
+		status.Note,
+	).Scan(
+		&fromStatus,
+		&toStatus,
+	)
+
+	if err != nil {
+		o.logger.Error("failed to insert order status history in database", logger.Error(err))
+		return models.UpdateStatus{}, err
+	}
+
+	if status.ReturnCharges != nil {
+		// the late fee replaces whatever the late return job charged so far
+		query = `UPDATE orders SET
+			excess_mileage_charge = $2,
+			fuel_charge = $3,
+			total_price = total_price - late_fee + $2 + $3 + $4,
+			late_fee = $4
+		WHERE id = $1`
+
+		_, err = tx.Exec(ctx, query,
+			status.Id,
+			status.ReturnCharges.ExcessMileageCharge,
+			status.ReturnCharges.FuelCharge,
+			status.ReturnCharges.LateFee,
+		)
+
+		if err != nil {
+			o.logger.Error("failed to add return charges in database", logger.Error(err))
+			return models.UpdateStatus{}, err
+		}
+	}
+
+	if status.Cancellation != nil {
+		// the customer now only owes the cancellation fee, whatever was paid
+		// above it is due back
+		query = `UPDATE orders SET total_price = $2 WHERE id = $1`
+
+		_, err = tx.Exec(ctx, query, status.Id, status.Cancellation.Fee)
+		if err != nil {
+			o.logger.Error("failed to apply cancellation fee in database", logger.Error(err))
+			return models.UpdateStatus{}, err
+		}
+
+		query = `INSERT INTO order_cancellations (
+			order_id,
+			reason,
+			hours_before,
+			fee_rate,
+			fee,
+			refund_due,
+			cancelled_by,
+			cancelled_by_role,
+			created_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			GREATEST(COALESCE((SELECT paid_amount FROM order_balances WHERE order_id = $1), 0) - $5, 0),
+			NULLIF($6, '')::uuid, NULLIF($7, ''), CURRENT_TIMESTAMP
+		)
+		RETURNING refund_due`
+
+		var refundDue float64
+		err = tx.QueryRow(ctx, query,
+			status.Id,
+			status.Cancellation.Reason,
+			status.Cancellation.HoursBefore,
+			status.Cancellation.FeeRate,
+			status.Cancellation.Fee,
+			status.ChangedBy,
+			status.ChangedByRole,
+		).Scan(&refundDue)
+
+		if err != nil {
+			o.logger.Error("failed to insert order cancellation in database", logger.Error(err))
+			return models.UpdateStatus{}, err
+		}
+
+		// a cancelled order does not count against the code's limits
+		if status.Status == config.STATUS_CANCELLED {
+			if _, err = releasePromo(ctx, tx, status.Id); err != nil {
+				o.logger.Error("failed to release promo code in database", logger.Error(err))
+				return models.UpdateStatus{}, err
+			}
+		}
+
+		// the refund goes back the way the customer last paid
+		if refundDue > 0 {
+			query = `INSERT INTO payments (order_id, kind, method, amount)
+			SELECT order_id, $2, method, $3
+			FROM payments
+			WHERE order_id = $1 AND kind = ANY($4)
+			ORDER BY created_at DESC
+			LIMIT 1`
+
+			tag, err := tx.Exec(ctx, query,
+				status.Id,
+				config.PAYMENT_REFUND,
+				refundDue,
+				[]string{config.PAYMENT_CHARGE, config.PAYMENT_PARTIAL},
+			)
+			if err != nil {
+				o.logger.Error("failed to record cancellation refund in database", logger.Error(err))
+				return models.UpdateStatus{}, err
+			}
+			if tag.RowsAffected() != 1 {
+				return models.UpdateStatus{}, fmt.Errorf("cancellation refund of order %s has no payment to go back to", status.Id)
+			}
+		}
+	}
+
+	if status.CaptureDeposit {
+		// cars without a deposit amount do not get a hold, the ledger gets the
+		// hold only when it was captured now
+		query = `WITH hold AS (
+			INSERT INTO order_deposits (
+				order_id,
+				amount,
+				status,
+				captured_at
+			)
+			SELECT o.id, c.deposit, $2, CURRENT_TIMESTAMP
+			FROM orders o
+			JOIN cars c ON c.id = o.car_id
+			WHERE o.id = $1 AND c.deposit > 0
+			ON CONFLICT (order_id) DO NOTHING
+			RETURNING order_id, amount
+		)
+		INSERT INTO payments (order_id, kind, amount)
+		SELECT order_id, $3, amount FROM hold`
+
+		_, err = tx.Exec(ctx, query, status.Id, config.DEPOSIT_HELD, config.PAYMENT_DEPOSIT_HOLD)
+		if err != nil {
+			o.logger.Error("failed to capture order deposit in database", logger.Error(err))
+			return models.UpdateStatus{}, err
+		}
+	}
+
 	query = `SELECT order_number, 
                  (SELECT first_name || ' ' || last_name FROM customers WHERE id = orders.customer_id) AS client_full_name,
                  (SELECT phone FROM customers WHERE id = orders.customer_id) AS client_phone,
                  (SELECT price FROM cars WHERE id = orders.car_id) AS price, 
-                 status,
                  (SELECT name FROM cars WHERE id = orders.car_id) AS car_name,
                  from_date, 
                  to_date, 
-                 payment_status AS paid
+                 (SELECT payment_status FROM order_balances WHERE order_id = orders.id) AS paid
              FROM orders 
              WHERE id = $1`
 
-	err = o.db.QueryRow(ctx, query, status.Id).Scan(
+	err = tx.QueryRow(ctx, query, status.Id).Scan(
 		&orderNumber,
 		&clientFullName,
 		&clientPhone,
 		&price,
-		&toStatus,
 		&carName,
 		&fromDate,
 		&toDate,
@@ -169,6 +513,11 @@ func (o *OrderRepo) UpdateStatus(ctx context.Context, status models.UpdateOrderS
 		return models.UpdateStatus{}, err
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		o.logger.Error("failed to commit order status transaction", logger.Error(err))
+		return models.UpdateStatus{}, err
+	}
+
 	updatedOrder.OrderNumber = orderNumber.String
 	updatedOrder.ClientFullName = clientFullName.String
 	updatedOrder.ClientPhone = clientPhone.String
@@ -183,6 +532,74 @@ func (o *OrderRepo) UpdateStatus(ctx context.Context, status models.UpdateOrderS
 	return updatedOrder, nil
 }
 
+func (o *OrderRepo) GetStatusHistory(ctx context.Context, orderID string) (models.GetOrderStatusHistoryResponse, error) {
+	var resp = models.GetOrderStatusHistoryResponse{
+		History: []models.OrderStatusHistory{},
+	}
+
+	query := `SELECT
+		id,
+		order_id,
+		from_status,
+		to_status,
+		changed_by,
+		changed_by_role,
+		note,
+		created_at
+	FROM order_status_history
+	WHERE order_id = $1
+	ORDER BY created_at`
+
+	rows, err := o.db.Query(ctx, query, orderID)
+	if err != nil {
+		o.logger.Error("failed to get order status history from database", logger.Error(err))
+		return resp, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			history       models.OrderStatusHistory
+			changedBy     sql.NullString
+			changedByRole sql.NullString
+			note          sql.NullString
+			createdAt     sql.NullString
+		)
+
+		err := rows.Scan(
+			&history.Id,
+			&history.OrderId,
+			&history.FromStatus,
+			&history.ToStatus,
+			&changedBy,
+			&changedByRole,
+			&note,
+			&createdAt,
+		)
+
+		if err != nil {
+			o.logger.Error("failed to scan order status history from database", logger.Error(err))
+			return resp, err
+		}
+
+		history.ChangedBy = changedBy.String
+		history.ChangedByRole = changedByRole.String
+		history.Note = note.String
+		history.CreatedAt = createdAt.String
+
+		resp.History = append(resp.History, history)
+	}
+
+	if err = rows.Err(); err != nil {
+		o.logger.Error("failed to get order status history from database", logger.Error(err))
+		return resp, err
+	}
+
+	resp.Count = len(resp.History)
+
+	return resp, nil
+}
+
 func (o *OrderRepo) GetByID(ctx context.Context, id string) (models.GetOrderResponse, error) {
 	var (
 		order             = models.GetOrderResponse{}
@@ -197,6 +614,7 @@ func (o *OrderRepo) GetByID(ctx context.Context, id string) (models.GetOrderResp
 		toDate            sql.NullString
 		status            sql.NullString
 		paid              sql.NullBool
+		totalPrice        sql.NullFloat64
 		createdAt         sql.NullString
 		updatedAt         sql.NullString
 	)
@@ -215,12 +633,19 @@ func (o *OrderRepo) GetByID(ctx context.Context, id string) (models.GetOrderResp
 		o.from_date,
 		o.to_date,
 		o.status,
-		o.payment_status,
+		b.payment_status,
+		o.total_price,
+		o.discount,
+		o.excess_mileage_charge,
+		o.fuel_charge,
+		o.is_late,
+		o.late_fee,
 		o.created_at,
 		o.updated_at
 	FROM orders o
 	JOIN cars c ON o.car_id = c.id
 	JOIN customers cu ON o.customer_id = cu.id
+	JOIN order_balances b ON b.order_id = o.id
 	WHERE o.id = $1 AND o.deleted_at = 0`
 
 	row := o.db.QueryRow(ctx, query, id)
@@ -240,11 +665,20 @@ func (o *OrderRepo) GetByID(ctx context.Context, id string) (models.GetOrderResp
 		&toDate,
 		&status,
 		&paid,
+		&totalPrice,
+		&order.Discount,
+		&order.ExcessMileageCharge,
+		&order.FuelCharge,
+		&order.IsLate,
+		&order.LateFee,
 		&createdAt,
 		&updatedAt,
 	)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.GetOrderResponse{}, storage.ErrOrderNotFound
+		}
 		o.logger.Error("failed to get order by ID from database", logger.Error(err))
 		return models.GetOrderResponse{}, err
 	}
@@ -268,53 +702,76 @@ func (o *OrderRepo) GetByID(ctx context.Context, id string) (models.GetOrderResp
 	order.ToDate = toDate.String
 	order.Status = status.String
 	order.Paid = paid.Bool
+	order.TotalPrice = totalPrice.Float64
 	order.CreatedAt = createdAt.String
 	order.UpdatedAt = updatedAt.String
 
 	return order, nil
 }
 
+func (o *OrderRepo) GetCustomerID(ctx context.Context, id string) (string, error) {
+	var customerID sql.NullString
+
+	query := `SELECT customer_id FROM orders WHERE id = $1 AND deleted_at = 0`
+
+	err := o.db.QueryRow(ctx, query, id).Scan(&customerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrOrderNotFound
+		}
+		o.logger.Error("failed to get order customer from database", logger.Error(err))
+		return "", err
+	}
+
+	return customerID.String, nil
+}
+
 func (o *OrderRepo) GetAll(ctx context.Context, req models.GetAllOrdersRequest) (models.GetAllOrdersResponse, error) {
 	var (
-		resp   = models.GetAllOrdersResponse{}
-		filter string
-		count  sql.NullInt64
+		resp  = models.GetAllOrdersResponse{}
+		count sql.NullInt64
 	)
 
-	offset := (req.Page - 1) * req.Limit
+	// customers only ever see their own orders
+	b := sqlbuilder.New().
+		Where("o.deleted_at = 0").
+		Page(req.Page, req.Limit)
 
-	if req.Search != "" {
-		filter = fmt.Sprintf(` AND (c.name ILIKE '%%%v%%' OR cu.first_name ILIKE '%%%v%%' OR cu.last_name ILIKE '%%%v%%')`, req.Search, req.Search, req.Search)
+	err := b.Filter(orderFilters, sqlbuilder.Values{
+		"search":      req.Search,
+		"customer_id": req.CustomerId,
+		"status":      req.Status,
+	})
+	if err != nil {
+		return resp, err
 	}
 
-	filter += fmt.Sprintf(" OFFSET %v LIMIT %v", offset, req.Limit)
-	//fmt.Println("filter: ", filter)
+	// a cursor only continues the default order, an explicit sort pages by offset
+	switch {
+	case len(req.Sort) > 0 && req.Cursor != "":
+		return resp, fmt.Errorf("%w: cursor can not be combined with sort", sqlbuilder.ErrInvalidCursor)
+	case len(req.Sort) > 0:
+		err = b.OrderBy(orderSortColumns, "o.id", req.Sort...)
+	default:
+		err = b.Keyset(req.Cursor, "o.created_at", "o.id")
+	}
+	if err != nil {
+		return resp, err
+	}
 
-	query := `SELECT
-		o.id,
-		c.id AS car_id,
-		c.name AS car_name,
-		c.brand AS car_brand,
-		cu.id AS customer_id,
-		cu.first_name AS customer_first_name,
-		cu.last_name AS customer_last_name,
-		cu.email AS customer_email,
-		cu.phone AS customer_phone,
-		cu.address AS customer_address,
-		o.from_date,
-		o.to_date,
-		o.status,
-		o.payment_status,
-		o.created_at,
-		o.updated_at
+	// the cursor is made from id and created_at, so they are always selected
+	columns, err := orderFields.Columns(req.Fields, "id", "created_at")
+	if err != nil {
+		return resp, err
+	}
+
+	query, args := b.Select(`SELECT ` + columns + `
 		FROM orders o
 		JOIN cars c ON o.car_id = c.id
 		JOIN customers cu ON o.customer_id = cu.id
-		WHERE o.deleted_at = 0` + filter
-
-	//.Println(query)
+		JOIN order_balances b ON b.order_id = o.id`)
 
-	rows, err := o.db.Query(ctx, query)
+	rows, err := o.db.Query(ctx, query, args...)
 	if err != nil {
 		o.logger.Error("failed to get all orders from database", logger.Error(err))
 		return resp, err
@@ -328,8 +785,10 @@ func (o *OrderRepo) GetAll(ctx context.Context, req models.GetAllOrdersRequest)
 		}
 
 		var (
+			carID             sql.NullString
 			carName           sql.NullString
 			carBrand          sql.NullString
+			customerID        sql.NullString
 			customerFirstName sql.NullString
 			customerLastName  sql.NullString
 			customerEmail     sql.NullString
@@ -339,16 +798,17 @@ func (o *OrderRepo) GetAll(ctx context.Context, req models.GetAllOrdersRequest)
 			toDate            sql.NullString
 			status            sql.NullString
 			paid              sql.NullBool
+			totalPrice        sql.NullFloat64
 			createdAt         sql.NullString
 			updatedAt         sql.NullString
 		)
 
 		err := rows.Scan(
 			&order.Id,
-			&order.Car.ID,
+			&carID,
 			&carName,
 			&carBrand,
-			&order.Customer.ID,
+			&customerID,
 			&customerFirstName,
 			&customerLastName,
 			&customerEmail,
@@ -358,6 +818,7 @@ func (o *OrderRepo) GetAll(ctx context.Context, req models.GetAllOrdersRequest)
 			&toDate,
 			&status,
 			&paid,
+			&totalPrice,
 			&createdAt,
 			&updatedAt,
 		)
@@ -367,8 +828,10 @@ func (o *OrderRepo) GetAll(ctx context.Context, req models.GetAllOrdersRequest)
 			return resp, err
 		}
 
+		order.Car.ID = carID.String
 		order.Car.Name = carName.String
 		order.Car.Brand = carBrand.String
+		order.Customer.ID = customerID.String
 		order.Customer.FirstName = customerFirstName.String
 		order.Customer.LastName = customerLastName.String
 		order.Customer.Email = customerEmail.String
@@ -378,6 +841,7 @@ func (o *OrderRepo) GetAll(ctx context.Context, req models.GetAllOrdersRequest)
 		order.ToDate = toDate.String
 		order.Status = status.String
 		order.Paid = paid.Bool
+		order.TotalPrice = totalPrice.Float64
 		order.CreatedAt = createdAt.String
 		order.UpdatedAt = updatedAt.String
 
@@ -389,8 +853,16 @@ func (o *OrderRepo) GetAll(ctx context.Context, req models.GetAllOrdersRequest)
 		return resp, err
 	}
 
-	countQuery := `SELECT COUNT(id) FROM orders`
-	err = o.db.QueryRow(ctx, countQuery).Scan(&count)
+	if n := len(resp.Orders); len(req.Sort) == 0 && req.Limit > 0 && uint64(n) == req.Limit {
+		resp.NextCursor = sqlbuilder.Cursor(resp.Orders[n-1].CreatedAt, resp.Orders[n-1].Id)
+	}
+
+	countQuery, countArgs := b.Count(`SELECT COUNT(*)
+		FROM orders o
+		JOIN cars c ON o.car_id = c.id
+		JOIN customers cu ON o.customer_id = cu.id`)
+
+	err = o.db.QueryRow(ctx, countQuery, countArgs...).Scan(&count)
 	resp.Count = int(count.Int64)
 	if err != nil {
 		o.logger.Error("failed to get count of orders from database", logger.Error(err))
@@ -403,12 +875,16 @@ func (o *OrderRepo) GetAll(ctx context.Context, req models.GetAllOrdersRequest)
 func (o *OrderRepo) Delete(ctx context.Context, id string) error {
 	query := `UPDATE orders SET deleted_at = date_part('epoch', CURRENT_TIMESTAMP)::int WHERE id = $1 AND deleted_at = 0`
 
-	_, err := o.db.Exec(ctx, query, id)
+	tag, err := o.db.Exec(ctx, query, id)
 	if err != nil {
 		o.logger.Error("failed to delete order from database", logger.Error(err))
 		return err
 	}
 
+	if tag.RowsAffected() == 0 {
+		return storage.ErrOrderNotFound
+	}
+
 	return nil
 }
 
@@ -423,3 +899,166 @@ func (o *OrderRepo) DeleteHard(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// GetOverdue returns the orders whose car is still out on or after their
+// to_date, the day it was due back. today comes from the caller's clock, the same one late hours
+// are counted with.
+func (o *OrderRepo) GetOverdue(ctx context.Context, today string) ([]models.LateOrder, error) {
+	orders := []models.LateOrder{}
+
+	query := `SELECT
+		o.id,
+		o.order_number,
+		c.id,
+		c.name,
+		COALESCE(c.price, 0),
+		cu.first_name || ' ' || COALESCE(cu.last_name, ''),
+		cu.email,
+		o.to_date::text
+	FROM orders o
+	JOIN cars c ON c.id = o.car_id
+	JOIN customers cu ON cu.id = o.customer_id
+	WHERE o.status = $1 AND o.deleted_at = 0 AND o.to_date <= $2::date
+	ORDER BY o.to_date`
+
+	rows, err := o.db.Query(ctx, query, config.STATUS_PICKED_UP, today)
+	if err != nil {
+		o.logger.Error("failed to get overdue orders from database", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			order       models.LateOrder
+			orderNumber sql.NullString
+		)
+
+		err := rows.Scan(
+			&order.OrderId,
+			&orderNumber,
+			&order.CarId,
+			&order.CarName,
+			&order.DailyPrice,
+			&order.CustomerName,
+			&order.CustomerEmail,
+			&order.ToDate,
+		)
+
+		if err != nil {
+			o.logger.Error("failed to scan overdue orders from database", logger.Error(err))
+			return nil, err
+		}
+
+		order.OrderNumber = orderNumber.String
+
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		o.logger.Error("failed to get overdue orders from database", logger.Error(err))
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// FlagLate marks an order that is still out as late and sets its late fee,
+// replacing the fee from the previous run in the order total. It reports
+// whether the order was flagged for the first time, so that the customer and
+// staff are told only once.
+func (o *OrderRepo) FlagLate(ctx context.Context, orderID string, lateFee float64) (bool, error) {
+	var first bool
+
+	query := `UPDATE orders o SET
+		is_late = TRUE,
+		total_price = o.total_price - o.late_fee + $2,
+		late_fee = $2,
+		late_notified_at = COALESCE(o.late_notified_at, CURRENT_TIMESTAMP),
+		updated_at = CURRENT_TIMESTAMP
+	FROM (SELECT id, late_notified_at FROM orders WHERE id = $1 FOR UPDATE) previous
+	WHERE o.id = previous.id AND o.status = $3
+	RETURNING previous.late_notified_at IS NULL`
+
+	err := o.db.QueryRow(ctx, query, orderID, lateFee, config.STATUS_PICKED_UP).Scan(&first)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// returned since it was found overdue
+			return false, nil
+		}
+		o.logger.Error("failed to flag late order in database", logger.Error(err))
+		return false, err
+	}
+
+	return first, nil
+}
+
+// HasLateReturn reports whether the car is still out on an overdue rental.
+func (o *OrderRepo) HasLateReturn(ctx context.Context, carID string) (bool, error) {
+	var late bool
+
+	query := `SELECT EXISTS (
+		SELECT 1 FROM orders
+		WHERE car_id = $1 AND is_late AND status = $2 AND deleted_at = 0
+	)`
+
+	if err := o.db.QueryRow(ctx, query, carID, config.STATUS_PICKED_UP).Scan(&late); err != nil {
+		o.logger.Error("failed to check late returns in database", logger.Error(err))
+		return false, err
+	}
+
+	return late, nil
+}
+
+func (o *OrderRepo) GetCancellation(ctx context.Context, orderID string) (models.OrderCancellation, error) {
+	var (
+		cancellation    models.OrderCancellation
+		cancelledBy     sql.NullString
+		cancelledByRole sql.NullString
+		createdAt       sql.NullString
+	)
+
+	query := `SELECT
+		order_id,
+		reason,
+		hours_before,
+		fee_rate,
+		fee,
+		refund_due,
+		cancelled_by,
+		cancelled_by_role,
+		created_at
+	FROM order_cancellations
+	WHERE order_id = $1`
+
+	err := o.db.QueryRow(ctx, query, orderID).Scan(
+		&cancellation.OrderId,
+		&cancellation.Reason,
+		&cancellation.HoursBefore,
+		&cancellation.FeeRate,
+		&cancellation.Fee,
+		&cancellation.RefundDue,
+		&cancelledBy,
+		&cancelledByRole,
+		&createdAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OrderCancellation{}, storage.ErrCancellationNotFound
+		}
+		o.logger.Error("failed to get order cancellation from database", logger.Error(err))
+		return models.OrderCancellation{}, err
+	}
+
+	cancellation.CancelledBy = cancelledBy.String
+	cancellation.CancelledByRole = cancelledByRole.String
+	cancellation.CreatedAt = createdAt.String
+
+	return cancellation, nil
+}
+
+func isBookingConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23P01"
+}
@@ -3,24 +3,30 @@ package postgres
 import (
 	"context"
 	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/sqlbuilder"
+	"rent-car/storage"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreateOrder(t *testing.T) {
 	orderRepo := NewOrderRepo(db, log)
 
+	carID := createTestCar(t, models.CreateCarRequest{Name: "Bookable"})
+
 	reqOrder := models.CreateOrder{
-		CarId:      CariD,
+		CarId:      carID,
 		CustomerId: CustomeriD,
 		FromDate:   time.Now().Format(time.RFC3339),
 		ToDate:     time.Now().AddDate(0, 0, 5).Format(time.RFC3339),
 		Status:     "active",
-		Paid:       false,
+		TotalPrice: 250,
 	}
 
 	id, err := orderRepo.Create(context.Background(), reqOrder)
@@ -32,22 +38,31 @@ func TestCreateOrder(t *testing.T) {
 	assert.Equal(t, reqOrder.CarId, createdOrder.Car.ID)
 	assert.Equal(t, reqOrder.CustomerId, createdOrder.Customer.ID)
 	assert.Equal(t, reqOrder.Status, createdOrder.Status)
-	assert.Equal(t, reqOrder.Paid, createdOrder.Paid)
+	assert.False(t, createdOrder.Paid)
+	assert.Equal(t, reqOrder.TotalPrice, createdOrder.TotalPrice)
 }
 
 func TestUpdateOrder(t *testing.T) {
 	orderRepo := NewOrderRepo(db, log)
 
+	order, err := orderRepo.GetByID(context.Background(), OrderiD)
+	assert.NoError(t, err)
+
 	updateOrder := models.UpdateOrder{
 		Id:         OrderiD,
 		CarId:      CariD,
 		CustomerId: CustomeriD,
 		FromDate:   time.Now().AddDate(0, 0, 2).Format(time.RFC3339),
 		ToDate:     time.Now().AddDate(0, 0, 7).Format(time.RFC3339),
-		Status:     "active",
-		Paid:       true,
+		TotalPrice: 300,
+		// a status the order is not in leaves it untouched
+		EditableStatuses: []string{config.STATUS_CLOSED},
 	}
 
+	_, err = orderRepo.Update(context.Background(), updateOrder)
+	assert.ErrorIs(t, err, storage.ErrOrderStatusChanged)
+
+	updateOrder.EditableStatuses = []string{order.Status}
 	updatedID, err := orderRepo.Update(context.Background(), updateOrder)
 	assert.NoError(t, err)
 	assert.Equal(t, updateOrder.Id, updatedID)
@@ -56,8 +71,53 @@ func TestUpdateOrder(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, updateOrder.Id, updatedOrder.Id)
-	assert.Equal(t, updateOrder.Status, updatedOrder.Status)
-	assert.Equal(t, updateOrder.Paid, updatedOrder.Paid)
+	assert.Equal(t, order.Status, updatedOrder.Status)
+	assert.Equal(t, updateOrder.TotalPrice, updatedOrder.TotalPrice)
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	orderRepo := NewOrderRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{Name: "Bookable"})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().Format(time.RFC3339),
+		ToDate:     time.Now().AddDate(0, 0, 5).Format(time.RFC3339),
+		Status:     config.STATUS_NEW,
+	})
+	assert.NoError(t, err)
+
+	updated, err := orderRepo.UpdateStatus(context.Background(), models.UpdateOrderStatus{
+		Id:            orderID,
+		Status:        config.STATUS_CONFIRMED,
+		Note:          "documents checked",
+		FromStatus:    config.STATUS_NEW,
+		ChangedBy:     CustomeriD,
+		ChangedByRole: config.CUSTOMER_ROLE,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, config.STATUS_NEW, updated.FromStatus)
+	assert.Equal(t, config.STATUS_CONFIRMED, updated.ToStatus)
+
+	_, err = orderRepo.UpdateStatus(context.Background(), models.UpdateOrderStatus{
+		Id:         orderID,
+		Status:     config.STATUS_CANCELLED,
+		FromStatus: config.STATUS_NEW,
+	})
+	assert.ErrorIs(t, err, storage.ErrOrderStatusChanged)
+
+	history, err := orderRepo.GetStatusHistory(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, history.Count)
+	assert.Equal(t, config.STATUS_NEW, history.History[0].FromStatus)
+	assert.Equal(t, config.STATUS_CONFIRMED, history.History[0].ToStatus)
+	assert.Equal(t, CustomeriD, history.History[0].ChangedBy)
+	assert.Equal(t, "documents checked", history.History[0].Note)
+
+	err = orderRepo.DeleteHard(context.Background(), orderID)
+	assert.NoError(t, err)
 }
 
 func TestGetByIDOrder(t *testing.T) {
@@ -73,7 +133,7 @@ func TestGetByIDOrder(t *testing.T) {
 
 func TestGetAllOrder(t *testing.T) {
 	orderRepo := NewOrderRepo(db, log)
-	customerRepo := NewCustomerRepo(db, log)
+	customerRepo := NewCustomerRepo(db, log, cache)
 	carRepo := NewCarRepo(db, log)
 
 	for i := 0; i < 5; i++ {
@@ -107,7 +167,6 @@ func TestGetAllOrder(t *testing.T) {
 			FromDate:   time.Now().Format(time.RFC3339),
 			ToDate:     time.Now().AddDate(0, 1, 5).Format(time.RFC3339),
 			Status:     "active",
-			Paid:       false,
 		}
 
 		_, err = orderRepo.Create(context.Background(), reqOrder)
@@ -133,22 +192,354 @@ func TestGetAllOrder(t *testing.T) {
 	}
 }
 
+func TestCreateOrderOverlap(t *testing.T) {
+	orderRepo := NewOrderRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{Name: "Bookable"})
+
+	firstID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().AddDate(0, 0, 10).Format(time.RFC3339),
+		ToDate:     time.Now().AddDate(0, 0, 15).Format(time.RFC3339),
+		Status:     "active",
+	})
+	assert.NoError(t, err)
+
+	overlap, err := orderRepo.CheckOverlap(context.Background(), carID,
+		time.Now().AddDate(0, 0, 12).Format(time.RFC3339),
+		time.Now().AddDate(0, 0, 20).Format(time.RFC3339), "")
+	assert.NoError(t, err)
+	assert.True(t, overlap)
+
+	// the car can go out again on the day it comes back
+	overlap, err = orderRepo.CheckOverlap(context.Background(), carID,
+		time.Now().AddDate(0, 0, 15).Format(time.RFC3339),
+		time.Now().AddDate(0, 0, 18).Format(time.RFC3339), "")
+	assert.NoError(t, err)
+	assert.False(t, overlap)
+
+	_, err = orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().AddDate(0, 0, 12).Format(time.RFC3339),
+		ToDate:     time.Now().AddDate(0, 0, 20).Format(time.RFC3339),
+		Status:     "active",
+	})
+	assert.ErrorIs(t, err, storage.ErrCarAlreadyBooked)
+
+	cancelledID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().AddDate(0, 0, 12).Format(time.RFC3339),
+		ToDate:     time.Now().AddDate(0, 0, 20).Format(time.RFC3339),
+		Status:     config.STATUS_CANCELLED,
+	})
+	assert.NoError(t, err)
+
+	err = orderRepo.DeleteHard(context.Background(), cancelledID)
+	assert.NoError(t, err)
+	err = orderRepo.DeleteHard(context.Background(), firstID)
+	assert.NoError(t, err)
+}
+
 func TestDeleteOrder(t *testing.T) {
 	orderRepo := NewOrderRepo(db, log)
 
+	carID := createTestCar(t, models.CreateCarRequest{Name: "Bookable"})
+
 	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
-		CarId:      CariD,
+		CarId:      carID,
 		CustomerId: CustomeriD,
 		FromDate:   time.Now().Format(time.RFC3339),
 		ToDate:     time.Now().AddDate(0, 0, 5).Format(time.RFC3339),
 		Status:     "active",
-		Paid:       false,
 	})
 	assert.NoError(t, err)
 
 	err = orderRepo.Delete(context.Background(), orderID)
 	assert.NoError(t, err)
 
+	err = orderRepo.Delete(context.Background(), orderID)
+	assert.ErrorIs(t, err, storage.ErrOrderNotFound)
+
 	_, err = orderRepo.GetByID(context.Background(), orderID)
 	assert.Error(t, err)
 }
+
+func TestLateReturns(t *testing.T) {
+	orderRepo := NewOrderRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "Overdue",
+		Price: 40,
+	})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().AddDate(0, 0, -5).Format(time.DateOnly),
+		ToDate:     time.Now().AddDate(0, 0, -2).Format(time.DateOnly),
+		Status:     config.STATUS_PICKED_UP,
+		TotalPrice: 120,
+	})
+	assert.NoError(t, err)
+
+	overdue, err := orderRepo.GetOverdue(context.Background(), time.Now().Format(time.DateOnly))
+	assert.NoError(t, err)
+
+	found := false
+	for _, order := range overdue {
+		if order.OrderId == orderID {
+			found = true
+			assert.Equal(t, 40.0, order.DailyPrice)
+		}
+	}
+	assert.True(t, found)
+
+	first, err := orderRepo.FlagLate(context.Background(), orderID, 60)
+	assert.NoError(t, err)
+	assert.True(t, first)
+
+	first, err = orderRepo.FlagLate(context.Background(), orderID, 90)
+	assert.NoError(t, err)
+	assert.False(t, first)
+
+	order, err := orderRepo.GetByID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.True(t, order.IsLate)
+	assert.Equal(t, 90.0, order.LateFee)
+	assert.Equal(t, 210.0, order.TotalPrice)
+
+	late, err := orderRepo.HasLateReturn(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.True(t, late)
+}
+
+func TestBackToBackLateReturn(t *testing.T) {
+	orderRepo := NewOrderRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "Back to back",
+		Price: 40,
+	})
+
+	today := time.Now().Format(time.DateOnly)
+
+	previousID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().AddDate(0, 0, -3).Format(time.DateOnly),
+		ToDate:     today,
+		Status:     config.STATUS_PICKED_UP,
+		TotalPrice: 120,
+	})
+	assert.NoError(t, err)
+
+	// the return day is free, the next rental may start on it
+	_, err = orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   today,
+		ToDate:     time.Now().AddDate(0, 0, 2).Format(time.DateOnly),
+		Status:     config.STATUS_NEW,
+		TotalPrice: 80,
+	})
+	assert.NoError(t, err)
+
+	// so the car is overdue on that day already, not from the next one
+	overdue, err := orderRepo.GetOverdue(context.Background(), today)
+	assert.NoError(t, err)
+
+	found := false
+	for _, order := range overdue {
+		if order.OrderId == previousID {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	_, err = orderRepo.FlagLate(context.Background(), previousID, 4)
+	assert.NoError(t, err)
+
+	late, err := orderRepo.HasLateReturn(context.Background(), carID)
+	assert.NoError(t, err)
+	assert.True(t, late)
+}
+
+func TestCancelOrder(t *testing.T) {
+	orderRepo := NewOrderRepo(db, log)
+	paymentRepo := NewPaymentRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "Cancelled",
+		Price: 50,
+	})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().AddDate(0, 0, 1).Format(time.DateOnly),
+		ToDate:     time.Now().AddDate(0, 0, 4).Format(time.DateOnly),
+		Status:     config.STATUS_CONFIRMED,
+		TotalPrice: 200,
+	})
+	assert.NoError(t, err)
+
+	_, err = paymentRepo.Create(context.Background(), models.CreatePayment{
+		OrderId: orderID,
+		Kind:    config.PAYMENT_CHARGE,
+		Method:  config.METHOD_CARD,
+		Amount:  200,
+	})
+	assert.NoError(t, err)
+
+	_, err = orderRepo.UpdateStatus(context.Background(), models.UpdateOrderStatus{
+		Id:         orderID,
+		Status:     config.STATUS_CANCELLED,
+		FromStatus: config.STATUS_CONFIRMED,
+		Note:       "plans changed",
+		Cancellation: &models.OrderCancellation{
+			Reason:      "plans changed",
+			HoursBefore: 20,
+			FeeRate:     config.CANCEL_LATE_FEE,
+			Fee:         100,
+		},
+	})
+	assert.NoError(t, err)
+
+	cancellation, err := orderRepo.GetCancellation(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, "plans changed", cancellation.Reason)
+	assert.Equal(t, 100.0, cancellation.Fee)
+	assert.Equal(t, 100.0, cancellation.RefundDue)
+
+	// the refund is in the ledger, leaving the fee paid
+	balance, err := paymentRepo.GetBalance(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, balance.RefundedAmount)
+	assert.Equal(t, 100.0, balance.PaidAmount)
+	assert.Equal(t, 0.0, balance.Outstanding)
+
+	payments, err := paymentRepo.GetByOrderID(context.Background(), orderID)
+	assert.NoError(t, err)
+
+	var refunds []models.Payment
+	for _, payment := range payments.Payments {
+		if payment.Kind == config.PAYMENT_REFUND {
+			refunds = append(refunds, payment)
+		}
+	}
+	if assert.Len(t, refunds, 1) {
+		assert.Equal(t, config.METHOD_CARD, refunds[0].Method)
+		assert.Equal(t, 100.0, refunds[0].Amount)
+	}
+
+	order, err := orderRepo.GetByID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, order.TotalPrice)
+
+	// the car is free again for the cancelled window
+	overlaps, err := orderRepo.CheckOverlap(context.Background(), carID, order.FromDate, order.ToDate, "")
+	assert.NoError(t, err)
+	assert.False(t, overlaps)
+
+	_, err = orderRepo.GetCancellation(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, storage.ErrCancellationNotFound)
+}
+
+func TestNoShowOrder(t *testing.T) {
+	orderRepo := NewOrderRepo(db, log)
+	paymentRepo := NewPaymentRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "No show",
+		Price: 50,
+	})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().AddDate(0, 0, -1).Format(time.DateOnly),
+		ToDate:     time.Now().AddDate(0, 0, 3).Format(time.DateOnly),
+		Status:     config.STATUS_CONFIRMED,
+		TotalPrice: 200,
+	})
+	assert.NoError(t, err)
+
+	_, err = paymentRepo.Create(context.Background(), models.CreatePayment{
+		OrderId: orderID,
+		Kind:    config.PAYMENT_CHARGE,
+		Method:  config.METHOD_TRANSFER,
+		Amount:  200,
+	})
+	assert.NoError(t, err)
+
+	_, err = orderRepo.UpdateStatus(context.Background(), models.UpdateOrderStatus{
+		Id:         orderID,
+		Status:     config.STATUS_NO_SHOW,
+		FromStatus: config.STATUS_CONFIRMED,
+		Cancellation: &models.OrderCancellation{
+			Reason:      "customer did not show up",
+			HoursBefore: -24,
+			FeeRate:     0.75,
+			Fee:         150,
+		},
+	})
+	assert.NoError(t, err)
+
+	cancellation, err := orderRepo.GetCancellation(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 150.0, cancellation.Fee)
+	assert.Equal(t, 50.0, cancellation.RefundDue)
+
+	// the refund goes back by transfer, the way the order was paid
+	payments, err := paymentRepo.GetByOrderID(context.Background(), orderID)
+	assert.NoError(t, err)
+
+	var refunds []models.Payment
+	for _, payment := range payments.Payments {
+		if payment.Kind == config.PAYMENT_REFUND {
+			refunds = append(refunds, payment)
+		}
+	}
+	if assert.Len(t, refunds, 1) {
+		assert.Equal(t, config.METHOD_TRANSFER, refunds[0].Method)
+		assert.Equal(t, 50.0, refunds[0].Amount)
+	}
+
+	assert.Equal(t, 50.0, payments.Balance.RefundedAmount)
+	assert.Equal(t, 0.0, payments.Balance.Outstanding)
+}
+
+func TestGetAllOrdersCursor(t *testing.T) {
+	orderRepo := NewOrderRepo(db, log)
+
+	req := models.GetAllOrdersRequest{CustomerId: CustomeriD, Page: 1, Limit: 2}
+
+	page, err := orderRepo.GetAll(context.Background(), req)
+	assert.NoError(t, err)
+
+	total := page.Count
+	seen := map[string]bool{}
+
+	for {
+		for _, order := range page.Orders {
+			assert.False(t, seen[order.Id], "order %s was returned twice", order.Id)
+			seen[order.Id] = true
+		}
+		if page.NextCursor == "" {
+			break
+		}
+
+		req.Cursor = page.NextCursor
+		page, err = orderRepo.GetAll(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, total, page.Count)
+	}
+
+	assert.Equal(t, total, len(seen))
+
+	_, err = orderRepo.GetAll(context.Background(), models.GetAllOrdersRequest{Page: 1, Limit: 2, Cursor: "bogus"})
+	assert.ErrorIs(t, err, sqlbuilder.ErrInvalidCursor)
+}
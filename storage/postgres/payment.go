@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PaymentRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewPaymentRepo(db *pgxpool.Pool, log logger.ILogger) PaymentRepo {
+	return PaymentRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (p *PaymentRepo) Create(ctx context.Context, payment models.CreatePayment) (string, error) {
+	id := uuid.New().String()
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.logger.Error("failed to begin payment transaction", logger.Error(err))
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	// lock the order so concurrent refunds see each other
+	query := `SELECT id FROM orders WHERE id = $1 AND deleted_at = 0 FOR UPDATE`
+
+	var orderID string
+	err = tx.QueryRow(ctx, query, payment.OrderId).Scan(&orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrOrderNotFound
+		}
+		p.logger.Error("failed to lock order for payment", logger.Error(err))
+		return "", err
+	}
+
+	if payment.Kind == config.PAYMENT_REFUND {
+		var paid float64
+
+		query = `SELECT paid_amount FROM order_balances WHERE order_id = $1`
+		err = tx.QueryRow(ctx, query, payment.OrderId).Scan(&paid)
+		if err != nil {
+			p.logger.Error("failed to get order paid amount", logger.Error(err))
+			return "", err
+		}
+
+		if payment.Amount > paid {
+			return "", storage.ErrRefundExceedsPaid
+		}
+	}
+
+	query = `INSERT INTO payments (
+		id,
+		order_id,
+		kind,
+		method,
+		amount,
+		reference,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), CURRENT_TIMESTAMP)`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		payment.OrderId,
+		payment.Kind,
+		payment.Method,
+		payment.Amount,
+		payment.Reference,
+	)
+
+	if err != nil {
+		p.logger.Error("failed to create payment in database", logger.Error(err))
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.logger.Error("failed to commit payment transaction", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (p *PaymentRepo) GetByOrderID(ctx context.Context, orderID string) (models.GetOrderPaymentsResponse, error) {
+	var resp = models.GetOrderPaymentsResponse{
+		Payments: []models.Payment{},
+	}
+
+	query := `SELECT
+		id,
+		order_id,
+		kind,
+		method,
+		amount,
+		reference,
+		created_at
+	FROM payments
+	WHERE order_id = $1
+	ORDER BY created_at`
+
+	rows, err := p.db.Query(ctx, query, orderID)
+	if err != nil {
+		p.logger.Error("failed to get order payments from database", logger.Error(err))
+		return resp, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			payment   models.Payment
+			method    sql.NullString
+			reference sql.NullString
+			createdAt sql.NullString
+		)
+
+		err := rows.Scan(
+			&payment.Id,
+			&payment.OrderId,
+			&payment.Kind,
+			&method,
+			&payment.Amount,
+			&reference,
+			&createdAt,
+		)
+
+		if err != nil {
+			p.logger.Error("failed to scan order payments from database", logger.Error(err))
+			return resp, err
+		}
+
+		payment.Method = method.String
+		payment.Reference = reference.String
+		payment.CreatedAt = createdAt.String
+
+		resp.Payments = append(resp.Payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logger.Error("failed to get order payments from database", logger.Error(err))
+		return resp, err
+	}
+
+	resp.Count = len(resp.Payments)
+
+	resp.Balance, err = p.GetBalance(ctx, orderID)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func (p *PaymentRepo) GetBalance(ctx context.Context, orderID string) (models.OrderBalance, error) {
+	var balance models.OrderBalance
+
+	query := `SELECT
+		order_id,
+		total_price,
+		paid_amount,
+		refunded_amount,
+		outstanding,
+		payment_status,
+		deposit_held,
+		deposit_withheld
+	FROM order_balances
+	WHERE order_id = $1`
+
+	err := p.db.QueryRow(ctx, query, orderID).Scan(
+		&balance.OrderId,
+		&balance.TotalPrice,
+		&balance.PaidAmount,
+		&balance.RefundedAmount,
+		&balance.Outstanding,
+		&balance.PaymentStatus,
+		&balance.DepositHeld,
+		&balance.DepositWithheld,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OrderBalance{}, storage.ErrOrderNotFound
+		}
+		p.logger.Error("failed to get order balance from database", logger.Error(err))
+		return models.OrderBalance{}, err
+	}
+
+	return balance, nil
+}
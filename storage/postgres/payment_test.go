@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/storage"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePayment(t *testing.T) {
+	paymentRepo := NewPaymentRepo(db, log)
+	orderRepo := NewOrderRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "Payable",
+		Price: 50,
+	})
+
+	orderID, err := orderRepo.Create(context.Background(), models.CreateOrder{
+		CarId:      carID,
+		CustomerId: CustomeriD,
+		FromDate:   time.Now().Format(time.RFC3339),
+		ToDate:     time.Now().AddDate(0, 0, 4).Format(time.RFC3339),
+		Status:     config.STATUS_NEW,
+		TotalPrice: 200,
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		payment models.CreatePayment
+		err     error
+	}{
+		{"Partial payment", models.CreatePayment{OrderId: orderID, Kind: config.PAYMENT_PARTIAL, Method: config.METHOD_CARD, Amount: 120, Reference: "POS-1"}, nil},
+		{"Refund more than paid", models.CreatePayment{OrderId: orderID, Kind: config.PAYMENT_REFUND, Method: config.METHOD_CARD, Amount: 150}, storage.ErrRefundExceedsPaid},
+		{"Refund", models.CreatePayment{OrderId: orderID, Kind: config.PAYMENT_REFUND, Method: config.METHOD_CARD, Amount: 20}, nil},
+		{"Charge", models.CreatePayment{OrderId: orderID, Kind: config.PAYMENT_CHARGE, Method: config.METHOD_TRANSFER, Amount: 100}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := paymentRepo.Create(context.Background(), tc.payment)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	payments, err := paymentRepo.GetByOrderID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, payments.Count)
+	assert.Equal(t, 200.0, payments.Balance.PaidAmount)
+	assert.Equal(t, 20.0, payments.Balance.RefundedAmount)
+	assert.Equal(t, 0.0, payments.Balance.Outstanding)
+	assert.True(t, payments.Balance.PaymentStatus)
+
+	order, err := orderRepo.GetByID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.True(t, order.Paid)
+
+	err = orderRepo.DeleteHard(context.Background(), orderID)
+	assert.NoError(t, err)
+}
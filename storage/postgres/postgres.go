@@ -56,6 +56,12 @@ func (s Store) Car() storage.ICarStorage {
 	return &newCar
 }
 
+func (s Store) CarPhoto() storage.ICarPhotoStorage {
+	newCarPhoto := NewCarPhotoRepo(s.Pool, s.logger)
+
+	return &newCarPhoto
+}
+
 func (s Store) Customer() storage.ICustomerStorage {
 	newCustomer := NewCustomerRepo(s.Pool, s.logger, s.redis)
 
@@ -68,6 +74,54 @@ func (s Store) Order() storage.IOrderStorage {
 	return &newOrder
 }
 
+func (s Store) Payment() storage.IPaymentStorage {
+	newPayment := NewPaymentRepo(s.Pool, s.logger)
+
+	return &newPayment
+}
+
+func (s Store) Deposit() storage.IDepositStorage {
+	newDeposit := NewDepositRepo(s.Pool, s.logger)
+
+	return &newDeposit
+}
+
+func (s Store) Inspection() storage.IInspectionStorage {
+	newInspection := NewInspectionRepo(s.Pool, s.logger)
+
+	return &newInspection
+}
+
+func (s Store) Damage() storage.IDamageStorage {
+	newDamage := NewDamageRepo(s.Pool, s.logger)
+
+	return &newDamage
+}
+
+func (s Store) Maintenance() storage.IMaintenanceStorage {
+	newMaintenance := NewMaintenanceRepo(s.Pool, s.logger)
+
+	return &newMaintenance
+}
+
+func (s Store) Promo() storage.IPromoStorage {
+	newPromo := NewPromoRepo(s.Pool, s.logger)
+
+	return &newPromo
+}
+
+func (s Store) Admin() storage.IAdminStorage {
+	newAdmin := NewAdminRepo(s.Pool, s.logger)
+
+	return &newAdmin
+}
+
+func (s Store) Document() storage.IDocumentStorage {
+	newDocument := NewDocumentRepo(s.Pool, s.logger)
+
+	return &newDocument
+}
+
 func (s Store) Redis() storage.IRedisStorage {
 	return redis.New(s.cfg)
 }
@@ -0,0 +1,396 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/pkg/logger"
+	"rent-car/pkg/sqlbuilder"
+	"rent-car/storage"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// promoSortColumns are the columns promo code lists can be sorted by.
+var promoSortColumns = sqlbuilder.Columns{
+	"created_at": "created_at",
+	"code":       "code",
+	"uses":       "uses",
+}
+
+// promoFilters are the filters promo code lists accept.
+var promoFilters = sqlbuilder.Filters{
+	"search": {Kind: sqlbuilder.FilterILike, Columns: []string{"code"}},
+}
+
+type PromoRepo struct {
+	db     *pgxpool.Pool
+	logger logger.ILogger
+}
+
+func NewPromoRepo(db *pgxpool.Pool, log logger.ILogger) PromoRepo {
+	return PromoRepo{
+		db:     db,
+		logger: log,
+	}
+}
+
+const promoColumns = `
+		id,
+		code,
+		discount_type,
+		discount_value,
+		valid_from::text,
+		valid_to::text,
+		max_uses,
+		max_uses_per_customer,
+		min_rental_days,
+		brand,
+		model,
+		uses,
+		active,
+		created_at,
+		updated_at`
+
+func (p *PromoRepo) Create(ctx context.Context, promo models.CreatePromoCode) (string, error) {
+	id := uuid.New().String()
+
+	query := `INSERT INTO promo_codes (
+		id,
+		code,
+		discount_type,
+		discount_value,
+		valid_from,
+		valid_to,
+		max_uses,
+		max_uses_per_customer,
+		min_rental_days,
+		brand,
+		model,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, NULLIF($5, '')::date, NULLIF($6, '')::date, NULLIF($7, 0), NULLIF($8, 0), $9,
+		NULLIF($10, ''), NULLIF($11, ''), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err := p.db.Exec(ctx, query,
+		id,
+		promo.Code,
+		promo.DiscountType,
+		promo.DiscountValue,
+		promo.ValidFrom,
+		promo.ValidTo,
+		promo.MaxUses,
+		promo.MaxUsesPerCustomer,
+		promo.MinRentalDays,
+		promo.Brand,
+		promo.Model,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return "", storage.ErrPromoCodeExists
+		}
+		p.logger.Error("failed to create promo code in database", logger.Error(err))
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (p *PromoRepo) GetByID(ctx context.Context, id string) (models.PromoCode, error) {
+	query := `SELECT` + promoColumns + `
+	FROM promo_codes
+	WHERE id = $1`
+
+	promo, err := scanPromo(p.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PromoCode{}, storage.ErrPromoNotFound
+		}
+		p.logger.Error("failed to get promo code from database", logger.Error(err))
+		return models.PromoCode{}, err
+	}
+
+	return promo, nil
+}
+
+func (p *PromoRepo) GetAll(ctx context.Context, req models.GetAllPromoCodesRequest) (models.GetAllPromoCodesResponse, error) {
+	resp := models.GetAllPromoCodesResponse{PromoCodes: []models.PromoCode{}}
+
+	b := sqlbuilder.New().
+		Page(req.Page, req.Limit)
+
+	if err := b.Filter(promoFilters, sqlbuilder.Values{"search": req.Search}); err != nil {
+		return resp, err
+	}
+
+	// active=false filters too, so it can not be left off on the zero value
+	if req.Active != "" {
+		b.Where("active = ?", req.Active == "true")
+	}
+
+	if err := b.OrderBy(promoSortColumns, "id", "-created_at"); err != nil {
+		return resp, err
+	}
+
+	query, args := b.Select(`SELECT` + promoColumns + `
+	FROM promo_codes`)
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		p.logger.Error("failed to get all promo codes from database", logger.Error(err))
+		return resp, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		promo, err := scanPromo(rows)
+		if err != nil {
+			p.logger.Error("failed to scan promo codes from database", logger.Error(err))
+			return resp, err
+		}
+
+		resp.PromoCodes = append(resp.PromoCodes, promo)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logger.Error("failed to get all promo codes from database", logger.Error(err))
+		return resp, err
+	}
+
+	countQuery, countArgs := b.Count(`SELECT COUNT(*) FROM promo_codes`)
+
+	if err = p.db.QueryRow(ctx, countQuery, countArgs...).Scan(&resp.Count); err != nil {
+		p.logger.Error("failed to get promo codes count from database", logger.Error(err))
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func (p *PromoRepo) Update(ctx context.Context, promo models.UpdatePromoCode) error {
+	query := `UPDATE promo_codes SET
+		discount_type = $2,
+		discount_value = $3,
+		valid_from = NULLIF($4, '')::date,
+		valid_to = NULLIF($5, '')::date,
+		max_uses = NULLIF($6, 0),
+		max_uses_per_customer = NULLIF($7, 0),
+		min_rental_days = $8,
+		brand = NULLIF($9, ''),
+		model = NULLIF($10, ''),
+		active = $11,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	tag, err := p.db.Exec(ctx, query,
+		promo.Id,
+		promo.DiscountType,
+		promo.DiscountValue,
+		promo.ValidFrom,
+		promo.ValidTo,
+		promo.MaxUses,
+		promo.MaxUsesPerCustomer,
+		promo.MinRentalDays,
+		promo.Brand,
+		promo.Model,
+		promo.Active,
+	)
+
+	if err != nil {
+		p.logger.Error("failed to update promo code in database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrPromoNotFound
+	}
+
+	return nil
+}
+
+// Delete deactivates the code, redeemed codes are kept for the orders that
+// used them.
+func (p *PromoRepo) Delete(ctx context.Context, id string) error {
+	query := `UPDATE promo_codes SET active = FALSE, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	tag, err := p.db.Exec(ctx, query, id)
+	if err != nil {
+		p.logger.Error("failed to delete promo code in database", logger.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrPromoNotFound
+	}
+
+	return nil
+}
+
+// lockPromo locks the promo code for the rest of the transaction, checks it
+// against the order and returns the discount it gives off the order total.
+func lockPromo(ctx context.Context, tx pgx.Tx, order models.CreateOrder) (models.PromoCode, float64, error) {
+	var inWindow bool
+
+	query := `SELECT` + promoColumns + `,
+		(valid_from IS NULL OR valid_from <= CURRENT_DATE) AND (valid_to IS NULL OR valid_to >= CURRENT_DATE)
+	FROM promo_codes
+	WHERE code = $1
+	FOR UPDATE`
+
+	promo, err := scanPromo(tx.QueryRow(ctx, query, order.PromoCode), &inWindow)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PromoCode{}, 0, storage.ErrPromoNotFound
+		}
+		return models.PromoCode{}, 0, err
+	}
+
+	if !promo.Active || !inWindow {
+		return models.PromoCode{}, 0, storage.ErrPromoNotValid
+	}
+
+	if promo.MaxUses > 0 && promo.Uses >= promo.MaxUses {
+		return models.PromoCode{}, 0, storage.ErrPromoExhausted
+	}
+
+	if promo.MaxUsesPerCustomer > 0 {
+		var uses int
+
+		query = `SELECT COUNT(*) FROM promo_redemptions WHERE promo_code_id = $1 AND customer_id = $2`
+
+		if err = tx.QueryRow(ctx, query, promo.Id, order.CustomerId).Scan(&uses); err != nil {
+			return models.PromoCode{}, 0, err
+		}
+		if uses >= promo.MaxUsesPerCustomer {
+			return models.PromoCode{}, 0, storage.ErrPromoExhausted
+		}
+	}
+
+	if order.RentalDays < promo.MinRentalDays {
+		return models.PromoCode{}, 0, storage.ErrPromoNotApplicable
+	}
+
+	if promo.Brand != "" || promo.Model != "" {
+		var brand, model sql.NullString
+
+		query = `SELECT brand, model FROM cars WHERE id = $1 AND deleted_at = 0`
+
+		if err = tx.QueryRow(ctx, query, order.CarId).Scan(&brand, &model); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.PromoCode{}, 0, storage.ErrCarNotFound
+			}
+			return models.PromoCode{}, 0, err
+		}
+
+		if promo.Brand != "" && !strings.EqualFold(promo.Brand, brand.String) ||
+			promo.Model != "" && !strings.EqualFold(promo.Model, model.String) {
+			return models.PromoCode{}, 0, storage.ErrPromoNotApplicable
+		}
+	}
+
+	return promo, promoDiscount(promo, order.TotalPrice), nil
+}
+
+// redeemPromo counts a use of the promo code locked by lockPromo against the order.
+func redeemPromo(ctx context.Context, tx pgx.Tx, promoID, orderID, customerID string, discount float64) error {
+	query := `INSERT INTO promo_redemptions (
+		id,
+		promo_code_id,
+		order_id,
+		customer_id,
+		discount,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)`
+
+	_, err := tx.Exec(ctx, query, uuid.New().String(), promoID, orderID, customerID, discount)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE promo_codes SET uses = uses + 1 WHERE id = $1`, promoID)
+	return err
+}
+
+// releasePromo takes back the order's redemption, if it has one, and returns
+// the released code.
+func releasePromo(ctx context.Context, tx pgx.Tx, orderID string) (string, error) {
+	var code string
+
+	query := `WITH released AS (
+		DELETE FROM promo_redemptions WHERE order_id = $1 RETURNING promo_code_id
+	)
+	UPDATE promo_codes p SET uses = p.uses - 1
+	FROM released r
+	WHERE p.id = r.promo_code_id
+	RETURNING p.code`
+
+	err := tx.QueryRow(ctx, query, orderID).Scan(&code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return code, err
+}
+
+// promoDiscount never takes more off than the order costs.
+func promoDiscount(promo models.PromoCode, total float64) float64 {
+	discount := promo.DiscountValue
+	if promo.DiscountType == config.PROMO_PERCENT {
+		discount = total * promo.DiscountValue / 100
+	}
+	return math.Round(math.Min(discount, total)*100) / 100
+}
+
+func scanPromo(row pgx.Row, extra ...interface{}) (models.PromoCode, error) {
+	var (
+		promo              models.PromoCode
+		validFrom          sql.NullString
+		validTo            sql.NullString
+		maxUses            sql.NullInt64
+		maxUsesPerCustomer sql.NullInt64
+		brand              sql.NullString
+		model              sql.NullString
+		createdAt          sql.NullString
+		updatedAt          sql.NullString
+	)
+
+	dest := append([]interface{}{
+		&promo.Id,
+		&promo.Code,
+		&promo.DiscountType,
+		&promo.DiscountValue,
+		&validFrom,
+		&validTo,
+		&maxUses,
+		&maxUsesPerCustomer,
+		&promo.MinRentalDays,
+		&brand,
+		&model,
+		&promo.Uses,
+		&promo.Active,
+		&createdAt,
+		&updatedAt,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return models.PromoCode{}, err
+	}
+
+	promo.ValidFrom = validFrom.String
+	promo.ValidTo = validTo.String
+	promo.MaxUses = int(maxUses.Int64)
+	promo.MaxUsesPerCustomer = int(maxUsesPerCustomer.Int64)
+	promo.Brand = brand.String
+	promo.Model = model.String
+	promo.CreatedAt = createdAt.String
+	promo.UpdatedAt = updatedAt.String
+
+	return promo, nil
+}
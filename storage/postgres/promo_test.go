@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"rent-car/api/models"
+	"rent-car/config"
+	"rent-car/storage"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeemPromoCode(t *testing.T) {
+	promoRepo := NewPromoRepo(db, log)
+	orderRepo := NewOrderRepo(db, log)
+
+	carID := createTestCar(t, models.CreateCarRequest{
+		Name:  "Promoted",
+		Brand: "Chevrolet",
+		Price: 50,
+	})
+
+	code := "SUMMER-" + strings.ToUpper(uuid.New().String()[:8])
+
+	promoID, err := promoRepo.Create(context.Background(), models.CreatePromoCode{
+		Code:          code,
+		DiscountType:  config.PROMO_PERCENT,
+		DiscountValue: 10,
+		ValidFrom:     time.Now().AddDate(0, 0, -1).Format(time.DateOnly),
+		MaxUses:       1,
+		MinRentalDays: 3,
+		Brand:         "chevrolet",
+	})
+	assert.NoError(t, err)
+
+	_, err = promoRepo.Create(context.Background(), models.CreatePromoCode{
+		Code:          code,
+		DiscountType:  config.PROMO_FIXED,
+		DiscountValue: 10,
+	})
+	assert.ErrorIs(t, err, storage.ErrPromoCodeExists)
+
+	order := func(days int) models.CreateOrder {
+		start := time.Now().AddDate(0, 1, 0)
+		return models.CreateOrder{
+			CarId:      carID,
+			CustomerId: CustomeriD,
+			FromDate:   start.Format(time.DateOnly),
+			ToDate:     start.AddDate(0, 0, days).Format(time.DateOnly),
+			Status:     config.STATUS_NEW,
+			PromoCode:  code,
+			TotalPrice: float64(days) * 50,
+			RentalDays: days,
+		}
+	}
+
+	_, err = orderRepo.Create(context.Background(), order(2))
+	assert.ErrorIs(t, err, storage.ErrPromoNotApplicable)
+
+	orderID, err := orderRepo.Create(context.Background(), order(4))
+	assert.NoError(t, err)
+
+	created, err := orderRepo.GetByID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, created.Discount)
+	assert.Equal(t, 180.0, created.TotalPrice)
+
+	promo, err := promoRepo.GetByID(context.Background(), promoID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, promo.Uses)
+
+	next := order(5)
+	next.FromDate = time.Now().AddDate(0, 2, 0).Format(time.DateOnly)
+	next.ToDate = time.Now().AddDate(0, 2, 5).Format(time.DateOnly)
+	_, err = orderRepo.Create(context.Background(), next)
+	assert.ErrorIs(t, err, storage.ErrPromoExhausted)
+
+	edit := func(days int) models.UpdateOrder {
+		o := order(days)
+		return models.UpdateOrder{
+			Id:               orderID,
+			CarId:            o.CarId,
+			CustomerId:       o.CustomerId,
+			FromDate:         o.FromDate,
+			ToDate:           o.ToDate,
+			TotalPrice:       o.TotalPrice,
+			RentalDays:       o.RentalDays,
+			EditableStatuses: []string{config.STATUS_NEW},
+		}
+	}
+
+	_, err = orderRepo.Update(context.Background(), edit(2))
+	assert.ErrorIs(t, err, storage.ErrPromoNotApplicable)
+
+	_, err = orderRepo.Update(context.Background(), edit(6))
+	assert.NoError(t, err)
+
+	updated, err := orderRepo.GetByID(context.Background(), orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, updated.Discount)
+	assert.Equal(t, 270.0, updated.TotalPrice)
+
+	promo, err = promoRepo.GetByID(context.Background(), promoID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, promo.Uses)
+
+	_, err = orderRepo.UpdateStatus(context.Background(), models.UpdateOrderStatus{
+		Id:           orderID,
+		Status:       config.STATUS_CANCELLED,
+		FromStatus:   config.STATUS_NEW,
+		Cancellation: &models.OrderCancellation{Reason: "plans changed"},
+	})
+	assert.NoError(t, err)
+
+	promo, err = promoRepo.GetByID(context.Background(), promoID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, promo.Uses)
+
+	_, err = orderRepo.Create(context.Background(), next)
+	assert.NoError(t, err)
+
+	assert.NoError(t, promoRepo.Delete(context.Background(), promoID))
+
+	next.PromoCode = "NO-SUCH-CODE"
+	_, err = orderRepo.Create(context.Background(), next)
+	assert.ErrorIs(t, err, storage.ErrPromoNotFound)
+}
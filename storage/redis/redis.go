@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"rent-car/config"
 	"rent-car/storage"
+	"strconv"
 
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -52,3 +54,61 @@ func (s Store) Del(ctx context.Context, key string) error {
 	fmt.Println("Deleted from redis cache")
 	return nil
 }
+
+func (s Store) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := s.db.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Pop deletes key and reports whether it was there, so only one of several
+// concurrent callers can consume the same key.
+func (s Store) Pop(ctx context.Context, key string) (bool, error) {
+	n, err := s.db.Del(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Incr increments the counter at key, starting its expiry on the first increment.
+func (s Store) Incr(ctx context.Context, key string, duration time.Duration) (int64, error) {
+	n, err := s.db.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if n == 1 {
+		if err := s.db.Expire(ctx, key, duration).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// Hit records a request at key and returns how many requests were recorded
+// within the last window, sliding the window forward on every call.
+func (s Store) Hit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	now := time.Now()
+
+	pipe := s.db.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now.Add(-window).UnixMicro(), 10))
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixMicro()), Member: uuid.New().String()})
+	count := pipe.ZCard(ctx, key)
+	pipe.Expire(ctx, key, window)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return count.Val(), nil
+}
+
+// Lock claims key for duration and reports whether this caller got it. The
+// claim is left to expire so that other instances skip work already done.
+func (s Store) Lock(ctx context.Context, key string, duration time.Duration) (bool, error) {
+	return s.db.SetNX(ctx, key, uuid.New().String(), duration).Result()
+}
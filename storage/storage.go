@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"io"
 	"rent-car/api/models"
 	"time"
 )
@@ -9,8 +10,17 @@ import (
 type IStorage interface {
 	CloseDB()
 	Car() ICarStorage
+	CarPhoto() ICarPhotoStorage
 	Customer() ICustomerStorage
 	Order() IOrderStorage
+	Payment() IPaymentStorage
+	Deposit() IDepositStorage
+	Inspection() IInspectionStorage
+	Damage() IDamageStorage
+	Maintenance() IMaintenanceStorage
+	Promo() IPromoStorage
+	Admin() IAdminStorage
+	Document() IDocumentStorage
 	Redis() IRedisStorage
 }
 
@@ -23,10 +33,27 @@ type ICarStorage interface {
 	Delete(ctx context.Context, id string) error
 }
 
+type ICarPhotoStorage interface {
+	Create(ctx context.Context, photo models.CreateCarPhoto) (string, error)
+	GetByID(ctx context.Context, id string) (models.CarPhoto, error)
+	GetByCarID(ctx context.Context, carID string) ([]models.CarPhoto, error)
+	SetPrimary(ctx context.Context, carID, photoID string) error
+	Reorder(ctx context.Context, req models.ReorderCarPhotos) error
+	Delete(ctx context.Context, carID, photoID string) (models.CarPhoto, error)
+}
+
 type ICustomerStorage interface {
 	Create(ctx context.Context, customer models.CreateCustomer) (string, error)
 	Update(ctx context.Context, customer models.UpdateCustomer, id string) (string, error)
 	ChangePassword(ctx context.Context, pass models.ChangePassword) (string, error)
+	ResetPassword(ctx context.Context, email string, password string) (string, error)
+	Block(ctx context.Context, req models.BlockCustomer) error
+	Unblock(ctx context.Context, req models.UnblockCustomer) error
+	GetBlockStatus(ctx context.Context, id string) (models.CustomerBlockStatus, error)
+	GetBlocks(ctx context.Context, id string) (models.GetCustomerBlocksResponse, error)
+	UpdateLicence(ctx context.Context, req models.UpdateCustomerLicence) error
+	ReviewVerification(ctx context.Context, req models.ReviewCustomerVerification) error
+	GetVerification(ctx context.Context, id string) (models.CustomerVerification, error)
 	GetByID(ctx context.Context, id string) (models.Customer, error)
 	GetAll(ctx context.Context, req models.GetAllCustomersRequest) (models.GetAllCustomersResponse, error)
 	GetCustomerCars(ctx context.Context, name string, id string, boolean bool) (models.GetCustomerCarsResponse, error)
@@ -39,15 +66,97 @@ type ICustomerStorage interface {
 type IOrderStorage interface {
 	Create(ctx context.Context, order models.CreateOrder) (string, error)
 	Update(ctx context.Context, order models.UpdateOrder) (string, error)
+	CheckOverlap(ctx context.Context, carID, fromDate, toDate, excludeID string) (bool, error)
 	UpdateStatus(ctx context.Context, status models.UpdateOrderStatus) (models.UpdateStatus, error)
+	GetStatusHistory(ctx context.Context, orderID string) (models.GetOrderStatusHistoryResponse, error)
 	GetByID(ctx context.Context, id string) (models.GetOrderResponse, error)
+	GetCustomerID(ctx context.Context, id string) (string, error)
 	GetAll(ctx context.Context, req models.GetAllOrdersRequest) (models.GetAllOrdersResponse, error)
 	Delete(ctx context.Context, id string) error
 	DeleteHard(ctx context.Context, id string) error
+	GetOverdue(ctx context.Context, today string) ([]models.LateOrder, error)
+	FlagLate(ctx context.Context, orderID string, lateFee float64) (bool, error)
+	HasLateReturn(ctx context.Context, carID string) (bool, error)
+	GetCancellation(ctx context.Context, orderID string) (models.OrderCancellation, error)
+}
+
+type IPaymentStorage interface {
+	Create(ctx context.Context, payment models.CreatePayment) (string, error)
+	GetByOrderID(ctx context.Context, orderID string) (models.GetOrderPaymentsResponse, error)
+	GetBalance(ctx context.Context, orderID string) (models.OrderBalance, error)
+}
+
+type IDepositStorage interface {
+	Release(ctx context.Context, req models.ReleaseDeposit) (models.OrderDeposit, error)
+	GetByOrderID(ctx context.Context, orderID string) (models.OrderDeposit, error)
+	GetAll(ctx context.Context, req models.GetAllDepositsRequest) (models.GetAllDepositsResponse, error)
+}
+
+type IInspectionStorage interface {
+	Create(ctx context.Context, inspection models.CreateOrderInspection) (string, error)
+	GetByOrderID(ctx context.Context, orderID string) ([]models.OrderInspection, error)
+}
+
+type IDamageStorage interface {
+	Create(ctx context.Context, damage models.CreateDamageReport) (string, error)
+	GetByID(ctx context.Context, orderID, id string) (models.DamageReport, error)
+	GetByOrderID(ctx context.Context, orderID string) ([]models.DamageReport, error)
+	GetByCarID(ctx context.Context, carID string) ([]models.DamageReport, error)
+	Update(ctx context.Context, damage models.UpdateDamageReport) error
+	CreatePhoto(ctx context.Context, photo models.CreateDamagePhoto) (string, error)
+	GetPhoto(ctx context.Context, damageID, id string) (models.DamagePhoto, error)
+}
+
+type IMaintenanceStorage interface {
+	Create(ctx context.Context, maintenance models.CreateMaintenance) (string, error)
+	GetByID(ctx context.Context, carID, id string) (models.Maintenance, error)
+	GetAll(ctx context.Context, carID string) (models.GetAllMaintenanceResponse, error)
+	Update(ctx context.Context, maintenance models.UpdateMaintenance) error
+	Complete(ctx context.Context, req models.CompleteMaintenance) error
+	Delete(ctx context.Context, carID, id string) error
+	CheckOverlap(ctx context.Context, carID, fromDate, toDate string) (bool, error)
+	CreateInterval(ctx context.Context, interval models.CreateMaintenanceInterval) (string, error)
+	GetIntervals(ctx context.Context, carID string) ([]models.MaintenanceInterval, error)
+	DeleteInterval(ctx context.Context, carID, id string) error
+	GetDue(ctx context.Context, req models.GetDueMaintenanceRequest) (models.GetDueMaintenanceResponse, error)
+}
+
+type IPromoStorage interface {
+	Create(ctx context.Context, promo models.CreatePromoCode) (string, error)
+	GetByID(ctx context.Context, id string) (models.PromoCode, error)
+	GetAll(ctx context.Context, req models.GetAllPromoCodesRequest) (models.GetAllPromoCodesResponse, error)
+	Update(ctx context.Context, promo models.UpdatePromoCode) error
+	Delete(ctx context.Context, id string) error
+}
+
+type IAdminStorage interface {
+	Create(ctx context.Context, admin models.CreateAdmin) (string, error)
+	GetByLogin(ctx context.Context, login string) (models.Admin, error)
+	Count(ctx context.Context) (int64, error)
+}
+
+type IDocumentStorage interface {
+	Create(ctx context.Context, document models.CreateDocument) (string, error)
+	GetByID(ctx context.Context, id string) (models.Document, error)
+	GetAll(ctx context.Context, req models.GetAllDocumentsRequest) (models.GetAllDocumentsResponse, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// IFileStorage keeps the contents of uploaded files, the database only holds
+// their metadata and the key they were stored under.
+type IFileStorage interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
 }
 
 type IRedisStorage interface {
 	SetX(ctx context.Context, key string, value interface{}, duration time.Duration) error
 	Get(ctx context.Context, key string) (interface{}, error)
 	Del(ctx context.Context, key string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	Pop(ctx context.Context, key string) (bool, error)
+	Incr(ctx context.Context, key string, duration time.Duration) (int64, error)
+	Hit(ctx context.Context, key string, window time.Duration) (int64, error)
+	Lock(ctx context.Context, key string, duration time.Duration) (bool, error)
 }